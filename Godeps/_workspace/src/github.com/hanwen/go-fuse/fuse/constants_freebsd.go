@@ -1,5 +0,0 @@
-package fuse
-
-// arbitrary values
-const syscall_O_LARGEFILE = 1 << 29
-const syscall_O_NOATIME = 1 << 30
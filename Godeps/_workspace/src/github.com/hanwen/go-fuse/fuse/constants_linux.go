@@ -1,8 +0,0 @@
-package fuse
-
-import (
-	"syscall"
-)
-
-const syscall_O_LARGEFILE = syscall.O_LARGEFILE
-const syscall_O_NOATIME = syscall.O_NOATIME
@@ -1,9 +0,0 @@
-package nodefs
-
-import "time"
-
-func splitDuration(dt time.Duration, secs *uint64, nsecs *uint32) {
-	ns := int64(dt)
-	*nsecs = uint32(ns % 1e9)
-	*secs = uint64(ns / 1e9)
-}
@@ -1,9 +0,0 @@
-package fuse
-
-const outputHeaderSize = 200
-
-const (
-	_FUSE_KERNEL_VERSION   = 7
-	_MINIMUM_MINOR_VERSION = 8
-	_OUR_MINOR_VERSION     = 8
-)
@@ -1,5 +0,0 @@
-package fuse
-
-func (a *Attr) String() string {
-	return Print((*Attr)(a))
-}
@@ -1,3 +1,7 @@
+// Copyright 2016 the Go-FUSE Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
 package fuse
 
 import (
@@ -14,6 +18,9 @@ const (
 
 	FUSE_LK_FLOCK = (1 << 0)
 
+	FUSE_RELEASE_FLUSH        = (1 << 0)
+	FUSE_RELEASE_FLOCK_UNLOCK = (1 << 1)
+
 	FUSE_IOCTL_MAX_IOV = 256
 
 	FUSE_POLL_SCHEDULE_NOTIFY = (1 << 0)
@@ -28,4 +35,10 @@ const (
 	CUSE_INIT = 4096
 
 	O_ANYWRITE = uint32(os.O_WRONLY | os.O_RDWR | os.O_APPEND | os.O_CREATE | os.O_TRUNC)
+
+	// FMODE_EXEC is a passed to OPEN requests if the file is
+	// being executed.
+	FMODE_EXEC = 0x20
+
+	logicalBlockSize = 512
 )
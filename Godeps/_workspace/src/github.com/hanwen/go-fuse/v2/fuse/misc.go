@@ -1,3 +1,7 @@
+// Copyright 2016 the Go-FUSE Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
 // Random odds and ends.
 
 package fuse
@@ -6,9 +10,8 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"reflect"
 	"syscall"
-	"unsafe"
+	"time"
 )
 
 func (code Status) String() string {
@@ -18,9 +21,9 @@ func (code Status) String() string {
 			"NOTIFY_POLL",
 			"NOTIFY_INVAL_INODE",
 			"NOTIFY_INVAL_ENTRY",
-			"NOTIFY_INVAL_STORE",
-			"NOTIFY_INVAL_RETRIEVE",
-			"NOTIFY_INVAL_DELETE",
+			"NOTIFY_STORE_CACHE",
+			"NOTIFY_RETRIEVE_CACHE",
+			"NOTIFY_DELETE",
 		}[-code]
 	}
 	return fmt.Sprintf("%d=%v", int(code), syscall.Errno(code))
@@ -60,11 +63,6 @@ func ToStatus(err error) Status {
 	return ENOSYS
 }
 
-func toSlice(dest *[]byte, ptr unsafe.Pointer, byteCount uintptr) {
-	h := (*reflect.SliceHeader)(unsafe.Pointer(dest))
-	*h = reflect.SliceHeader{uintptr(ptr), int(byteCount), int(byteCount)}
-}
-
 func CurrentOwner() *Owner {
 	return &Owner{
 		Uid: uint32(os.Getuid()),
@@ -72,9 +70,20 @@ func CurrentOwner() *Owner {
 	}
 }
 
-func init() {
-	p := syscall.Getpagesize()
-	if p != PAGESIZE {
-		log.Panicf("page size incorrect: %d", p)
+// UtimeToTimespec converts a "Time" pointer as passed to Utimens to a
+// "Timespec" that can be passed to the utimensat syscall.
+// A nil pointer is converted to the special UTIME_OMIT value.
+//
+// Deprecated: use unix.TimeToTimespec from the x/sys/unix package instead.
+func UtimeToTimespec(t *time.Time) (ts syscall.Timespec) {
+	if t == nil {
+		ts.Nsec = _UTIME_OMIT
+	} else {
+		ts = syscall.NsecToTimespec(t.UnixNano())
+		// Go bug https://github.com/golang/go/issues/12777
+		if ts.Nsec < 0 {
+			ts.Nsec = 0
+		}
 	}
+	return ts
 }
@@ -0,0 +1,4 @@
+package fuse
+
+// Ref: https://github.com/apple/darwin-xnu/blob/main/bsd/sys/stat.h#L576
+const _UTIME_OMIT = -2
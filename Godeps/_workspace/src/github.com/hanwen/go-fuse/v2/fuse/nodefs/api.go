@@ -1,3 +1,10 @@
+// Copyright 2016 the Go-FUSE Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This package is deprecated. New projects should use the package
+// "github.com/hanwen/go-fuse/v2/fs" instead.
+//
 // The nodefs package offers a high level API that resembles the
 // kernel's idea of what an FS looks like.  File systems can have
 // multiple hard-links to one file, for example. It is also suited if
@@ -8,7 +15,7 @@ package nodefs
 import (
 	"time"
 
-	"github.com/hanwen/go-fuse/fuse"
+	"github.com/hanwen/go-fuse/v2/fuse"
 )
 
 // The Node interface implements the user-defined file system
@@ -34,17 +41,24 @@ type Node interface {
 	OnUnmount()
 
 	// Lookup finds a child node to this node; it is only called
-	// for directory Nodes.
+	// for directory Nodes. Lookup may be called on nodes that are
+	// already known.
 	Lookup(out *fuse.Attr, name string, context *fuse.Context) (*Inode, fuse.Status)
 
-	// Deletable() should return true if this inode may be
-	// discarded from the children list. This will be called from
-	// within the treeLock critical section, so you cannot look at
-	// other inodes.
+	// Deletable() should return true if this node may be discarded once
+	// the kernel forgets its reference.
+	// If it returns false, OnForget will never get called for this node. This
+	// is appropriate if the filesystem has no persistent backing store
+	// (in-memory filesystems) where discarding the node loses the stored data.
+	// Deletable will be called from within the treeLock critical section, so you
+	// cannot look at other nodes.
 	Deletable() bool
 
-	// OnForget is called when the reference to this inode is
-	// dropped from the tree.
+	// OnForget is called when the kernel forgets its reference to this node and
+	// sends a FORGET request. It should perform cleanup and free memory as
+	// appropriate for the filesystem.
+	// OnForget is not called if the node is a directory and has children.
+	// This is called from within a treeLock critical section.
 	OnForget()
 
 	// Misc.
@@ -92,6 +106,17 @@ type Node interface {
 	SetXAttr(attr string, data []byte, flags int, context *fuse.Context) fuse.Status
 	ListXAttr(context *fuse.Context) (attrs []string, code fuse.Status)
 
+	// File locking
+	//
+	// GetLk returns existing lock information for file.
+	GetLk(file File, owner uint64, lk *fuse.FileLock, flags uint32, out *fuse.FileLock, context *fuse.Context) (code fuse.Status)
+
+	// Sets or clears the lock described by lk on file.
+	SetLk(file File, owner uint64, lk *fuse.FileLock, flags uint32, context *fuse.Context) (code fuse.Status)
+
+	// Sets or clears the lock described by lk. This call blocks until the operation can be completed.
+	SetLkw(file File, owner uint64, lk *fuse.FileLock, flags uint32, context *fuse.Context) (code fuse.Status)
+
 	// Attributes
 	GetAttr(out *fuse.Attr, file File, context *fuse.Context) (code fuse.Status)
 	Chmod(file File, perms uint32, context *fuse.Context) (code fuse.Status)
@@ -107,7 +132,9 @@ type Node interface {
 // FileSystem.Create.  Include the NewDefaultFile return value into
 // the struct to inherit a null implementation.
 type File interface {
-	// Called upon registering the filehandle in the inode.
+	// Called upon registering the filehandle in the inode. This
+	// is useful in that PathFS API, where Create/Open have no
+	// access to the Inode at hand.
 	SetInode(*Inode)
 
 	// The String method is for debug printing.
@@ -120,6 +147,11 @@ type File interface {
 	Read(dest []byte, off int64) (fuse.ReadResult, fuse.Status)
 	Write(data []byte, off int64) (written uint32, code fuse.Status)
 
+	// File locking
+	GetLk(owner uint64, lk *fuse.FileLock, flags uint32, out *fuse.FileLock) (code fuse.Status)
+	SetLk(owner uint64, lk *fuse.FileLock, flags uint32) (code fuse.Status)
+	SetLkw(owner uint64, lk *fuse.FileLock, flags uint32) (code fuse.Status)
+
 	// Flush is called for close() call on a file descriptor. In
 	// case of duplicated descriptor, it may be called more than
 	// once for a file.
@@ -166,13 +198,18 @@ type Options struct {
 	NegativeTimeout time.Duration
 
 	// If set, replace all uids with given UID.
-	// NewFileSystemOptions() will set this to the daemon's
+	// NewOptions() will set this to the daemon's
 	// uid/gid.
 	*fuse.Owner
 
-	// If set, use a more portable, but slower inode number
-	// generation scheme.  This will make inode numbers (exported
-	// back to callers) stay within int32, which is necessary for
-	// making stat() succeed in 32-bit programs.
+	// This option exists for compatibility and is ignored.
 	PortableInodes bool
+
+	// If set, print debug information.
+	Debug bool
+
+	// If set, issue Lookup rather than GetAttr calls for known
+	// children. This allows the filesystem to update its inode
+	// hierarchy in response to kernel calls.
+	LookupKnownChildren bool
 }
@@ -1,3 +1,7 @@
+// Copyright 2016 the Go-FUSE Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
 package nodefs
 
 import (
@@ -5,7 +9,8 @@ import (
 	"time"
 	"unsafe"
 
-	"github.com/hanwen/go-fuse/fuse"
+	"github.com/hanwen/go-fuse/v2/fuse"
+	"github.com/hanwen/go-fuse/v2/internal/utimens"
 )
 
 func (f *loopbackFile) Allocate(off uint64, sz uint64, mode uint32) fuse.Status {
@@ -61,28 +66,32 @@ func (f *loopbackFile) Allocate(off uint64, sz uint64, mode uint32) fuse.Status
 	return fuse.OK
 }
 
-const _UTIME_NOW = ((1 << 30) - 1)
-const _UTIME_OMIT = ((1 << 30) - 2)
+// timeToTimeval - Convert time.Time to syscall.Timeval
+//
+// Note: This does not use syscall.NsecToTimespec because
+// that does not work properly for times before 1970,
+// see https://github.com/golang/go/issues/12777
+func timeToTimeval(t *time.Time) syscall.Timeval {
+	var tv syscall.Timeval
+	tv.Usec = int32(t.Nanosecond() / 1000)
+	tv.Sec = t.Unix()
+	return tv
+}
 
+// MacOS before High Sierra lacks utimensat() and UTIME_OMIT.
+// We emulate using utimes() and extra GetAttr() calls.
 func (f *loopbackFile) Utimens(a *time.Time, m *time.Time) fuse.Status {
-	tv := make([]syscall.Timeval, 2)
-	if a == nil {
-		tv[0].Usec = _UTIME_OMIT
-	} else {
-		n := a.UnixNano()
-		tv[0] = syscall.NsecToTimeval(n)
-	}
-
-	if m == nil {
-		tv[1].Usec = _UTIME_OMIT
-	} else {
-		n := m.UnixNano()
-		tv[1] = syscall.NsecToTimeval(n)
+	var attr fuse.Attr
+	if a == nil || m == nil {
+		var status fuse.Status
+		status = f.GetAttr(&attr)
+		if !status.Ok() {
+			return status
+		}
 	}
-
+	tv := utimens.Fill(a, m, &attr)
 	f.lock.Lock()
 	err := syscall.Futimes(int(f.File.Fd()), tv)
 	f.lock.Unlock()
-
 	return fuse.ToStatus(err)
 }
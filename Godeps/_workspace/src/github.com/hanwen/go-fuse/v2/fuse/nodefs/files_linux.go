@@ -1,10 +1,14 @@
+// Copyright 2016 the Go-FUSE Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
 package nodefs
 
 import (
 	"syscall"
 	"time"
 
-	"github.com/hanwen/go-fuse/fuse"
+	"github.com/hanwen/go-fuse/v2/fuse"
 )
 
 func (f *loopbackFile) Allocate(off uint64, sz uint64, mode uint32) fuse.Status {
@@ -17,27 +21,13 @@ func (f *loopbackFile) Allocate(off uint64, sz uint64, mode uint32) fuse.Status
 	return fuse.OK
 }
 
-const _UTIME_NOW = ((1 << 30) - 1)
-const _UTIME_OMIT = ((1 << 30) - 2)
-
+// Utimens - file handle based version of loopbackFileSystem.Utimens()
 func (f *loopbackFile) Utimens(a *time.Time, m *time.Time) fuse.Status {
-	tv := make([]syscall.Timeval, 2)
-	if a == nil {
-		tv[0].Usec = _UTIME_OMIT
-	} else {
-		n := a.UnixNano()
-		tv[0] = syscall.NsecToTimeval(n)
-	}
-
-	if m == nil {
-		tv[1].Usec = _UTIME_OMIT
-	} else {
-		n := a.UnixNano()
-		tv[1] = syscall.NsecToTimeval(n)
-	}
-
+	var ts [2]syscall.Timespec
+	ts[0] = fuse.UtimeToTimespec(a)
+	ts[1] = fuse.UtimeToTimespec(m)
 	f.lock.Lock()
-	err := syscall.Futimes(int(f.File.Fd()), tv)
+	err := futimens(int(f.File.Fd()), &ts)
 	f.lock.Unlock()
 	return fuse.ToStatus(err)
 }
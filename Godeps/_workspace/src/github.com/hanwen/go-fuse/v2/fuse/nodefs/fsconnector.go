@@ -1,3 +1,7 @@
+// Copyright 2016 the Go-FUSE Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
 package nodefs
 
 // This file contains the internal logic of the
@@ -8,24 +12,20 @@ import (
 	"log"
 	"path/filepath"
 	"strings"
-	"sync/atomic"
+	"sync"
 	"time"
 	"unsafe"
 
-	"github.com/hanwen/go-fuse/fuse"
+	"github.com/hanwen/go-fuse/v2/fuse"
 )
 
 // Tests should set to true.
 var paranoia = false
 
-// FilesystemConnector translates the raw FUSE protocol (serialized
+// FileSystemConnector translates the raw FUSE protocol (serialized
 // structs of uint32/uint64) to operations on Go objects representing
 // files and directories.
 type FileSystemConnector struct {
-	// Used as the generation inodes. This must be 64-bit aligned,
-	// for sync/atomic on i386 to work properly.
-	generation uint64
-
 	debug bool
 
 	// Callbacks for talking back to the kernel.
@@ -36,6 +36,16 @@ type FileSystemConnector struct {
 
 	// The root of the FUSE file system.
 	rootNode *Inode
+
+	// This lock prevents Lookup() and Forget() from running concurrently.
+	// Locking at this level is a big hammer, but makes sure we don't return
+	// forgotten nodes to the kernel. Problems solved by this lock:
+	// https://github.com/hanwen/go-fuse/issues/168
+	// https://github.com/rfjakob/gocryptfs/issues/322
+	//
+	// The lock is shared: several concurrent Lookups are allowed to be
+	// run simultaneously, while Forget is exclusive.
+	lookupLock sync.RWMutex
 }
 
 // NewOptions generates FUSE options that correspond to libfuse's
@@ -56,18 +66,16 @@ func NewFileSystemConnector(root Node, opts *Options) (c *FileSystemConnector) {
 	if opts == nil {
 		opts = NewOptions()
 	}
-	c.inodeMap = newHandleMap(opts.PortableInodes)
+	c.inodeMap = newPortableHandleMap()
 	c.rootNode = newInode(true, root)
 
-	// Make sure we don't reuse generation numbers.
-	c.generation = uint64(time.Now().UnixNano())
-
 	c.verify()
 	c.mountRoot(opts)
 
 	// FUSE does not issue a LOOKUP for 1 (obviously), but it does
 	// issue a forget.  This lookupUpdate is to make the counts match.
 	c.lookupUpdate(c.rootNode)
+	c.debug = opts.Debug
 
 	return c
 }
@@ -77,15 +85,12 @@ func (c *FileSystemConnector) Server() *fuse.Server {
 	return c.server
 }
 
-// SetDebug toggles printing of debug information.
+// SetDebug toggles printing of debug information. This function is
+// deprecated. Set the Debug option in the Options struct instead.
 func (c *FileSystemConnector) SetDebug(debug bool) {
 	c.debug = debug
 }
 
-func (c *FileSystemConnector) nextGeneration() uint64 {
-	return atomic.AddUint64(&c.generation, 1)
-}
-
 // This verifies invariants of the data structure.  This routine
 // acquires tree locks as it walks the inode tree.
 func (c *FileSystemConnector) verify() {
@@ -98,10 +103,12 @@ func (c *FileSystemConnector) verify() {
 
 // childLookup fills entry information for a newly created child inode
 func (c *rawBridge) childLookup(out *fuse.EntryOut, n *Inode, context *fuse.Context) {
-	n.Node().GetAttr((*fuse.Attr)(&out.Attr), nil, context)
+	n.Node().GetAttr(&out.Attr, nil, context)
 	n.mount.fillEntry(out)
-	out.Ino = c.fsConn().lookupUpdate(n)
-	out.NodeId = out.Ino
+	out.NodeId, out.Generation = c.fsConn().lookupUpdate(n)
+	if out.Ino == 0 {
+		out.Ino = out.NodeId
+	}
 	if out.Nlink == 0 {
 		// With Nlink == 0, newer kernels will refuse link
 		// operations.
@@ -117,13 +124,14 @@ func (c *rawBridge) toInode(nodeid uint64) *Inode {
 	return i
 }
 
-// Must run outside treeLock.  Returns the nodeId.
-func (c *FileSystemConnector) lookupUpdate(node *Inode) (id uint64) {
-	id = c.inodeMap.Register(&node.handled)
+// Must run outside treeLock.  Returns the nodeId and generation.
+func (c *FileSystemConnector) lookupUpdate(node *Inode) (id, generation uint64) {
+	id, generation = c.inodeMap.Register(&node.handled)
 	c.verify()
-	return id
+	return
 }
 
+// forgetUpdate decrements the reference counter for "nodeID" by "forgetCount".
 // Must run outside treeLock.
 func (c *FileSystemConnector) forgetUpdate(nodeID uint64, forgetCount int) {
 	if nodeID == fuse.FUSE_ROOT_ID {
@@ -134,11 +142,33 @@ func (c *FileSystemConnector) forgetUpdate(nodeID uint64, forgetCount int) {
 		return
 	}
 
-	if forgotten, handled := c.inodeMap.Forget(nodeID, forgetCount); forgotten {
-		node := (*Inode)(unsafe.Pointer(handled))
-		node.mount.treeLock.Lock()
-		c.recursiveConsiderDropInode(node)
-		node.mount.treeLock.Unlock()
+	// Prevent concurrent modification of the tree while we are processing
+	// the FORGET
+	node := (*Inode)(unsafe.Pointer(c.inodeMap.Decode(nodeID)))
+	node.mount.treeLock.Lock()
+	defer node.mount.treeLock.Unlock()
+
+	if forgotten, _ := c.inodeMap.Forget(nodeID, forgetCount); forgotten {
+		if len(node.children) > 0 || !node.Node().Deletable() ||
+			node == c.rootNode || node.mountPoint != nil {
+			// We cannot forget a directory that still has children as these
+			// would become unreachable.
+			return
+		}
+		// We have to remove ourself from all parents.
+		// Create a copy of node.parents so we can safely iterate over it
+		// while modifying the original.
+		parents := make(map[parentData]struct{}, len(node.parents))
+		for k, v := range node.parents {
+			parents[k] = v
+		}
+
+		for p := range parents {
+			// This also modifies node.parents
+			p.parent.rmChild(p.name)
+		}
+
+		node.fsInode.OnForget()
 	}
 	// TODO - try to drop children even forget was not successful.
 	c.verify()
@@ -149,39 +179,6 @@ func (c *FileSystemConnector) InodeHandleCount() int {
 	return c.inodeMap.Count()
 }
 
-// Must hold treeLock.
-
-func (c *FileSystemConnector) recursiveConsiderDropInode(n *Inode) (drop bool) {
-	delChildren := []string{}
-	for k, v := range n.children {
-		// Only consider children from the same mount, or
-		// already unmounted mountpoints.
-		if v.mountPoint == nil && c.recursiveConsiderDropInode(v) {
-			delChildren = append(delChildren, k)
-		}
-	}
-	for _, k := range delChildren {
-		ch := n.rmChild(k)
-		if ch == nil {
-			log.Panicf("trying to del child %q, but not present", k)
-		}
-		ch.fsInode.OnForget()
-	}
-
-	if len(n.children) > 0 || !n.Node().Deletable() {
-		return false
-	}
-	if n == c.rootNode || n.mountPoint != nil {
-		return false
-	}
-
-	n.openFilesMutex.Lock()
-	ok := len(n.openFiles) == 0
-	n.openFilesMutex.Unlock()
-
-	return ok
-}
-
 // Finds a node within the currently known inodes, returns the last
 // known node and the remaining unknown path components.  If parent is
 // nil, start from FUSE mountpoint.
@@ -224,7 +221,7 @@ func (c *FileSystemConnector) Node(parent *Inode, fullPath string) (*Inode, []st
 }
 
 // Follows the path from the given parent, doing lookups as
-// necesary. The path should be '/' separated without leading slash.
+// necessary. The path should be '/' separated without leading slash.
 func (c *FileSystemConnector) LookupNode(parent *Inode, path string) *Inode {
 	if path == "" {
 		return parent
@@ -236,7 +233,7 @@ func (c *FileSystemConnector) LookupNode(parent *Inode, path string) *Inode {
 		// This will not affect inode ID lookup counts, which
 		// are only update in response to kernel requests.
 		var dummy fuse.InHeader
-		child, _ := c.internalLookup(&a, parent, r, &dummy)
+		child, _ := c.internalLookup(nil, &a, parent, r, &dummy)
 		if child == nil {
 			return nil
 		}
@@ -250,7 +247,6 @@ func (c *FileSystemConnector) LookupNode(parent *Inode, path string) *Inode {
 func (c *FileSystemConnector) mountRoot(opts *Options) {
 	c.rootNode.mountFs(opts)
 	c.rootNode.mount.connector = c
-	c.rootNode.Node().OnMount(c)
 	c.verify()
 }
 
@@ -262,12 +258,22 @@ func (c *FileSystemConnector) mountRoot(opts *Options) {
 // It returns ENOENT if the directory containing the mount point does
 // not exist, and EBUSY if the intended mount point already exists.
 func (c *FileSystemConnector) Mount(parent *Inode, name string, root Node, opts *Options) fuse.Status {
+	node, code := c.lockMount(parent, name, root, opts)
+	if !code.Ok() {
+		return code
+	}
+
+	node.Node().OnMount(c)
+	return code
+}
+
+func (c *FileSystemConnector) lockMount(parent *Inode, name string, root Node, opts *Options) (*Inode, fuse.Status) {
 	defer c.verify()
 	parent.mount.treeLock.Lock()
 	defer parent.mount.treeLock.Unlock()
 	node := parent.children[name]
 	if node != nil {
-		return fuse.EBUSY
+		return nil, fuse.EBUSY
 	}
 
 	node = newInode(true, root)
@@ -281,11 +287,10 @@ func (c *FileSystemConnector) Mount(parent *Inode, name string, root Node, opts
 
 	node.mountPoint.parentInode = parent
 	if c.debug {
-		log.Printf("Mount %T on subdir %s, parent %d", node,
+		log.Printf("Mount %T on subdir %s, parent i%d", node,
 			name, c.inodeMap.Handle(&parent.handled))
 	}
-	node.Node().OnMount(c)
-	return fuse.OK
+	return node, fuse.OK
 }
 
 // Unmount() tries to unmount the given inode.  It returns EINVAL if the
@@ -377,17 +382,70 @@ func (c *FileSystemConnector) Unmount(node *Inode) fuse.Status {
 // Use negative offset for metadata-only invalidation, and zero-length
 // for invalidating all content.
 func (c *FileSystemConnector) FileNotify(node *Inode, off int64, length int64) fuse.Status {
-	var nId uint64
+	var nID uint64
 	if node == c.rootNode {
-		nId = fuse.FUSE_ROOT_ID
+		nID = fuse.FUSE_ROOT_ID
 	} else {
-		nId = c.inodeMap.Handle(&node.handled)
+		nID = c.inodeMap.Handle(&node.handled)
 	}
 
-	if nId == 0 {
+	if nID == 0 {
 		return fuse.OK
 	}
-	return c.server.InodeNotify(nId, off, length)
+	return c.server.InodeNotify(nID, off, length)
+}
+
+// FileNotifyStoreCache notifies the kernel about changed data of the inode.
+//
+// This call is similar to FileNotify, but instead of only invalidating a data
+// region, it puts updated data directly to the kernel cache:
+//
+// After this call completes, the kernel has put updated data into the inode's cache,
+// and will use data from that cache for non direct-IO reads from the inode
+// in corresponding data region. After kernel's cache data is evicted, the kernel
+// will have to issue new Read calls on user request to get data content.
+//
+// ENOENT is returned if the kernel does not currently have entry for this
+// inode in its dentry cache.
+func (c *FileSystemConnector) FileNotifyStoreCache(node *Inode, off int64, data []byte) fuse.Status {
+	var nID uint64
+	if node == c.rootNode {
+		nID = fuse.FUSE_ROOT_ID
+	} else {
+		nID = c.inodeMap.Handle(&node.handled)
+	}
+
+	if nID == 0 {
+		// the kernel does not currently know about this inode.
+		return fuse.ENOENT
+	}
+	return c.server.InodeNotifyStoreCache(nID, off, data)
+}
+
+// FileRetrieveCache retrieves data from kernel's inode cache.
+//
+// This call retrieves data from kernel's inode cache @ offset and up to
+// len(dest) bytes. If kernel cache has fewer consecutive data starting at
+// offset, that fewer amount is returned. In particular if inode data at offset
+// is not cached (0, OK) is returned.
+//
+// If the kernel does not currently have entry for this inode in its dentry
+// cache (0, OK) is still returned, pretending that the inode could be known to
+// the kernel, but kernel's inode cache is empty.
+func (c *FileSystemConnector) FileRetrieveCache(node *Inode, off int64, dest []byte) (n int, st fuse.Status) {
+	var nID uint64
+	if node == c.rootNode {
+		nID = fuse.FUSE_ROOT_ID
+	} else {
+		nID = c.inodeMap.Handle(&node.handled)
+	}
+
+	if nID == 0 {
+		// the kernel does not currently know about this inode.
+		// -> we can pretend that its cache for the inode is empty.
+		return 0, fuse.OK
+	}
+	return c.server.InodeRetrieveCache(nID, off, dest)
 }
 
 // EntryNotify makes the kernel forget the entry data from the given
@@ -395,36 +453,36 @@ func (c *FileSystemConnector) FileNotify(node *Inode, off int64, length int64) f
 // new lookup request for the given name when necessary. No filesystem
 // related locks should be held when calling this.
 func (c *FileSystemConnector) EntryNotify(node *Inode, name string) fuse.Status {
-	var nId uint64
+	var nID uint64
 	if node == c.rootNode {
-		nId = fuse.FUSE_ROOT_ID
+		nID = fuse.FUSE_ROOT_ID
 	} else {
-		nId = c.inodeMap.Handle(&node.handled)
+		nID = c.inodeMap.Handle(&node.handled)
 	}
 
-	if nId == 0 {
+	if nID == 0 {
 		return fuse.OK
 	}
-	return c.server.EntryNotify(nId, name)
+	return c.server.EntryNotify(nID, name)
 }
 
 // DeleteNotify signals to the kernel that the named entry in dir for
 // the child disappeared. No filesystem related locks should be held
 // when calling this.
 func (c *FileSystemConnector) DeleteNotify(dir *Inode, child *Inode, name string) fuse.Status {
-	var nId uint64
+	var nID uint64
 
 	if dir == c.rootNode {
-		nId = fuse.FUSE_ROOT_ID
+		nID = fuse.FUSE_ROOT_ID
 	} else {
-		nId = c.inodeMap.Handle(&dir.handled)
+		nID = c.inodeMap.Handle(&dir.handled)
 	}
 
-	if nId == 0 {
+	if nID == 0 {
 		return fuse.OK
 	}
 
 	chId := c.inodeMap.Handle(&child.handled)
 
-	return c.server.DeleteNotify(nId, chId, name)
+	return c.server.DeleteNotify(nID, chId, name)
 }
@@ -1,12 +1,22 @@
+// Copyright 2016 the Go-FUSE Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
 package nodefs
 
 import (
+	"fmt"
 	"log"
 	"sync"
 
-	"github.com/hanwen/go-fuse/fuse"
+	"github.com/hanwen/go-fuse/v2/fuse"
 )
 
+type parentData struct {
+	parent *Inode
+	name   string
+}
+
 // An Inode reflects the kernel's idea of the inode.  Inodes have IDs
 // that are communicated to the kernel, and they have a tree
 // structure: a directory Inode may contain named children.  Each
@@ -15,10 +25,6 @@ import (
 type Inode struct {
 	handled handled
 
-	// Generation number of the inode. Each (re)use of an inode
-	// should have a unique generation number.
-	generation uint64
-
 	// Number of open files and its protection.
 	openFilesMutex sync.Mutex
 	openFiles      []*openedFile
@@ -32,6 +38,8 @@ type Inode struct {
 
 	// All data below is protected by treeLock.
 	children map[string]*Inode
+	// Due to hard links, an Inode can have many parents.
+	parents map[parentData]struct{}
 
 	// Non-nil if this inode is a mountpoint, ie. the Root of a
 	// NodeFileSystem.
@@ -40,6 +48,7 @@ type Inode struct {
 
 func newInode(isDir bool, fsNode Node) *Inode {
 	me := new(Inode)
+	me.parents = map[parentData]struct{}{}
 	if isDir {
 		me.children = make(map[string]*Inode, initDirSize)
 	}
@@ -50,6 +59,13 @@ func newInode(isDir bool, fsNode Node) *Inode {
 
 // public methods.
 
+// Print the inode. The default print method may not be used for
+// debugging, as dumping the map requires synchronization.
+func (n *Inode) String() string {
+
+	return fmt.Sprintf("node{%d}", n.handled.handle)
+}
+
 // Returns any open file, preferably a r/w one.
 func (n *Inode) AnyFile() (file File) {
 	n.openFilesMutex.Lock()
@@ -75,6 +91,21 @@ func (n *Inode) Children() (out map[string]*Inode) {
 	return out
 }
 
+// Parent returns a random parent and the name this inode has under this parent.
+// This function can be used to walk up the directory tree. It will not cross
+// sub-mounts.
+func (n *Inode) Parent() (parent *Inode, name string) {
+	if n.mountPoint != nil {
+		return nil, ""
+	}
+	n.mount.treeLock.RLock()
+	defer n.mount.treeLock.RUnlock()
+	for k := range n.parents {
+		return k.parent, k.name
+	}
+	return nil, ""
+}
+
 // FsChildren returns all the children from the same filesystem.  It
 // will skip mountpoints.
 func (n *Inode) FsChildren() (out map[string]*Inode) {
@@ -117,7 +148,6 @@ func (n *Inode) IsDir() bool {
 func (n *Inode) NewChild(name string, isDir bool, fsi Node) *Inode {
 	ch := newInode(isDir, fsi)
 	ch.mount = n.mount
-	n.generation = ch.mount.connector.nextGeneration()
 	n.AddChild(name, ch)
 	return ch
 }
@@ -143,6 +173,15 @@ func (n *Inode) AddChild(name string, child *Inode) {
 	n.mount.treeLock.Unlock()
 }
 
+// TreeWatcher is an additional interface that Nodes can implement.
+// If they do, the OnAdd and OnRemove are called for operations on the
+// file system tree. These functions run under a lock, so they should
+// not do blocking operations.
+type TreeWatcher interface {
+	OnAdd(parent *Inode, name string)
+	OnRemove(parent *Inode, name string)
+}
+
 // RmChild removes an inode by name, and returns it. It returns nil if
 // child does not exist.
 func (n *Inode) RmChild(name string) (ch *Inode) {
@@ -155,6 +194,7 @@ func (n *Inode) RmChild(name string) (ch *Inode) {
 //////////////////////////////////////////////////////////////
 // private
 
+// addChild adds "child" to our children under name "name".
 // Must be called with treeLock for the mount held.
 func (n *Inode) addChild(name string, child *Inode) {
 	if paranoia {
@@ -164,13 +204,23 @@ func (n *Inode) addChild(name string, child *Inode) {
 		}
 	}
 	n.children[name] = child
+	child.parents[parentData{n, name}] = struct{}{}
+	if w, ok := child.Node().(TreeWatcher); ok && child.mountPoint == nil {
+		w.OnAdd(n, name)
+	}
 }
 
+// rmChild throws out child "name". This means (1) deleting "name" from our
+// "children" map and (2) deleting ourself from the child's "parents" map.
 // Must be called with treeLock for the mount held.
-func (n *Inode) rmChild(name string) (ch *Inode) {
-	ch = n.children[name]
+func (n *Inode) rmChild(name string) *Inode {
+	ch := n.children[name]
 	if ch != nil {
 		delete(n.children, name)
+		delete(ch.parents, parentData{n, name})
+		if w, ok := ch.Node().(TreeWatcher); ok && ch.mountPoint == nil {
+			w.OnRemove(n, name)
+		}
 	}
 	return ch
 }
@@ -178,7 +228,7 @@ func (n *Inode) rmChild(name string) (ch *Inode) {
 // Can only be called on untouched root inodes.
 func (n *Inode) mountFs(opts *Options) {
 	n.mountPoint = &fileSystemMount{
-		openFiles:  newHandleMap(false),
+		openFiles:  newPortableHandleMap(),
 		mountInode: n,
 		options:    opts,
 	}
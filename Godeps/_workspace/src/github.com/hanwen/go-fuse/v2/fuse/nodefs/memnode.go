@@ -1,3 +1,7 @@
+// Copyright 2016 the Go-FUSE Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
 package nodefs
 
 import (
@@ -7,7 +11,7 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/hanwen/go-fuse/fuse"
+	"github.com/hanwen/go-fuse/v2/fuse"
 )
 
 // NewMemNodeFSRoot creates an in-memory node-based filesystem. Files
@@ -47,16 +51,17 @@ func (fs *memNodeFs) OnUnmount() {
 
 func (fs *memNodeFs) newNode() *memNode {
 	fs.mutex.Lock()
+	id := fs.nextFree
+	fs.nextFree++
+	fs.mutex.Unlock()
 	n := &memNode{
 		Node: NewDefaultNode(),
 		fs:   fs,
-		id:   fs.nextFree,
+		id:   id,
 	}
 	now := time.Now()
 	n.info.SetTimes(&now, &now, &now)
 	n.info.Mode = fuse.S_IFDIR | 0777
-	fs.nextFree++
-	fs.mutex.Unlock()
 	return n
 }
 
@@ -70,16 +75,11 @@ type memNode struct {
 	fs *memNodeFs
 	id int
 
+	mu   sync.Mutex
 	link string
 	info fuse.Attr
 }
 
-func (n *memNode) newNode(name string, isDir bool) *memNode {
-	newNode := n.fs.newNode()
-	n.Inode().NewChild(name, isDir, newNode)
-	return newNode
-}
-
 func (n *memNode) filename() string {
 	return fmt.Sprintf("%s%d", n.fs.backingStorePrefix, n.id)
 }
@@ -89,6 +89,8 @@ func (n *memNode) Deletable() bool {
 }
 
 func (n *memNode) Readlink(c *fuse.Context) ([]byte, fuse.Status) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
 	return []byte(n.link), fuse.OK
 }
 
@@ -97,8 +99,9 @@ func (n *memNode) StatFs() *fuse.StatfsOut {
 }
 
 func (n *memNode) Mkdir(name string, mode uint32, context *fuse.Context) (newNode *Inode, code fuse.Status) {
-	ch := n.newNode(name, true)
+	ch := n.fs.newNode()
 	ch.info.Mode = mode | fuse.S_IFDIR
+	n.Inode().NewChild(name, true, ch)
 	return ch.Inode(), fuse.OK
 }
 
@@ -115,10 +118,10 @@ func (n *memNode) Rmdir(name string, context *fuse.Context) (code fuse.Status) {
 }
 
 func (n *memNode) Symlink(name string, content string, context *fuse.Context) (newNode *Inode, code fuse.Status) {
-	ch := n.newNode(name, false)
+	ch := n.fs.newNode()
 	ch.info.Mode = fuse.S_IFLNK | 0777
 	ch.link = content
-
+	n.Inode().NewChild(name, false, ch)
 	return ch.Inode(), fuse.OK
 }
 
@@ -135,13 +138,14 @@ func (n *memNode) Link(name string, existing Node, context *fuse.Context) (*Inod
 }
 
 func (n *memNode) Create(name string, flags uint32, mode uint32, context *fuse.Context) (file File, node *Inode, code fuse.Status) {
-	ch := n.newNode(name, false)
+	ch := n.fs.newNode()
 	ch.info.Mode = mode | fuse.S_IFREG
 
 	f, err := os.Create(ch.filename())
 	if err != nil {
 		return nil, nil, fuse.ToStatus(err)
 	}
+	n.Inode().NewChild(name, false, ch)
 	return ch.newFile(f), ch.Inode(), fuse.OK
 }
 
@@ -167,6 +171,9 @@ func (n *memNodeFile) Flush() fuse.Status {
 
 	st := syscall.Stat_t{}
 	err := syscall.Stat(n.node.filename(), &st)
+
+	n.node.mu.Lock()
+	defer n.node.mu.Unlock()
 	n.node.info.Size = uint64(st.Size)
 	n.node.info.Blocks = uint64(st.Blocks)
 	return fuse.ToStatus(err)
@@ -189,6 +196,9 @@ func (n *memNode) Open(flags uint32, context *fuse.Context) (file File, code fus
 }
 
 func (n *memNode) GetAttr(fi *fuse.Attr, file File, context *fuse.Context) (code fuse.Status) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
 	*fi = n.info
 	return fuse.OK
 }
@@ -202,6 +212,10 @@ func (n *memNode) Truncate(file File, size uint64, context *fuse.Context) (code
 	}
 	if code.Ok() {
 		now := time.Now()
+
+		n.mu.Lock()
+		defer n.mu.Unlock()
+
 		n.info.SetTimes(nil, nil, &now)
 		// TODO - should update mtime too?
 		n.info.Size = size
@@ -211,13 +225,18 @@ func (n *memNode) Truncate(file File, size uint64, context *fuse.Context) (code
 
 func (n *memNode) Utimens(file File, atime *time.Time, mtime *time.Time, context *fuse.Context) (code fuse.Status) {
 	c := time.Now()
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
 	n.info.SetTimes(atime, mtime, &c)
 	return fuse.OK
 }
 
 func (n *memNode) Chmod(file File, perms uint32, context *fuse.Context) (code fuse.Status) {
-	n.info.Mode = (n.info.Mode ^ 07777) | perms
+	n.info.Mode = (n.info.Mode &^ 07777) | perms
 	now := time.Now()
+	n.mu.Lock()
+	defer n.mu.Unlock()
 	n.info.SetTimes(nil, nil, &now)
 	return fuse.OK
 }
@@ -226,6 +245,8 @@ func (n *memNode) Chown(file File, uid uint32, gid uint32, context *fuse.Context
 	n.info.Uid = uid
 	n.info.Gid = gid
 	now := time.Now()
+	n.mu.Lock()
+	defer n.mu.Unlock()
 	n.info.SetTimes(nil, nil, &now)
 	return fuse.OK
 }
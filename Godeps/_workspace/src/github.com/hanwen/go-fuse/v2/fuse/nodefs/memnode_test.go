@@ -1,21 +1,22 @@
+// Copyright 2016 the Go-FUSE Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
 package nodefs
 
 import (
-	"io/ioutil"
 	"os"
 	"testing"
 	"time"
 
-	"github.com/hanwen/go-fuse/fuse"
+	"github.com/hanwen/go-fuse/v2/fuse"
+	"github.com/hanwen/go-fuse/v2/internal/testutil"
 )
 
 const testTtl = 100 * time.Millisecond
 
-func setupMemNodeTest(t *testing.T) (wd string, root Node, clean func()) {
-	tmp, err := ioutil.TempDir("", "go-fuse-memnode_test")
-	if err != nil {
-		t.Fatalf("TempDir failed: %v", err)
-	}
+func setupMemNodeTest(t *testing.T) (wd string, root Node) {
+	tmp := t.TempDir()
 	back := tmp + "/backing"
 	os.Mkdir(back, 0700)
 	root = NewMemNodeFSRoot(back)
@@ -24,49 +25,49 @@ func setupMemNodeTest(t *testing.T) (wd string, root Node, clean func()) {
 
 	connector := NewFileSystemConnector(root,
 		&Options{
-			EntryTimeout:    testTtl,
-			AttrTimeout:     testTtl,
-			NegativeTimeout: 0.0,
+			EntryTimeout:        testTtl,
+			AttrTimeout:         testTtl,
+			NegativeTimeout:     0.0,
+			Debug:               testutil.VerboseTest(),
+			LookupKnownChildren: true,
 		})
-	connector.SetDebug(VerboseTest())
-	state, err := fuse.NewServer(connector.RawFS(), mnt, nil)
+	state, err := fuse.NewServer(connector.RawFS(), mnt, &fuse.MountOptions{Debug: testutil.VerboseTest()})
 	if err != nil {
 		t.Fatal("NewServer", err)
 	}
 
-	//me.state.SetDebug(false)
-	state.SetDebug(VerboseTest())
-
 	// Unthreaded, but in background.
 	go state.Serve()
-	return mnt, root, func() {
-		state.Unmount()
-		os.RemoveAll(tmp)
+
+	if err := state.WaitMount(); err != nil {
+		t.Fatal("WaitMount", err)
 	}
+	t.Cleanup(func() {
+		state.Unmount()
+	})
 
+	return mnt, root
 }
 
 func TestMemNodeFsWrite(t *testing.T) {
-	wd, _, clean := setupMemNodeTest(t)
-	defer clean()
-
+	wd, _ := setupMemNodeTest(t)
 	want := "hello"
-	err := ioutil.WriteFile(wd+"/test", []byte(want), 0644)
+
+	err := os.WriteFile(wd+"/test", []byte(want), 0644)
 	if err != nil {
 		t.Fatalf("WriteFile failed: %v", err)
 	}
 
-	content, err := ioutil.ReadFile(wd + "/test")
+	content, err := os.ReadFile(wd + "/test")
 	if string(content) != want {
 		t.Fatalf("content mismatch: got %q, want %q", content, want)
 	}
 }
 
-func TestMemNodeFs(t *testing.T) {
-	wd, _, clean := setupMemNodeTest(t)
-	defer clean()
+func TestMemNodeFsBasic(t *testing.T) {
+	wd, _ := setupMemNodeTest(t)
 
-	err := ioutil.WriteFile(wd+"/test", []byte{42}, 0644)
+	err := os.WriteFile(wd+"/test", []byte{42}, 0644)
 	if err != nil {
 		t.Fatalf("WriteFile failed: %v", err)
 	}
@@ -79,15 +80,14 @@ func TestMemNodeFs(t *testing.T) {
 		t.Errorf("Size after write incorrect: got %d want 1", fi.Size())
 	}
 
-	entries, err := ioutil.ReadDir(wd)
+	entries, err := os.ReadDir(wd)
 	if len(entries) != 1 || entries[0].Name() != "test" {
 		t.Fatalf("Readdir got %v, expected 1 file named 'test'", entries)
 	}
 }
 
 func TestMemNodeSetattr(t *testing.T) {
-	wd, _, clean := setupMemNodeTest(t)
-	defer clean()
+	wd, _ := setupMemNodeTest(t)
 
 	f, err := os.OpenFile(wd+"/test", os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
@@ -107,4 +107,16 @@ func TestMemNodeSetattr(t *testing.T) {
 	if fi.Size() != 4096 {
 		t.Errorf("Size should be 4096 after Truncate: %d", fi.Size())
 	}
+
+	if err := f.Chown(21, 42); err != nil {
+		t.Errorf("Chown: %v", err)
+	}
+	if fi, err := f.Stat(); err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	} else {
+		attr := fuse.ToStatT(fi)
+		if attr.Gid != 42 || attr.Uid != 21 {
+			t.Errorf("got (%d, %d), want 42,21", attr.Uid, attr.Gid)
+		}
+	}
 }
@@ -1,10 +1,18 @@
+// Copyright 2016 the Go-FUSE Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This package is deprecated. New projects should use the package
+// "github.com/hanwen/go-fuse/v2/fs" instead.
+//
+// Package pathfs provides a file system API expressed in filenames.
 package pathfs
 
 import (
 	"time"
 
-	"github.com/hanwen/go-fuse/fuse"
-	"github.com/hanwen/go-fuse/fuse/nodefs"
+	"github.com/hanwen/go-fuse/v2/fuse"
+	"github.com/hanwen/go-fuse/v2/fuse/nodefs"
 )
 
 // A filesystem API that uses paths rather than inodes.  A minimal
@@ -75,4 +83,7 @@ type PathNodeFsOptions struct {
 	// If ClientInodes is set, use Inode returned from GetAttr to
 	// find hard-linked files.
 	ClientInodes bool
+
+	// Debug controls printing of debug information.
+	Debug bool
 }
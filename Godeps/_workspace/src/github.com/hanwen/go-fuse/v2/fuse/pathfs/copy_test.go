@@ -1,29 +1,24 @@
+// Copyright 2016 the Go-FUSE Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
 package pathfs
 
 import (
-	"io/ioutil"
 	"os"
 	"testing"
 )
 
 func TestCopyFile(t *testing.T) {
-	d1, err := ioutil.TempDir("", "go-fuse")
-	if err != nil {
-		t.Fatalf("TempDir failed: %v", err)
-	}
-	defer os.RemoveAll(d1)
-	d2, err := ioutil.TempDir("", "go-fuse")
-	if err != nil {
-		t.Fatalf("TempDir failed: %v", err)
-	}
-	defer os.RemoveAll(d2)
+	d1 := t.TempDir()
+	d2 := t.TempDir()
 
 	fs1 := NewLoopbackFileSystem(d1)
 	fs2 := NewLoopbackFileSystem(d2)
 
 	content1 := "blabla"
 
-	err = ioutil.WriteFile(d1+"/file", []byte(content1), 0644)
+	err := os.WriteFile(d1+"/file", []byte(content1), 0644)
 	if err != nil {
 		t.Fatalf("WriteFile failed: %v", err)
 	}
@@ -33,14 +28,14 @@ func TestCopyFile(t *testing.T) {
 		t.Fatal("Unexpected ret code", code)
 	}
 
-	data, err := ioutil.ReadFile(d2 + "/file")
+	data, err := os.ReadFile(d2 + "/file")
 	if content1 != string(data) {
 		t.Fatal("Unexpected content", string(data))
 	}
 
 	content2 := "foobar"
 
-	err = ioutil.WriteFile(d2+"/file", []byte(content2), 0644)
+	err = os.WriteFile(d2+"/file", []byte(content2), 0644)
 	if err != nil {
 		t.Fatalf("WriteFile failed: %v", err)
 	}
@@ -51,7 +46,7 @@ func TestCopyFile(t *testing.T) {
 		t.Fatal("Unexpected ret code", code)
 	}
 
-	data, err = ioutil.ReadFile(d1 + "/file")
+	data, err = os.ReadFile(d1 + "/file")
 	if content2 != string(data) {
 		t.Fatal("Unexpected content", string(data))
 	}
@@ -1,3 +1,7 @@
+// Copyright 2016 the Go-FUSE Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
 package pathfs
 
 import (
@@ -6,10 +10,10 @@ import (
 	"os"
 	"path/filepath"
 	"syscall"
-	"time"
 
-	"github.com/hanwen/go-fuse/fuse"
-	"github.com/hanwen/go-fuse/fuse/nodefs"
+	"github.com/hanwen/go-fuse/v2/fuse"
+	"github.com/hanwen/go-fuse/v2/fuse/nodefs"
+	"github.com/hanwen/go-fuse/v2/internal"
 )
 
 type loopbackFileSystem struct {
@@ -22,12 +26,29 @@ type loopbackFileSystem struct {
 // system.  Its main purpose is to provide test coverage without
 // having to build a synthetic filesystem.
 func NewLoopbackFileSystem(root string) FileSystem {
+	// Make sure the Root path is absolute to avoid problems when the
+	// application changes working directory.
+	root, err := filepath.Abs(root)
+	if err != nil {
+		panic(err)
+	}
 	return &loopbackFileSystem{
 		FileSystem: NewDefaultFileSystem(),
 		Root:       root,
 	}
 }
 
+func (fs *loopbackFileSystem) StatFs(name string) *fuse.StatfsOut {
+	s := syscall.Statfs_t{}
+	err := syscall.Statfs(fs.GetPath(name), &s)
+	if err == nil {
+		out := &fuse.StatfsOut{}
+		out.FromStatfsT(&s)
+		return out
+	}
+	return nil
+}
+
 func (fs *loopbackFileSystem) OnMount(nodeFs *PathNodeFs) {
 }
 
@@ -48,7 +69,6 @@ func (fs *loopbackFileSystem) GetAttr(name string, context *fuse.Context) (a *fu
 	} else {
 		err = syscall.Lstat(fullPath, &st)
 	}
-
 	if err != nil {
 		return nil, fuse.ToStatus(err)
 	}
@@ -69,7 +89,7 @@ func (fs *loopbackFileSystem) OpenDir(name string, context *fuse.Context) (strea
 	for {
 		infos, err := f.Readdir(want)
 		for i := range infos {
-			// workaround forhttps://code.google.com/p/go/issues/detail?id=5960
+			// workaround for https://code.google.com/p/go/issues/detail?id=5960
 			if infos[i] == nil {
 				continue
 			}
@@ -79,6 +99,7 @@ func (fs *loopbackFileSystem) OpenDir(name string, context *fuse.Context) (strea
 			}
 			if s := fuse.ToStatT(infos[i]); s != nil {
 				d.Mode = uint32(s.Mode)
+				d.Ino = s.Ino
 			} else {
 				log.Printf("ReadDir entry %q for %q has no stat info", n, name)
 			}
@@ -98,6 +119,9 @@ func (fs *loopbackFileSystem) OpenDir(name string, context *fuse.Context) (strea
 }
 
 func (fs *loopbackFileSystem) Open(name string, flags uint32, context *fuse.Context) (fuseFile nodefs.File, status fuse.Status) {
+	// filter out append. The kernel layer will translate the
+	// offsets for us appropriately.
+	flags = flags &^ syscall.O_APPEND
 	f, err := os.OpenFile(fs.GetPath(name), int(flags), 0)
 	if err != nil {
 		return nil, fuse.ToStatus(err)
@@ -118,18 +142,6 @@ func (fs *loopbackFileSystem) Truncate(path string, offset uint64, context *fuse
 	return fuse.ToStatus(os.Truncate(fs.GetPath(path), int64(offset)))
 }
 
-func (fs *loopbackFileSystem) Utimens(path string, Atime *time.Time, Mtime *time.Time, context *fuse.Context) (code fuse.Status) {
-	var a time.Time
-	if Atime != nil {
-		a = *Atime
-	}
-	var m time.Time
-	if Mtime != nil {
-		m = *Mtime
-	}
-	return fuse.ToStatus(os.Chtimes(fs.GetPath(path), a, m))
-}
-
 func (fs *loopbackFileSystem) Readlink(name string, context *fuse.Context) (out string, code fuse.Status) {
 	f, err := os.Readlink(fs.GetPath(name))
 	return f, fuse.ToStatus(err)
@@ -157,7 +169,9 @@ func (fs *loopbackFileSystem) Symlink(pointedTo string, linkName string, context
 }
 
 func (fs *loopbackFileSystem) Rename(oldPath string, newPath string, context *fuse.Context) (codee fuse.Status) {
-	err := os.Rename(fs.GetPath(oldPath), fs.GetPath(newPath))
+	oldPath = fs.GetPath(oldPath)
+	newPath = fs.GetPath(newPath)
+	err := os.Rename(oldPath, newPath)
 	return fuse.ToStatus(err)
 }
 
@@ -166,10 +180,20 @@ func (fs *loopbackFileSystem) Link(orig string, newName string, context *fuse.Co
 }
 
 func (fs *loopbackFileSystem) Access(name string, mode uint32, context *fuse.Context) (code fuse.Status) {
-	return fuse.ToStatus(syscall.Access(fs.GetPath(name), mode))
+	attr, status := fs.GetAttr(name, context)
+	if !status.Ok() {
+		return status
+
+	}
+	if !internal.HasAccess(context.Uid, context.Gid, attr.Uid, attr.Gid, attr.Mode, mode) {
+		return fuse.EACCES
+	}
+
+	return fuse.OK
 }
 
 func (fs *loopbackFileSystem) Create(path string, flags uint32, mode uint32, context *fuse.Context) (fuseFile nodefs.File, code fuse.Status) {
+	flags = flags &^ syscall.O_APPEND
 	f, err := os.OpenFile(fs.GetPath(path), int(flags)|os.O_CREATE, os.FileMode(mode))
 	return nodefs.NewLoopbackFile(f), fuse.ToStatus(err)
 }
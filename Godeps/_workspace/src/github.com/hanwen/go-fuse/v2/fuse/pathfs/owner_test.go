@@ -1,13 +1,17 @@
+// Copyright 2016 the Go-FUSE Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
 package pathfs
 
 import (
-	"io/ioutil"
 	"os"
 	"syscall"
 	"testing"
 
-	"github.com/hanwen/go-fuse/fuse"
-	"github.com/hanwen/go-fuse/fuse/nodefs"
+	"github.com/hanwen/go-fuse/v2/fuse"
+	"github.com/hanwen/go-fuse/v2/fuse/nodefs"
+	"github.com/hanwen/go-fuse/v2/internal/testutil"
 )
 
 type ownerFs struct {
@@ -31,8 +35,9 @@ func (fs *ownerFs) GetAttr(name string, context *fuse.Context) (*fuse.Attr, fuse
 }
 
 func setupOwnerTest(t *testing.T, opts *nodefs.Options) (workdir string, cleanup func()) {
-	wd, err := ioutil.TempDir("", "go-fuse-owner_test")
+	wd := t.TempDir()
 
+	opts.Debug = testutil.VerboseTest()
 	fs := &ownerFs{NewDefaultFileSystem()}
 	nfs := NewPathNodeFs(fs, nil)
 	state, _, err := nodefs.MountRoot(wd, nfs.Root(), opts)
@@ -40,9 +45,11 @@ func setupOwnerTest(t *testing.T, opts *nodefs.Options) (workdir string, cleanup
 		t.Fatalf("MountNodeFileSystem failed: %v", err)
 	}
 	go state.Serve()
+	if err := state.WaitMount(); err != nil {
+		t.Fatalf("WaitMount: %v", err)
+	}
 	return wd, func() {
 		state.Unmount()
-		os.RemoveAll(wd)
 	}
 }
 
@@ -77,7 +84,7 @@ func TestOwnerRoot(t *testing.T) {
 }
 
 func TestOwnerOverride(t *testing.T) {
-	wd, cleanup := setupOwnerTest(t, &nodefs.Options{Owner: &fuse.Owner{42, 43}})
+	wd, cleanup := setupOwnerTest(t, &nodefs.Options{Owner: &fuse.Owner{Uid: 42, Gid: 43}})
 	defer cleanup()
 
 	var stat syscall.Stat_t
@@ -1,15 +1,21 @@
+// Copyright 2016 the Go-FUSE Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux
+// +build linux
+
 package pathfs
 
 import (
 	"bytes"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"syscall"
 	"testing"
 
-	"github.com/hanwen/go-fuse/fuse"
-	"github.com/hanwen/go-fuse/fuse/nodefs"
+	"github.com/hanwen/go-fuse/v2/fuse"
+	"github.com/hanwen/go-fuse/v2/fuse/nodefs"
 )
 
 var xattrGolden = map[string][]byte{
@@ -18,7 +24,6 @@ var xattrGolden = map[string][]byte{
 var xattrFilename = "filename"
 
 type XAttrTestFs struct {
-	tester   *testing.T
 	filename string
 	attrs    map[string][]byte
 
@@ -52,7 +57,6 @@ func (fs *XAttrTestFs) GetAttr(name string, context *fuse.Context) (*fuse.Attr,
 }
 
 func (fs *XAttrTestFs) SetXAttr(name string, attr string, data []byte, flags int, context *fuse.Context) fuse.Status {
-	fs.tester.Log("SetXAttr", name, attr, string(data), flags)
 	if name != fs.filename {
 		return fuse.ENOENT
 	}
@@ -68,9 +72,8 @@ func (fs *XAttrTestFs) GetXAttr(name string, attr string, context *fuse.Context)
 	}
 	v, ok := fs.attrs[attr]
 	if !ok {
-		return nil, fuse.ENODATA
+		return nil, fuse.ENOATTR
 	}
-	fs.tester.Log("GetXAttr", string(v))
 	return v, fuse.OK
 }
 
@@ -90,44 +93,54 @@ func (fs *XAttrTestFs) RemoveXAttr(name string, attr string, context *fuse.Conte
 		return fuse.ENOENT
 	}
 	_, ok := fs.attrs[attr]
-	fs.tester.Log("RemoveXAttr", name, attr, ok)
 	if !ok {
-		return fuse.ENODATA
+		return fuse.ENOATTR
 	}
 	delete(fs.attrs, attr)
 	return fuse.OK
 }
 
-func readXAttr(p, a string) (val []byte, err error) {
-	val = make([]byte, 1024)
-	return getXAttr(p, a, val)
-}
-
-func xattrTestCase(t *testing.T, nm string) (mountPoint string, cleanup func()) {
-	xfs := NewXAttrFs(nm, xattrGolden)
-	xfs.tester = t
-	mountPoint, err := ioutil.TempDir("", "go-fuse-xattr_test")
-	if err != nil {
-		t.Fatalf("TempDir failed: %v", err)
-	}
+func xattrTestCase(t *testing.T, nm string, m map[string][]byte) (mountPoint string, cleanup func()) {
+	xfs := NewXAttrFs(nm, m)
+	mountPoint = t.TempDir()
 
 	nfs := NewPathNodeFs(xfs, nil)
-	state, _, err := nodefs.MountRoot(mountPoint, nfs.Root(), nil)
+	state, _, err := nodefs.MountRoot(mountPoint, nfs.Root(),
+		&nodefs.Options{Debug: VerboseTest()})
 	if err != nil {
-		t.Fatalf("TempDir failed: %v", err)
+		t.Fatalf("MountRoot failed: %v", err)
 	}
-	state.SetDebug(VerboseTest())
 
 	go state.Serve()
 	return mountPoint, func() {
 		state.Unmount()
-		os.RemoveAll(mountPoint)
 	}
 }
 
+func TestXAttrNoAttrs(t *testing.T) {
+	nm := xattrFilename
+	mountPoint, clean := xattrTestCase(t, nm, make(map[string][]byte))
+	defer clean()
+
+	mounted := filepath.Join(mountPoint, nm)
+	attrs, err := listXAttr(mounted)
+	if err != nil {
+		t.Error("Unexpected ListXAttr error", err)
+	}
+
+	if len(attrs) > 0 {
+		t.Errorf("ListXAttr(%s) = %s, want empty slice", mounted, attrs)
+	}
+}
+
+func readXAttr(p, a string) (val []byte, err error) {
+	val = make([]byte, 1024)
+	return getXAttr(p, a, val)
+}
+
 func TestXAttrNoExist(t *testing.T) {
 	nm := xattrFilename
-	mountPoint, clean := xattrTestCase(t, nm)
+	mountPoint, clean := xattrTestCase(t, nm, xattrGolden)
 	defer clean()
 
 	mounted := filepath.Join(mountPoint, nm)
@@ -144,7 +157,7 @@ func TestXAttrNoExist(t *testing.T) {
 
 func TestXAttrRead(t *testing.T) {
 	nm := xattrFilename
-	mountPoint, clean := xattrTestCase(t, nm)
+	mountPoint, clean := xattrTestCase(t, nm, xattrGolden)
 	defer clean()
 
 	mounted := filepath.Join(mountPoint, nm)
@@ -172,8 +185,7 @@ func TestXAttrRead(t *testing.T) {
 		}
 	}
 
-	err = sysSetxattr(mounted, "third", []byte("value"), 0)
-	if err != nil {
+	if err = syscall.Setxattr(mounted, "third", []byte("value"), 0); err != nil {
 		t.Error("Setxattr error", err)
 	}
 	val, err := readXAttr(mounted, "third")
@@ -181,9 +193,11 @@ func TestXAttrRead(t *testing.T) {
 		t.Error("Read back set xattr:", err, string(val))
 	}
 
-	sysRemovexattr(mounted, "third")
+	if err := syscall.Removexattr(mounted, "third"); err != nil {
+		t.Errorf("Removexattr: %v", err)
+	}
 	val, err = readXAttr(mounted, "third")
-	if err != syscall.ENODATA {
+	if fuse.ToStatus(err) != fuse.ENOATTR {
 		t.Error("Data not removed?", err, val)
 	}
 }
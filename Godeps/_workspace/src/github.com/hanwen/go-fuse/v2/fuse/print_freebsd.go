@@ -0,0 +1,5 @@
+package fuse
+
+func init() {
+	initFlagNames.set(CAP_NO_OPENDIR_SUPPORT, "NO_OPENDIR_SUPPORT")
+}
@@ -1,11 +1,9 @@
 package fuse
 
-import (
-	"fmt"
-)
+import "fmt"
 
 func (s *Server) setSplice() {
-	panic("darwin has no splice.")
+	s.canSplice = false
 }
 
 func (ms *Server) trySplice(header []byte, req *request, fdData *readResultFd) error {
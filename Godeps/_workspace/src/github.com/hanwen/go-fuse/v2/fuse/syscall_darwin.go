@@ -1,45 +1,26 @@
+// Copyright 2016 the Go-FUSE Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
 package fuse
 
 import (
 	"bytes"
-	"os"
 	"syscall"
 	"unsafe"
 )
 
-// TODO - move these into Go's syscall package.
-
-func sys_writev(fd int, iovecs *syscall.Iovec, cnt int) (n int, errno int) {
-	n1, _, e1 := syscall.Syscall(
-		syscall.SYS_WRITEV,
-		uintptr(fd), uintptr(unsafe.Pointer(iovecs)), uintptr(cnt))
-	return int(n1), int(e1)
-}
-
-func writev(fd int, packet [][]byte) (n int, err error) {
-	iovecs := make([]syscall.Iovec, 0, len(packet))
-
-	for _, v := range packet {
-		if len(v) == 0 {
-			continue
-		}
-		vec := syscall.Iovec{
-			Base: &v[0],
-		}
-		vec.SetLen(len(v))
-		iovecs = append(iovecs, vec)
+func getxattr(path string, attr string, dest []byte) (sz int, errno int) {
+	pathBs, err := syscall.BytePtrFromString(path)
+	if err != nil {
+		return 0, int(syscall.EINVAL)
 	}
 
-	n, errno := sys_writev(fd, &iovecs[0], len(iovecs))
-	if errno != 0 {
-		err = os.NewSyscallError("writev", syscall.Errno(errno))
+	attrBs, err := syscall.BytePtrFromString(attr)
+	if err != nil {
+		return 0, int(syscall.EINVAL)
 	}
-	return n, err
-}
 
-func getxattr(path string, attr string, dest []byte) (sz int, errno int) {
-	pathBs := syscall.StringBytePtr(path)
-	attrBs := syscall.StringBytePtr(attr)
 	size, _, errNo := syscall.Syscall6(
 		syscall.SYS_GETXATTR,
 		uintptr(unsafe.Pointer(pathBs)),
@@ -66,7 +47,11 @@ func GetXAttr(path string, attr string, dest []byte) (value []byte, errno int) {
 }
 
 func listxattr(path string, dest []byte) (sz int, errno int) {
-	pathbs := syscall.StringBytePtr(path)
+	pathbs, err := syscall.BytePtrFromString(path)
+	if err != nil {
+		return 0, int(syscall.EINVAL)
+	}
+
 	var destPointer unsafe.Pointer
 	if len(dest) > 0 {
 		destPointer = unsafe.Pointer(&dest[0])
@@ -103,8 +88,16 @@ func ListXAttr(path string) (attributes []string, errno int) {
 }
 
 func Setxattr(path string, attr string, data []byte, flags int) (errno int) {
-	pathbs := syscall.StringBytePtr(path)
-	attrbs := syscall.StringBytePtr(attr)
+	pathbs, err := syscall.BytePtrFromString(path)
+	if err != nil {
+		return int(syscall.EINVAL)
+	}
+
+	attrbs, err := syscall.BytePtrFromString(attr)
+	if err != nil {
+		return int(syscall.EINVAL)
+	}
+
 	_, _, errNo := syscall.Syscall6(
 		syscall.SYS_SETXATTR,
 		uintptr(unsafe.Pointer(pathbs)),
@@ -117,8 +110,16 @@ func Setxattr(path string, attr string, data []byte, flags int) (errno int) {
 }
 
 func Removexattr(path string, attr string) (errno int) {
-	pathbs := syscall.StringBytePtr(path)
-	attrbs := syscall.StringBytePtr(attr)
+	pathbs, err := syscall.BytePtrFromString(path)
+	if err != nil {
+		return int(syscall.EINVAL)
+	}
+
+	attrbs, err := syscall.BytePtrFromString(attr)
+	if err != nil {
+		return int(syscall.EINVAL)
+	}
+
 	_, _, errNo := syscall.Syscall(
 		syscall.SYS_REMOVEXATTR,
 		uintptr(unsafe.Pointer(pathbs)),
@@ -1,3 +1,5 @@
+//go:build !linux
+
 package fuse
 
 import (
@@ -6,15 +8,19 @@ import (
 	"unsafe"
 )
 
-// TODO - move these into Go's syscall package.
-
-func sys_writev(fd int, iovecs *syscall.Iovec, cnt int) (n int, errno int) {
+func sys_writev(fd int, iovecs *syscall.Iovec, cnt int) (n int, err error) {
 	n1, _, e1 := syscall.Syscall(
 		syscall.SYS_WRITEV,
 		uintptr(fd), uintptr(unsafe.Pointer(iovecs)), uintptr(cnt))
-	return int(n1), int(e1)
+	n = int(n1)
+	if e1 != 0 {
+		err = syscall.Errno(e1)
+	}
+	return n, err
 }
 
+// Until golang.orgx/sys/unix provides Writev for Darwin and FreeBSD,
+// keep the syscall wrapping funcitons.
 func writev(fd int, packet [][]byte) (n int, err error) {
 	iovecs := make([]syscall.Iovec, 0, len(packet))
 
@@ -29,9 +35,13 @@ func writev(fd int, packet [][]byte) (n int, err error) {
 		iovecs = append(iovecs, vec)
 	}
 
-	n, errno := sys_writev(fd, &iovecs[0], len(iovecs))
-	if errno != 0 {
-		err = os.NewSyscallError("writev", syscall.Errno(errno))
+	sysErr := handleEINTR(func() error {
+		var err error
+		n, err = sys_writev(fd, &iovecs[0], len(iovecs))
+		return err
+	})
+	if sysErr != nil {
+		err = os.NewSyscallError("writev", sysErr)
 	}
 	return n, err
 }
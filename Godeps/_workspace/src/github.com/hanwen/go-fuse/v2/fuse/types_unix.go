@@ -0,0 +1,41 @@
+//go:build !darwin
+
+package fuse
+
+type Attr struct {
+	Ino  uint64
+	Size uint64
+
+	// Blocks is the number of 512-byte blocks that the file occupies on disk.
+	Blocks    uint64
+	Atime     uint64
+	Mtime     uint64
+	Ctime     uint64
+	Atimensec uint32
+	Mtimensec uint32
+	Ctimensec uint32
+	Mode      uint32
+	Nlink     uint32
+	Owner
+	Rdev uint32
+
+	// Blksize is the preferred size for file system operations.
+	Blksize uint32
+	Padding uint32
+}
+
+type SetAttrIn struct {
+	SetAttrInCommon
+}
+
+type SetXAttrIn struct {
+	InHeader
+	Size  uint32
+	Flags uint32
+}
+
+type GetXAttrIn struct {
+	InHeader
+	Size    uint32
+	Padding uint32
+}
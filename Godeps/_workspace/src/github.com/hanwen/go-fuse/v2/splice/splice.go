@@ -1,12 +1,18 @@
+//go:build linux
+
+// Copyright 2016 the Go-FUSE Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
 package splice
 
 // Routines for efficient file to file copying.
 
 import (
 	"fmt"
-	"io/ioutil"
 	"log"
 	"os"
+	"sync"
 	"syscall"
 )
 
@@ -26,8 +32,13 @@ func MaxPipeSize() int {
 // Since Linux 2.6.11, the pipe capacity is 65536 bytes.
 const DefaultPipeSize = 16 * 4096
 
+var (
+	devNullFDOnce  sync.Once
+	devNullFDValue int
+)
+
 func init() {
-	content, err := ioutil.ReadFile("/proc/sys/fs/pipe-max-size")
+	content, err := os.ReadFile("/proc/sys/fs/pipe-max-size")
 	if err != nil {
 		maxPipeSize = DefaultPipeSize
 	} else {
@@ -46,6 +57,18 @@ func init() {
 	w.Close()
 }
 
+// We empty pipes by splicing to /dev/null.
+func devNullFD() int {
+	devNullFDOnce.Do(func() {
+		fd, err := syscall.Open("/dev/null", os.O_WRONLY, 0)
+		if err != nil {
+			panic(fmt.Sprintf("failed to open /dev/null: %s", err))
+		}
+		devNullFDValue = fd
+	})
+	return devNullFDValue
+}
+
 // copy & paste from syscall.
 func fcntl(fd uintptr, cmd int, arg int) (val int, errno syscall.Errno) {
 	r0, _, e1 := syscall.Syscall(syscall.SYS_FCNTL, fd, uintptr(cmd), uintptr(arg))
@@ -57,30 +80,27 @@ func fcntl(fd uintptr, cmd int, arg int) (val int, errno syscall.Errno) {
 const F_SETPIPE_SZ = 1031
 const F_GETPIPE_SZ = 1032
 
+func osPipe() (int, int, error) {
+	var fds [2]int
+	err := syscall.Pipe2(fds[:], syscall.O_NONBLOCK)
+	return fds[0], fds[1], err
+}
+
 func newSplicePair() (p *Pair, err error) {
 	p = &Pair{}
-	p.r, p.w, err = os.Pipe()
+	p.r, p.w, err = osPipe()
 	if err != nil {
 		return nil, err
 	}
-
-	errNo := syscall.Errno(0)
-	for _, f := range []*os.File{p.r, p.w} {
-		_, errNo = fcntl(f.Fd(), syscall.F_SETFL, syscall.O_NONBLOCK)
-		if errNo != 0 {
-			p.Close()
-			return nil, os.NewSyscallError("fcntl setfl", errNo)
-		}
-	}
-
-	p.size, errNo = fcntl(p.r.Fd(), F_GETPIPE_SZ, 0)
-	if errNo == syscall.EINVAL {
+	var errNo syscall.Errno
+	p.size, errNo = fcntl(uintptr(p.r), F_GETPIPE_SZ, 0)
+	if err == syscall.EINVAL {
 		p.size = DefaultPipeSize
 		return p, nil
 	}
 	if errNo != 0 {
 		p.Close()
-		return nil, os.NewSyscallError("fcntl getsize", errNo)
+		return nil, fmt.Errorf("fcntl getsize: %v", errNo)
 	}
 	return p, nil
 }
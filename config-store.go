@@ -18,12 +18,13 @@ import (
 	"syscall"
 	"os/signal"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/gambol99/config-store/store"
-	"github.com/hanwen/go-fuse/fuse/nodefs"
-	"github.com/hanwen/go-fuse/fuse/pathfs"
-	"github.com/hanwen/go-fuse/fuse"
+	"github.com/hanwen/go-fuse/v2/fuse/nodefs"
+	"github.com/hanwen/go-fuse/v2/fuse/pathfs"
+	"github.com/hanwen/go-fuse/v2/fuse"
 	"github.com/golang/glog"
 )
 
@@ -34,44 +35,251 @@ const (
 
 var (
 	mount_point *string
+	settings_file *string
+	import_file, import_path *string
+	import_prune *bool
+	allow_other *bool
 )
 
 func init() {
 	mount_point = flag.String("mount", DEFAULT_MOUNT_POINT, "the mount of the fuse filesystem")
+	settings_file = flag.String("config", "", "a toml settings file of the flags and the [[mounts]], the flags on the command line take precedence; reloaded on SIGHUP")
+	import_file = flag.String("import", "", "import a JSON file into the k/v store in a single transaction and exit")
+	import_path = flag.String("import-path", "/", "the path in the k/v store the file is imported under")
+	import_prune = flag.Bool("import-prune", false, "remove any keys under the import path which are not in the file")
+	allow_other = flag.Bool("allow-other", false, "allow users other than the one mounting to access the filesystem")
 }
 
+/* A mount being served */
+type Mounted struct {
+	/* the options it was last mounted or reloaded with */
+	Options *store.MountOptions
+	/* the fuse server for the mount */
+	Server *fuse.Server
+	/* the filesystem, which is swapped on a reload */
+	Filesystem *store.ReloadableFileSystem
+}
+
+/* The mounts by mount point; held for the length of a reload */
+var mounted = struct {
+	sync.Mutex
+	Mounts map[string]*Mounted
+}{Mounts: make(map[string]*Mounted, 0)}
+
+/* the servers being served, main exits once they are all unmounted */
+var serving sync.WaitGroup
+
 func main() {
 	flag.Parse()
-	/* step: lets check we have everything we* need */
-	if *mount_point == "" {
-		glog.Fatal("you have not specified a mount point to bind the filesystem to")
+	/* step: the flags given on the command line win over the settings file, on a reload as well */
+	explicit := store.CommandLineFlags()
+	settings, err := LoadSettings(explicit)
+	if err != nil {
+		glog.Fatalf("Invalid settings, error: %s", err)
 	}
-
-	/* step: lets create the file system */
-	filesystem, err := store.NewFuseKVFileSystem()
+	store.LoadRuntimeSettings()
+	/* step: are we importing rather than mounting? */
+	if *import_file != "" {
+		if err := store.ImportFile(*import_file, *import_path, *import_prune); err != nil {
+			glog.Fatalf("Failed to import the file: %s, error: %s", *import_file, err)
+		}
+		glog.Flush()
+		os.Exit(0)
+	}
+	mounts, err := Mounts(settings)
 	if err != nil {
-		glog.Errorf("Failed to create the K/V FileSystem, error: %s", err)
+		glog.Fatalf("Invalid mount options, error: %s", err)
 	}
-
-	nfs := pathfs.NewPathNodeFs( filesystem, nil)
-	if server, _, err := nodefs.MountRoot(
-		*mount_point, nfs.Root(), &nodefs.Options{
-			NegativeTimeout: 0,
-			AttrTimeout:     time.Second,
-			EntryTimeout:    time.Second,
-			Owner:           &fuse.Owner{
-				Uid: uint32(0),
-				Gid: uint32(0)}}); err != nil {
-		glog.Fatalf("Mount fail: %v\n", err)
-	} else {
-		signalChannel := make(chan os.Signal, 1)
-		signal.Notify(signalChannel, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
-		go func() {
-			<-signalChannel
+	if err := store.ValidateSettings(mounts); err != nil {
+		glog.Fatalf("Invalid settings, error: %s", err)
+	}
+	for _, options := range mounts {
+		mount, err := Mount(options)
+		if err != nil {
+			Unmount()
+			glog.Fatalf("Failed to mount: %s, error: %s", options.Mount, err)
+		}
+		mounted.Mounts[options.Mount] = mount
+	}
+	for _, mount := range mounted.Mounts {
+		Serve(mount)
+	}
+	if err := store.NewMetricsServer(Filesystems); err != nil {
+		Unmount()
+		glog.Fatalf("Failed to start the metrics server, error: %s", err)
+	}
+	control, err := store.NewControlServer(Filesystems, func() error { return Reload(explicit) })
+	if err != nil {
+		Unmount()
+		glog.Fatalf("Failed to start the control api, error: %s", err)
+	}
+	signalChannel := make(chan os.Signal, 1)
+	signal.Notify(signalChannel, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+	go func() {
+		for received := range signalChannel {
+			if received == syscall.SIGHUP {
+				glog.Infof("Recieved a SIGHUP, reloading the settings and the mounts")
+				Reload(explicit)
+				continue
+			}
 			glog.Infof("Recieved a kill signal, attempting to unmount and exit")
-			server.Unmount()
+			control.Close()
+			Unmount()
 			os.Exit(0)
-		}()
-		server.Serve()
+		}
+	}()
+	serving.Wait()
+}
+
+/* The filesystems of the mounts, for the control api */
+func Filesystems() []*store.ReloadableFileSystem {
+	mounted.Lock()
+	defer mounted.Unlock()
+	filesystems := make([]*store.ReloadableFileSystem, 0)
+	for _, mount := range mounted.Mounts {
+		filesystems = append(filesystems, mount.Filesystem)
+	}
+	return filesystems
+}
+
+/* Unmount everything, we are on the way out */
+func Unmount() {
+	mounted.Lock()
+	defer mounted.Unlock()
+	for _, mount := range mounted.Mounts {
+		mount.Server.Unmount()
+	}
+}
+
+/* Read the settings file into the flags, there are no settings without one */
+func LoadSettings(explicit map[string]bool) (*store.Settings, error) {
+	if *settings_file == "" {
+		return new(store.Settings), nil
+	}
+	settings, err := store.LoadSettings(*settings_file)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := settings.Apply(explicit); err != nil {
+		return nil, err
 	}
+	glog.Infof("Loaded the settings file: %s, settings: %d, mounts: %d", *settings_file, len(settings.Flags), len(settings.Mounts))
+	return settings, nil
+}
+
+/* The mounts come from the settings file, otherwise it's the one given by the flags */
+func Mounts(settings *store.Settings) ([]*store.MountOptions, error) {
+	if len(settings.Mounts) > 0 {
+		return settings.Mounts, settings.ParseMounts()
+	}
+	options, err := store.DefaultMountOptions(*mount_point)
+	if err != nil {
+		return nil, err
+	}
+	return []*store.MountOptions{options}, nil
+}
+
+/*
+Reload the settings file, if there is one, and the mounts, without unmounting them. If the
+settings are invalid the flags are put back and we carry on as we were. Otherwise each mount gets
+a new filesystem with new backends, permissions, access policy and audit log; the calls under way
+and the files already open are served by the one they started with. Mounts which are new in the
+settings are mounted and those which are gone are unmounted
+*/
+func Reload(explicit map[string]bool) error {
+	mounted.Lock()
+	defer mounted.Unlock()
+	settings := new(store.Settings)
+	restore := func() {}
+	if *settings_file != "" {
+		var err error
+		if settings, err = store.LoadSettings(*settings_file); err != nil {
+			glog.Errorf("Failed to reload the settings, keeping the current ones, error: %s", err)
+			return err
+		}
+		if restore, err = settings.Apply(explicit); err != nil {
+			glog.Errorf("Failed to reload the settings, keeping the current ones, error: %s", err)
+			return err
+		}
+	}
+	mounts, err := Mounts(settings)
+	if err == nil {
+		err = store.ValidateSettings(mounts)
+	}
+	if err != nil {
+		restore()
+		glog.Errorf("Failed to reload the settings, keeping the current ones, error: %s", err)
+		return err
+	}
+	/* step: the calls from here on use the new settings, and the filesystems connect to the backends again */
+	store.LoadRuntimeSettings()
+	store.ResetSharedServices()
+	wanted := make(map[string]bool, 0)
+	for _, options := range mounts {
+		wanted[options.Mount] = true
+		if mount, found := mounted.Mounts[options.Mount]; found {
+			if err = mount.Filesystem.Reload(options); err == nil {
+				mount.Options = options
+			}
+			continue
+		}
+		mount, failed := Mount(options)
+		if failed != nil {
+			glog.Errorf("Failed to mount: %s, error: %s", options.Mount, failed)
+			err = failed
+			continue
+		}
+		mounted.Mounts[options.Mount] = mount
+		Serve(mount)
+	}
+	/* step: the new mounts are up before the old are taken down, so we never run out of mounts */
+	for point, mount := range mounted.Mounts {
+		if wanted[point] {
+			continue
+		}
+		glog.Infof("The mount: %s is no longer in the settings, unmounting it", point)
+		if failed := mount.Server.Unmount(); failed != nil {
+			glog.Errorf("Failed to unmount: %s, error: %s", point, failed)
+			err = failed
+			continue
+		}
+		delete(mounted.Mounts, point)
+	}
+	if err != nil {
+		glog.Warningf("Reloaded the settings, though not every mount could be reloaded")
+		return err
+	}
+	glog.Infof("Reloaded the settings and the mounts: %d", len(mounted.Mounts))
+	return nil
+}
+
+/* Create the filesystem for the mount and mount it, the server is ready to serve */
+func Mount(options *store.MountOptions) (*Mounted, error) {
+	filesystem, err := store.NewReloadableFileSystem(options)
+	if err != nil {
+		glog.Errorf("Failed to create the K/V FileSystem, error: %s", err)
+		return nil, err
+	}
+	nfs := pathfs.NewPathNodeFs(filesystem, nil)
+	/* step: the ownership comes from the filesystem, so we don't set an owner on the mount */
+	connector := nodefs.NewFileSystemConnector(nfs.Root(), &nodefs.Options{
+		NegativeTimeout: 0,
+		AttrTimeout:     time.Second,
+		EntryTimeout:    time.Second})
+	server, err := fuse.NewServer(connector.RawFS(), options.Mount, &fuse.MountOptions{
+		AllowOther: *allow_other})
+	if err != nil {
+		filesystem.FS().Close()
+		return nil, err
+	}
+	return &Mounted{Options: options, Server: server, Filesystem: filesystem}, nil
+}
+
+/* Serve the mount until it's unmounted */
+func Serve(mount *Mounted) {
+	serving.Add(1)
+	go func() {
+		defer serving.Done()
+		mount.Server.Serve()
+	}()
 }
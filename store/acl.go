@@ -0,0 +1,299 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gambol99/config-store/store/config"
+	"github.com/golang/glog"
+	"github.com/hanwen/go-fuse/v2/fuse"
+)
+
+const (
+	/* the operations a rule can allow */
+	ACL_READ = 1 << iota
+	ACL_LIST
+	ACL_WRITE
+	ACL_DELETE
+	ACL_ALL = ACL_READ | ACL_LIST | ACL_WRITE | ACL_DELETE
+	/* not an operation as such, seeing the node exists */
+	ACL_LOOKUP = 0
+)
+
+var access_policy *string
+
+var InvalidAccessRuleErr = errors.New("Invalid access rule, must be: <uid:|gid:|cgroup:|*> <read,list,write,delete|all> <pattern>")
+
+func init() {
+	access_policy = flag.String("acl", "", "a policy file of the prefixes and operations each caller is allowed, i.e. uid:1000 read,list /teams/a/**")
+}
+
+type AccessRule struct {
+	/* the kind of subject, uid, gid, cgroup or * for anyone */
+	Kind string
+	/* the uid or gid, or a pattern of the cgroup */
+	Subject string
+	/* the operations allowed */
+	Operations uint32
+	/* the pattern of the paths the rule covers */
+	Pattern string
+}
+
+/*
+The access policy maps the callers to the paths and operations they are allowed; once a policy
+is loaded anything not allowed by a rule is denied, root included. The directories above
+anything a caller is allowed remain visible, so they can find their way to it. The patterns
+match the paths in the backend, so a mount rooted at a prefix checks the prefix joined path
+*/
+type AccessPolicy struct {
+	/* the rules of the policy */
+	Rules []*AccessRule
+	/* the audit log the denials are recorded to, if any */
+	Audit *Auditor
+	/* the prefix of the mount in the backend */
+	Prefix string
+}
+
+/* Create the policy from the flags, without a policy file everything is allowed */
+func NewAccessPolicy(auditor *Auditor) (*AccessPolicy, error) {
+	if *access_policy == "" {
+		return nil, nil
+	}
+	rules, err := LoadAccessRules(*access_policy)
+	if err != nil {
+		glog.Errorf("Failed to load the access policy: %s, error: %s", *access_policy, err)
+		return nil, err
+	}
+	policy := &AccessPolicy{Rules: rules, Audit: auditor}
+	glog.Infof("Loaded the access policy: %s, rules: %d", *access_policy, len(rules))
+	return policy, nil
+}
+
+/* The policy of a mount rooted at the prefix, the mounts share the rules */
+func (r *AccessPolicy) ForMount(prefix string) *AccessPolicy {
+	if r == nil {
+		return nil
+	}
+	return &AccessPolicy{Rules: r.Rules, Audit: r.Audit, Prefix: prefix}
+}
+
+func LoadAccessRules(filename string) ([]*AccessRule, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	rules := make([]*AccessRule, 0)
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if index := strings.Index(text, "#"); index >= 0 {
+			text = text[:index]
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		rule, err := ParseAccessRule(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", line, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, scanner.Err()
+}
+
+/* Parse a rule, i.e. uid:app read,list /teams/a/** or cgroup:/docker/** read /shared/** */
+func ParseAccessRule(line string) (*AccessRule, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 3 || !strings.HasPrefix(fields[2], "/") {
+		return nil, InvalidAccessRuleErr
+	}
+	rule := &AccessRule{Kind: fields[0], Pattern: filepath.Clean(fields[2])}
+	if index := strings.Index(fields[0], ":"); index >= 0 {
+		rule.Kind, rule.Subject = fields[0][:index], fields[0][index+1:]
+	}
+	switch rule.Kind {
+	case "*", "cgroup":
+	case "uid":
+		if _, err := strconv.ParseUint(rule.Subject, 10, 32); err != nil {
+			account, err := user.Lookup(rule.Subject)
+			if err != nil {
+				return nil, err
+			}
+			rule.Subject = account.Uid
+		}
+	case "gid":
+		if _, err := strconv.ParseUint(rule.Subject, 10, 32); err != nil {
+			group, err := user.LookupGroup(rule.Subject)
+			if err != nil {
+				return nil, err
+			}
+			rule.Subject = group.Gid
+		}
+	default:
+		return nil, InvalidAccessRuleErr
+	}
+	for _, operation := range strings.Split(fields[1], ",") {
+		switch operation {
+		case "read":
+			rule.Operations |= ACL_READ
+		case "list":
+			rule.Operations |= ACL_LIST
+		case "write":
+			rule.Operations |= ACL_WRITE
+		case "delete":
+			rule.Operations |= ACL_DELETE
+		case "all":
+			rule.Operations |= ACL_ALL
+		default:
+			return nil, InvalidAccessRuleErr
+		}
+	}
+	return rule, nil
+}
+
+func OperationName(operation uint32) string {
+	switch operation {
+	case ACL_READ:
+		return "read"
+	case ACL_LIST:
+		return "list"
+	case ACL_WRITE:
+		return "write"
+	case ACL_DELETE:
+		return "delete"
+	}
+	return "lookup"
+}
+
+/* Check the caller is allowed the operation on the path, recording it to the audit log if not */
+func (r *AccessPolicy) Allowed(path string, caller *fuse.Context, operation uint32) bool {
+	if r == nil || caller == nil {
+		return true
+	}
+	path = r.BackendPath(path)
+	if r.Check(path, caller, operation) {
+		return true
+	}
+	r.Denied(path, caller, operation)
+	return false
+}
+
+/* Check the caller is allowed the operation without logging a denial, i.e. to filter a listing */
+func (r *AccessPolicy) Permits(path string, caller *fuse.Context, operation uint32) bool {
+	if r == nil || caller == nil {
+		return true
+	}
+	return r.Check(r.BackendPath(path), caller, operation)
+}
+
+/* The path in the backend of a path in the mount */
+func (r *AccessPolicy) BackendPath(path string) string {
+	return filepath.Join("/", r.Prefix, path)
+}
+
+func (r *AccessPolicy) Check(path string, caller *fuse.Context, operation uint32) bool {
+	var cgroups []string
+	for _, rule := range r.Rules {
+		switch rule.Kind {
+		case "uid":
+			if rule.Subject != strconv.FormatUint(uint64(caller.Uid), 10) {
+				continue
+			}
+		case "gid":
+			if rule.Subject != strconv.FormatUint(uint64(caller.Gid), 10) {
+				continue
+			}
+		case "cgroup":
+			if cgroups == nil {
+				cgroups = CallerCgroups(caller.Pid)
+			}
+			if !MatchAny(rule.Subject, cgroups) {
+				continue
+			}
+		}
+		if operation == ACL_LOOKUP {
+			/* step: anything allowed on the path, or under it, makes the path visible */
+			if config.MatchPattern(rule.Pattern, path) || MatchAncestor(rule.Pattern, path) {
+				return true
+			}
+			continue
+		}
+		if rule.Operations&operation == operation && config.MatchPattern(rule.Pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *AccessPolicy) Denied(path string, caller *fuse.Context, operation uint32) {
+	glog.Warningf("Access denied, uid: %d, gid: %d, pid: %d, operation: %s, path: %s",
+		caller.Uid, caller.Gid, caller.Pid, OperationName(operation), path)
+	r.Audit.Denied(caller, path, OperationName(operation))
+}
+
+/* Check if the path is a directory above anything the pattern could match */
+func MatchAncestor(pattern, path string) bool {
+	parts := strings.Split(strings.Trim(pattern, "/"), "/")
+	for index, part := range strings.Split(strings.Trim(path, "/"), "/") {
+		if part == "" {
+			return true
+		}
+		if index >= len(parts) {
+			return false
+		}
+		if parts[index] == "**" {
+			return true
+		}
+		if matched, _ := filepath.Match(parts[index], part); !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func MatchAny(pattern string, paths []string) bool {
+	for _, path := range paths {
+		if config.MatchPattern(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+/* The cgroups of the process, i.e. the paths in /proc/<pid>/cgroup */
+func CallerCgroups(pid uint32) []string {
+	cgroups := make([]string, 0)
+	content, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		Verbose("CallerCgroups() unable to read the cgroups of pid: %d, error: %s", pid, err)
+		return cgroups
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if fields := strings.SplitN(line, ":", 3); len(fields) == 3 {
+			cgroups = append(cgroups, fields[2])
+		}
+	}
+	return cgroups
+}
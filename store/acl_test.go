@@ -0,0 +1,112 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"testing"
+
+	"github.com/hanwen/go-fuse/v2/fuse"
+)
+
+func NewTestAccessPolicy(t *testing.T, lines ...string) *AccessPolicy {
+	policy := new(AccessPolicy)
+	for _, line := range lines {
+		rule, err := ParseAccessRule(line)
+		if err != nil {
+			t.Fatalf("failed to parse the rule: %s, error: %s", line, err)
+		}
+		policy.Rules = append(policy.Rules, rule)
+	}
+	return policy
+}
+
+func Caller(uid, gid uint32) *fuse.Context {
+	return &fuse.Context{Caller: fuse.Caller{Owner: fuse.Owner{Uid: uid, Gid: gid}}}
+}
+
+/* The mounts share the one policy, whose patterns match the paths in the backend */
+func TestAccessPolicyForMount(t *testing.T) {
+	policy := NewTestAccessPolicy(t,
+		"uid:1000 read,list /prod/payments/**",
+		"uid:1001 read /prod/orders/db/*")
+	tests := []struct {
+		Prefix    string
+		Path      string
+		Uid       uint32
+		Operation uint32
+		Allowed   bool
+	}{
+		{"/prod/payments", "/db/password", 1000, ACL_READ, true},
+		{"/prod/payments", "/", 1000, ACL_LIST, true},
+		{"/prod/payments", "/db/password", 1000, ACL_WRITE, false},
+		{"/prod/orders", "/db/password", 1000, ACL_READ, false},
+		{"/prod/orders", "/db/password", 1001, ACL_READ, true},
+		{"/prod/orders", "/db", 1001, ACL_LOOKUP, true},
+		{"/prod/orders", "/cache", 1001, ACL_LOOKUP, false},
+		{"/prod", "/payments/db/password", 1000, ACL_READ, true},
+		{"/prod", "/orders/db/password", 1000, ACL_READ, false},
+		{"/", "/prod/payments/db/password", 1000, ACL_READ, true},
+		{"/", "/db/password", 1000, ACL_READ, false},
+	}
+	for _, test := range tests {
+		mount := policy.ForMount(test.Prefix)
+		if allowed := mount.Allowed(test.Path, Caller(test.Uid, test.Uid), test.Operation); allowed != test.Allowed {
+			t.Errorf("prefix: %s, path: %s, uid: %d, operation: %s, expected allowed: %t",
+				test.Prefix, test.Path, test.Uid, OperationName(test.Operation), test.Allowed)
+		}
+	}
+	var nopolicy *AccessPolicy
+	if mount := nopolicy.ForMount("/prod"); !mount.Allowed("/anything", Caller(1000, 1000), ACL_WRITE) {
+		t.Errorf("expected everything to be allowed without a policy")
+	}
+}
+
+func TestParseAccessRule(t *testing.T) {
+	tests := []struct {
+		Line string
+		Rule *AccessRule
+	}{
+		{"uid:1000 read,list /teams/a/**", &AccessRule{Kind: "uid", Subject: "1000", Operations: ACL_READ | ACL_LIST, Pattern: "/teams/a/**"}},
+		{"uid:root write /teams/a", &AccessRule{Kind: "uid", Subject: "0", Operations: ACL_WRITE, Pattern: "/teams/a"}},
+		{"gid:0 delete /teams/a/*", &AccessRule{Kind: "gid", Subject: "0", Operations: ACL_DELETE, Pattern: "/teams/a/*"}},
+		{"gid:root read /shared", &AccessRule{Kind: "gid", Subject: "0", Operations: ACL_READ, Pattern: "/shared"}},
+		{"cgroup:/docker/** read /shared/**", &AccessRule{Kind: "cgroup", Subject: "/docker/**", Operations: ACL_READ, Pattern: "/shared/**"}},
+		{"* all /public/", &AccessRule{Kind: "*", Operations: ACL_ALL, Pattern: "/public"}},
+		{"  uid:1000   read   /teams/b/../a/**  ", &AccessRule{Kind: "uid", Subject: "1000", Operations: ACL_READ, Pattern: "/teams/a/**"}},
+		{"uid:1000 read", nil},
+		{"uid:1000 read teams/a", nil},
+		{"uid:1000 read /teams/a extra", nil},
+		{"uid:1000 execute /teams/a", nil},
+		{"uid:1000 read, /teams/a", nil},
+		{"pid:1 read /teams/a", nil},
+		{"uid:no-such-user-here read /teams/a", nil},
+		{"gid:no-such-group-here read /teams/a", nil},
+	}
+	for _, test := range tests {
+		rule, err := ParseAccessRule(test.Line)
+		if test.Rule == nil {
+			if err == nil {
+				t.Errorf("line: %q, expected an error, got: %+v", test.Line, rule)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("line: %q, unexpected error: %s", test.Line, err)
+			continue
+		}
+		if *rule != *test.Rule {
+			t.Errorf("line: %q, expected: %+v, got: %+v", test.Line, test.Rule, rule)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log/syslog"
+	"math/rand"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/hanwen/go-fuse/v2/fuse"
+)
+
+const (
+	/* the operations we audit */
+	AUDIT_READ     = "read"
+	AUDIT_LIST     = "list"
+	AUDIT_WRITE    = "write"
+	AUDIT_TRUNCATE = "truncate"
+	AUDIT_DELETE   = "delete"
+	AUDIT_RENAME   = "rename"
+	AUDIT_TTL      = "ttl"
+	/* a denial by the access policy */
+	AUDIT_ACCESS = "access"
+)
+
+var audit_log *string
+var audit_read_sample *float64
+
+var InvalidAuditSampleErr = errors.New("The audit sample rate for reads must be between 0 and 1")
+
+func init() {
+	audit_log = flag.String("audit", "", "record the operations through the mount, either a file of json lines, syslog or syslog://host:port")
+	audit_read_sample = flag.Float64("audit-read-sample", 1, "the fraction (0 - 1) of the successful reads and listings which are recorded")
+}
+
+/* An entry in the audit log */
+type AuditEvent struct {
+	Time      time.Time `json:"time"`
+	Uid       uint32    `json:"uid"`
+	Gid       uint32    `json:"gid"`
+	Pid       uint32    `json:"pid"`
+	Command   string    `json:"command,omitempty"`
+	Operation string    `json:"operation"`
+	Path      string    `json:"path"`
+	Target    string    `json:"target,omitempty"`
+	Status    string    `json:"status"`
+	Revision  uint64    `json:"revision,omitempty"`
+	/* the operation the access policy denied, i.e. read or write */
+	Denied string `json:"denied,omitempty"`
+}
+
+/*
+The auditor records who did what through the mount; every write and failure is recorded,
+while the successful reads and listings can be sampled as they tend to be the bulk of it.
+The paths are recorded as they are in the backend, the same as the access policy sees them
+*/
+type Auditor struct {
+	/* the log, which the mounts share */
+	*AuditLog
+	/* the prefix of the mount in the backend */
+	Prefix string
+}
+
+type AuditLog struct {
+	sync.Mutex
+	/* where the events are written */
+	Writer io.WriteCloser
+	/* the fraction of the reads we record */
+	ReadSample float64
+}
+
+/* Create the auditor from the flags, nil if auditing is off */
+func NewAuditor() (*Auditor, error) {
+	if *audit_log == "" {
+		return nil, nil
+	}
+	if *audit_read_sample < 0 || *audit_read_sample > 1 {
+		return nil, InvalidAuditSampleErr
+	}
+	auditor := &Auditor{AuditLog: &AuditLog{ReadSample: *audit_read_sample}}
+	var err error
+	switch {
+	case *audit_log == "syslog":
+		auditor.Writer, err = syslog.New(syslog.LOG_INFO|syslog.LOG_AUTH, "config-store")
+	case strings.HasPrefix(*audit_log, "syslog://"):
+		var uri *url.URL
+		if uri, err = url.Parse(*audit_log); err != nil {
+			return nil, err
+		}
+		auditor.Writer, err = syslog.Dial("udp", uri.Host, syslog.LOG_INFO|syslog.LOG_AUTH, "config-store")
+	default:
+		auditor.Writer, err = os.OpenFile(*audit_log, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	}
+	if err != nil {
+		glog.Errorf("Failed to open the audit log: %s, error: %s", *audit_log, err)
+		return nil, err
+	}
+	glog.Infof("Recording the operations to the audit log: %s, read sample: %f", *audit_log, auditor.ReadSample)
+	return auditor, nil
+}
+
+/* The auditor of a mount rooted at the prefix, the mounts share the log */
+func (r *Auditor) ForMount(prefix string) *Auditor {
+	if r == nil {
+		return nil
+	}
+	return &Auditor{AuditLog: r.AuditLog, Prefix: prefix}
+}
+
+/* Close the audit log, once the filesystems recording to it are done */
+func (r *Auditor) Close() error {
+	if r == nil {
+		return nil
+	}
+	r.Lock()
+	defer r.Unlock()
+	return r.Writer.Close()
+}
+
+/* Record the operation, a nil auditor records nothing */
+func (r *Auditor) Record(caller *fuse.Context, operation, path, target string, status fuse.Status, revision uint64) {
+	if r == nil {
+		return
+	}
+	if status == fuse.OK && (operation == AUDIT_READ || operation == AUDIT_LIST) && r.ReadSample < 1 && rand.Float64() >= r.ReadSample {
+		return
+	}
+	event := &AuditEvent{Operation: operation, Status: status.String(), Revision: revision}
+	if target != "" {
+		event.Target = r.BackendPath(target)
+	}
+	r.Write(caller, r.BackendPath(path), event)
+}
+
+/* The path in the backend of a path in the mount */
+func (r *Auditor) BackendPath(path string) string {
+	return filepath.Join("/", r.Prefix, path)
+}
+
+/* Record the access policy denying the caller the operation on the path, which is already the backend one */
+func (r *Auditor) Denied(caller *fuse.Context, path, operation string) {
+	if r == nil {
+		return
+	}
+	r.Write(caller, path, &AuditEvent{Operation: AUDIT_ACCESS, Status: fuse.EACCES.String(), Denied: operation})
+}
+
+func (r *Auditor) Write(caller *fuse.Context, path string, event *AuditEvent) {
+	event.Time = time.Now().UTC()
+	event.Path = filepath.Clean("/" + path)
+	if caller != nil {
+		event.Uid, event.Gid, event.Pid = caller.Uid, caller.Gid, caller.Pid
+		event.Command = CallerCommand(caller.Pid)
+	}
+	entry, _ := json.Marshal(event)
+	r.Lock()
+	defer r.Unlock()
+	if _, err := r.Writer.Write(append(entry, '\n')); err != nil {
+		glog.Errorf("Failed to write to the audit log, error: %s", err)
+	}
+}
+
+/* The name of the command of the process, it's gone if the process has already exited */
+func CallerCommand(pid uint32) string {
+	content, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hanwen/go-fuse/v2/fuse"
+)
+
+/* An audit log kept in memory */
+type AuditBuffer struct {
+	bytes.Buffer
+}
+
+func (r *AuditBuffer) Close() error {
+	return nil
+}
+
+func NewTestAuditor(sample float64) (*Auditor, *AuditBuffer) {
+	buffer := new(AuditBuffer)
+	return &Auditor{AuditLog: &AuditLog{Writer: buffer, ReadSample: sample}}, buffer
+}
+
+func AuditEvents(t *testing.T, buffer *AuditBuffer) []*AuditEvent {
+	events := make([]*AuditEvent, 0)
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		event := new(AuditEvent)
+		if err := json.Unmarshal([]byte(line), event); err != nil {
+			t.Fatalf("invalid audit entry: %s, error: %s", line, err)
+		}
+		events = append(events, event)
+	}
+	buffer.Reset()
+	return events
+}
+
+/* The records of every mount carry the path in the backend, the same as the denials do */
+func TestAuditorForMount(t *testing.T) {
+	auditor, buffer := NewTestAuditor(1)
+	policy := NewTestAccessPolicy(t, "uid:1000 read /prod/app/*")
+	policy.Audit = auditor
+	tests := []struct {
+		Prefix string
+		Path   string
+		Target string
+		Record string
+		Moved  string
+	}{
+		{"/", "app/key", "", "/app/key", ""},
+		{"/prod", "app/key", "", "/prod/app/key", ""},
+		{"/prod/", "/app/key", "app/moved", "/prod/app/key", "/prod/app/moved"},
+	}
+	for _, test := range tests {
+		auditor.ForMount(test.Prefix).Record(Caller(1000, 1000), AUDIT_RENAME, test.Path, test.Target, fuse.OK, 0)
+		policy.ForMount(test.Prefix).Allowed(test.Path, Caller(1001, 1001), ACL_READ)
+		events := AuditEvents(t, buffer)
+		if len(events) != 2 {
+			t.Errorf("prefix: %s, path: %s, expected a record and a denial, got: %d", test.Prefix, test.Path, len(events))
+			continue
+		}
+		if events[0].Path != test.Record || events[0].Target != test.Moved {
+			t.Errorf("prefix: %s, path: %s, expected: %s to: %q, got: %s to: %q", test.Prefix, test.Path, test.Record, test.Moved, events[0].Path, events[0].Target)
+		}
+		if events[1].Path != events[0].Path {
+			t.Errorf("prefix: %s, path: %s, expected the denial at: %s, got: %s", test.Prefix, test.Path, events[0].Path, events[1].Path)
+		}
+	}
+	if auditor := (*Auditor)(nil).ForMount("/prod"); auditor != nil {
+		t.Errorf("expected no auditor when auditing is off")
+	}
+}
+
+/* Only the successful reads and listings are sampled, the writes and failures are always recorded */
+func TestAuditorSampling(t *testing.T) {
+	tests := []struct {
+		Sample    float64
+		Operation string
+		Status    fuse.Status
+		Recorded  bool
+	}{
+		{0, AUDIT_READ, fuse.OK, false},
+		{0, AUDIT_LIST, fuse.OK, false},
+		{0, AUDIT_READ, fuse.EACCES, true},
+		{0, AUDIT_LIST, fuse.ENOENT, true},
+		{0, AUDIT_WRITE, fuse.OK, true},
+		{0, AUDIT_DELETE, fuse.OK, true},
+		{0, AUDIT_TTL, fuse.OK, true},
+		{1, AUDIT_READ, fuse.OK, true},
+		{1, AUDIT_LIST, fuse.OK, true},
+	}
+	for _, test := range tests {
+		auditor, buffer := NewTestAuditor(test.Sample)
+		auditor.Record(Caller(1000, 1000), test.Operation, "/key", "", test.Status, 0)
+		if recorded := len(AuditEvents(t, buffer)) == 1; recorded != test.Recorded {
+			t.Errorf("sample: %f, operation: %s, status: %s, expected recorded: %t", test.Sample, test.Operation, test.Status, test.Recorded)
+		}
+	}
+	/* step: a sample in between records some of the reads, but not all */
+	auditor, buffer := NewTestAuditor(0.5)
+	for i := 0; i < 1000; i++ {
+		auditor.Record(nil, AUDIT_READ, "/key", "", fuse.OK, 0)
+	}
+	if recorded := len(AuditEvents(t, buffer)); recorded < 350 || recorded > 650 {
+		t.Errorf("expected about half the reads to be recorded, got: %d", recorded)
+	}
+	/* step: a nil auditor records nothing */
+	var disabled *Auditor
+	disabled.Record(nil, AUDIT_WRITE, "/key", "", fuse.OK, 0)
+	disabled.Denied(nil, "/key", "read")
+}
+
+/* The entries are a json object per line */
+func TestAuditorEntries(t *testing.T) {
+	auditor, buffer := NewTestAuditor(1)
+	caller := Caller(1000, 1001)
+	caller.Pid = 1
+	auditor.Record(caller, AUDIT_WRITE, "app/key", "", fuse.OK, 42)
+	auditor.Record(caller, AUDIT_RENAME, "/app/key", "app/moved", fuse.EACCES, 0)
+	auditor.Denied(nil, "/app/key", "read")
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	expected := []map[string]interface{}{
+		{"uid": 1000.0, "gid": 1001.0, "pid": 1.0, "operation": "write", "path": "/app/key", "status": fuse.OK.String(), "revision": 42.0},
+		{"uid": 1000.0, "gid": 1001.0, "pid": 1.0, "operation": "rename", "path": "/app/key", "target": "/app/moved", "status": fuse.EACCES.String()},
+		{"uid": 0.0, "gid": 0.0, "pid": 0.0, "operation": "access", "path": "/app/key", "status": fuse.EACCES.String(), "denied": "read"},
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %d entries, got: %q", len(expected), lines)
+	}
+	for index, line := range lines {
+		entry := make(map[string]interface{}, 0)
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Errorf("entry %d: invalid json: %s, error: %s", index, line, err)
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, entry["time"].(string)); err != nil {
+			t.Errorf("entry %d: invalid time: %v", index, entry["time"])
+		}
+		/* step: the command is read from /proc, so we only check it's there for a live process */
+		if _, found := entry["command"]; found != (index < 2) {
+			t.Errorf("entry %d: expected a command: %t, got: %v", index, index < 2, entry["command"])
+		}
+		delete(entry, "time")
+		delete(entry, "command")
+		if !reflect.DeepEqual(entry, expected[index]) {
+			t.Errorf("entry %d: expected: %v, got: %v", index, expected[index], entry)
+		}
+	}
+}
+
+func TestNewAuditor(t *testing.T) {
+	directory, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatalf("failed to create a directory, error: %s", err)
+	}
+	defer os.RemoveAll(directory)
+	defer func() { *audit_log, *audit_read_sample = "", 1 }()
+	/* step: a file is appended to and kept private */
+	*audit_log = filepath.Join(directory, "audit.log")
+	ioutil.WriteFile(*audit_log, []byte("{}\n"), 0600)
+	auditor, err := NewAuditor()
+	if err != nil {
+		t.Fatalf("failed to create the auditor, error: %s", err)
+	}
+	auditor.Record(nil, AUDIT_WRITE, "/key", "", fuse.OK, 0)
+	auditor.Close()
+	content, _ := ioutil.ReadFile(*audit_log)
+	if lines := strings.Split(strings.TrimSpace(string(content)), "\n"); len(lines) != 2 || !strings.Contains(lines[1], `"path":"/key"`) {
+		t.Errorf("expected the entry to be appended, got: %q", content)
+	}
+	if stat, err := os.Stat(*audit_log); err != nil || stat.Mode().Perm() != 0600 {
+		t.Errorf("expected the audit log to be private, got: %v, error: %v", stat, err)
+	}
+	/* step: a remote syslog gets the entry as the message */
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen, error: %s", err)
+	}
+	defer listener.Close()
+	*audit_log = "syslog://" + listener.LocalAddr().String()
+	if auditor, err = NewAuditor(); err != nil {
+		t.Fatalf("failed to create the auditor, error: %s", err)
+	}
+	auditor.Record(nil, AUDIT_DELETE, "/key", "", fuse.OK, 0)
+	auditor.Close()
+	listener.SetReadDeadline(time.Now().Add(5 * time.Second))
+	message := make([]byte, 4096)
+	length, _, err := listener.ReadFrom(message)
+	if err != nil || !strings.Contains(string(message[:length]), `"operation":"delete"`) {
+		t.Errorf("expected the entry in the syslog message, got: %q, error: %v", message[:length], err)
+	}
+	/* step: no audit log is no auditor, and the sample must be a fraction */
+	*audit_log = ""
+	if auditor, err := NewAuditor(); auditor != nil || err != nil {
+		t.Errorf("expected no auditor, got: %v, error: %v", auditor, err)
+	}
+	*audit_log, *audit_read_sample = filepath.Join(directory, "audit.log"), 1.5
+	if _, err := NewAuditor(); err != InvalidAuditSampleErr {
+		t.Errorf("expected the sample to be refused, got: %v", err)
+	}
+}
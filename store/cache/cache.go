@@ -15,6 +15,7 @@ package cache
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang/glog"
@@ -33,6 +34,20 @@ type Cache interface {
 	Size() int
 	/* flush the cache all entries */
 	Flush() error
+	/* get the hits, misses and evictions of the cache */
+	Stats() CacheStats
+}
+
+/* The counts of the cache since it was created */
+type CacheStats struct {
+	/* the number of entries */
+	Size int `json:"size"`
+	/* the lookups which were found */
+	Hits uint64 `json:"hits"`
+	/* the lookups which weren't, or had expired */
+	Misses uint64 `json:"misses"`
+	/* the entries removed because they expired */
+	Evictions uint64 `json:"evictions"`
 }
 
 type CachedItem struct {
@@ -43,28 +58,41 @@ type CachedItem struct {
 }
 
 type CacheStore struct {
+	/* the counts, updated atomically as the lookups only hold the read lock; first for the alignment */
+	Hits, Misses, Evictions uint64
 	/* locking required for the cache */
 	sync.RWMutex
 	/* the cache map */
 	Items map[string]*CachedItem
 }
 
-func NewCacheStore() Cache {
+/* the interval the reaper removes the expired items at */
+const REAPER_INTERVAL = 5 * time.Second
+
+/* Create the cache and start the reaper, which runs until the shutdown channel is closed */
+func NewCacheStore(shutdown <-chan bool) Cache {
 	glog.Infof("Creating a new cache store")
-	return &CacheStore{Items: make(map[string]*CachedItem)}
+	store := &CacheStore{Items: make(map[string]*CachedItem)}
+	store.Reaper(shutdown)
+	return store
 }
 
 /*
 The reaper is responsible for looping around and removing any cache item thats
 old
  */
-func (c *CacheStore) Reaper() {
+func (c *CacheStore) Reaper(shutdown <-chan bool) {
 	go func() {
 		for {
+			/* step: go to sleep */
+			select {
+			case <-shutdown:
+				glog.V(5).Infof("Cache Reaper: shutting down")
+				return
+			case <-time.After(REAPER_INTERVAL):
+			}
 			/* step: reap any old items */
 			c.ReapItems()
-			/* step: go to sleep */
-			time.Sleep( 5 * time.Second )
 		}
 	}()
 }
@@ -78,9 +106,10 @@ func (c *CacheStore) ReapItems() {
 	}
 	now := time.Now().Unix()
 	for key, item := range c.Items {
-		if item.Expiring > 0 && now >= item.Expiring {
-			glog.V(6).Infof("Expiring the item: %s", item )
+		if item.Expired(now) {
+			glog.V(6).Infof("Expiring the item: %s", key )
 			delete( c.Items, key )
+			atomic.AddUint64(&c.Evictions, 1)
 		}
 	}
 }
@@ -91,13 +120,30 @@ func (c *CacheStore) Get(key string) (interface{},bool) {
 		return nil, false
 	}
 	c.RLock()
-	defer c.RUnlock()
 	glog.V(9).Infof("Get() key: %s", key)
-	if item, found := c.Items[key]; found {
-		glog.V(10).Infof("Get() key: %s found in cache", key )
-		return item.Data, true
+	item, found := c.Items[key]
+	c.RUnlock()
+	/* step: an expired item is removed here, rather than waiting on the reaper */
+	if found && item.Expired(time.Now().Unix()) {
+		c.Lock()
+		if current, still := c.Items[key]; still && current == item {
+			delete(c.Items, key)
+			atomic.AddUint64(&c.Evictions, 1)
+		}
+		c.Unlock()
+		found = false
+	}
+	if !found {
+		atomic.AddUint64(&c.Misses, 1)
+		return nil, false
 	}
-	return nil, false
+	glog.V(10).Infof("Get() key: %s found in cache", key )
+	atomic.AddUint64(&c.Hits, 1)
+	return item.Data, true
+}
+
+func (c *CachedItem) Expired(now int64) bool {
+	return c.Expiring > 0 && now >= c.Expiring
 }
 
 func (c *CacheStore) Set(key string, item interface{}, ttl time.Duration ) {
@@ -111,7 +157,7 @@ func (c *CacheStore) Set(key string, item interface{}, ttl time.Duration ) {
 	if ttl != 0 {
 		expiration_time = (time.Now().Unix()) + int64(ttl.Seconds())
 	}
-	glog.V(9).Infof("Set() key: %s, value: %V, expiration: %d", key, item, expiration_time )
+	glog.V(9).Infof("Set() key: %s, value: %v, expiration: %d", key, item, expiration_time )
 	c.Items[key] = &CachedItem{expiration_time,item}
 }
 
@@ -142,3 +188,11 @@ func (c *CacheStore) Size() int {
 	defer c.RUnlock()
 	return len(c.Items)
 }
+
+func (c *CacheStore) Stats() CacheStats {
+	return CacheStats{
+		Size:      c.Size(),
+		Hits:      atomic.LoadUint64(&c.Hits),
+		Misses:    atomic.LoadUint64(&c.Misses),
+		Evictions: atomic.LoadUint64(&c.Evictions)}
+}
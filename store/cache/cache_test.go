@@ -0,0 +1,62 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheExpiry(t *testing.T) {
+	shutdown := make(chan bool)
+	defer close(shutdown)
+	store := NewCacheStore(shutdown).(*CacheStore)
+	store.Set("/forever", "a", 0)
+	store.Set("/expired", "b", time.Second)
+	store.Items["/expired"].Expiring = time.Now().Unix() - 1
+	tests := []struct {
+		Key   string
+		Found bool
+	}{
+		{"/forever", true},
+		{"/expired", false},
+		{"/missing", false},
+	}
+	for _, test := range tests {
+		if _, found := store.Get(test.Key); found != test.Found {
+			t.Errorf("key: %s, expected found: %t", test.Key, test.Found)
+		}
+	}
+	stats := store.Stats()
+	if stats.Size != 1 || stats.Hits != 1 || stats.Misses != 2 || stats.Evictions != 1 {
+		t.Errorf("expected the expired item to be evicted on the get, stats: %+v", stats)
+	}
+}
+
+func TestCacheReapItems(t *testing.T) {
+	shutdown := make(chan bool)
+	defer close(shutdown)
+	store := NewCacheStore(shutdown).(*CacheStore)
+	store.Set("/a", "a", time.Minute)
+	store.Set("/b", "b", time.Minute)
+	store.Set("/c", "c", 0)
+	store.Items["/a"].Expiring = time.Now().Unix()
+	store.ReapItems()
+	if store.Exists("/a") || !store.Exists("/b") || !store.Exists("/c") {
+		t.Errorf("expected only the expired item to be reaped, items: %v", store.Items)
+	}
+	if evictions := store.Stats().Evictions; evictions != 1 {
+		t.Errorf("expected one eviction, got: %d", evictions)
+	}
+}
@@ -14,8 +14,12 @@ limitations under the License.
 package config
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/golang/glog"
 )
@@ -24,26 +28,62 @@ const STORE_VERBOSE_LEVEL = 6
 
 var InvalidUrlErr = errors.New("Invalid URI error, please check backend url")
 var InvalidDirectoryErr = errors.New("Invalid directory specified")
+var NodeNotFoundErr = errors.New("The key does not exist in the store")
+var CompareFailedErr = errors.New("The key has been changed since it was read")
+var TTLNotSupportedErr = errors.New("The backend does not support keys with a ttl")
 
 func Verbose(message string, args ...interface{}) {
-	glog.V(STORE_VERBOSE_LEVEL).Infof(message, args)
+	glog.V(STORE_VERBOSE_LEVEL).Infof(message, args...)
 }
 
+/*
+Every call takes a context, which carries the deadline for the operation and can be used to
+cancel it; a watch runs until it's cancelled or the context is done, at which point the
+update channel is closed
+*/
 type KVStore interface {
 	/* retrieve a key from the store */
-	Get(key string) (*Node, error)
+	Get(ctx context.Context, key string) (*Node, error)
 	/* Get a list of all the nodes under the path */
-	List(path string) ([]*Node, error)
+	List(ctx context.Context, path string) ([]*Node, error)
 	/* set a key in the store */
-	Set(key string, value string) error
+	Set(ctx context.Context, key string, value string) error
+	/* set a key which the store removes once the ttl has expired, a ttl of zero makes it permanent */
+	SetWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error
 	/* delete a key from the store */
-	Delete(key string) error
+	Delete(ctx context.Context, key string) error
 	/* recursively delete a path */
-	RemovePath(path string) error
+	RemovePath(ctx context.Context, path string) error
 	/* Create a directory node */
-	Mkdir(path string) error
-	/* watch for changes on the key */
-	Watch(key string, updateChannel chan NodeChange) (chan bool, error)
+	Mkdir(ctx context.Context, path string) error
+	/* set the key only if it's unchanged, i.e. still at the index or, when the index is zero, still holds the old value */
+	CompareAndSwap(ctx context.Context, key string, oldValue string, oldIndex uint64, value string) error
+	/* swap the key only if it's unchanged, same as above, giving it the ttl; a ttl of zero makes it permanent */
+	CompareAndSwapWithTTL(ctx context.Context, key string, oldValue string, oldIndex uint64, value string, ttl time.Duration) error
+	/* delete the key only if it's unchanged, same as above */
+	CompareAndDelete(ctx context.Context, key string, oldValue string, oldIndex uint64) error
+	/* apply the sets and deletes as one; either all of them are made or none are */
+	Txn(ctx context.Context, operations []*Operation) error
+	/* watch for changes on the key and everything under it */
+	Watch(ctx context.Context, key string) (<-chan NodeChange, context.CancelFunc, error)
+	/* release the connections and anything running in the background, once nothing is using the store */
+	Close() error
+}
+
+type versionKey struct{}
+
+/*
+Ask for the key as it was at the version; only the stores which keep the older versions of a
+key (vault) read it, the others return the key as it is now, so check the index of the node
+*/
+func WithVersion(ctx context.Context, version uint64) context.Context {
+	return context.WithValue(ctx, versionKey{}, version)
+}
+
+/* The version the context asks for, if any */
+func VersionFrom(ctx context.Context) (uint64, bool) {
+	version, found := ctx.Value(versionKey{}).(uint64)
+	return version, found
 }
 
 type Action int
@@ -54,6 +94,16 @@ const (
 	DELETED = 2
 )
 
+func (r Action) String() string {
+	switch r {
+	case CHANGED:
+		return "changed"
+	case DELETED:
+		return "deleted"
+	}
+	return "unknown"
+}
+
 type NodeChange struct {
 	/* The node in question */
 	Node Node
@@ -68,10 +118,28 @@ type Node struct {
 	Value string
 	/* the type of node it is, directory or file */
 	Directory bool
+	/* the modification index of the key in the backend, used for compare and swap */
+	Index uint64
+	/* the time left before the key expires, zero if it doesn't */
+	TTL time.Duration
+	/* the index the key was created at, where the backend keeps it */
+	CreateIndex uint64
+	/* the value could have been served by a follower and be behind the leader */
+	Stale bool
+	/* anything else the backend knows about the key, i.e. the consul flags and session */
+	Metadata map[string]string
 }
 
 func (n Node) String() string {
-	return fmt.Sprintf("path: %s, value: %s, type: %s", n.Path, n.Value, n.Directory )
+	return fmt.Sprintf("path: %s, value: %s, directory: %t", n.Path, Redact(n.Path, n.Value), n.Directory)
+}
+
+/* Check if the node is unchanged, by index if we have one, otherwise by value */
+func (n Node) Unchanged(oldValue string, oldIndex uint64) bool {
+	if oldIndex > 0 {
+		return n.Index == oldIndex
+	}
+	return n.Value == oldValue
 }
 
 func (n Node) IsDir() bool {
@@ -84,3 +152,32 @@ func (n Node) IsFile() bool {
 	}
 	return true
 }
+
+/*
+Match the path against the pattern; ** matches any number of directories, so /secrets/**
+covers /secrets itself and everything under it, otherwise each part is matched as a glob
+*/
+func MatchPattern(pattern, path string) bool {
+	return MatchParts(strings.Split(strings.Trim(pattern, "/"), "/"), strings.Split(strings.Trim(path, "/"), "/"))
+}
+
+func MatchParts(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0 || (len(path) == 1 && path[0] == "")
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if MatchParts(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 {
+		return false
+	}
+	if matched, _ := filepath.Match(pattern[0], path[0]); !matched {
+		return false
+	}
+	return MatchParts(pattern[1:], path[1:])
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import "testing"
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		Pattern string
+		Path    string
+		Matched bool
+	}{
+		{"/**", "/", true},
+		{"/**", "/prod/db/password", true},
+		{"/", "/", true},
+		{"/", "/prod", false},
+		{"/secrets/**", "/secrets", true},
+		{"/secrets/**", "/secrets/db/password", true},
+		{"/secrets/**", "/secretsx", false},
+		{"/secrets/**", "/prod/secrets", false},
+		{"/secrets/*", "/secrets/db", true},
+		{"/secrets/*", "/secrets", false},
+		{"/secrets/*", "/secrets/db/password", false},
+		{"/secrets", "/secrets/", true},
+		{"secrets", "/secrets", true},
+		{"/secrets", "/secrets/db", false},
+		{"/prod/*/password", "/prod/db/password", true},
+		{"/prod/*/password", "/prod/db/user", false},
+		{"/prod/**/password", "/prod/password", true},
+		{"/prod/**/password", "/prod/a/b/c/password", true},
+		{"/prod/**/password", "/prod/a/b/c/password/old", false},
+		{"/prod/db?/*", "/prod/db1/host", true},
+		{"/prod/db[0-9]/*", "/prod/dbx/host", false},
+		{"/prod/[", "/prod/[", false},
+	}
+	for _, test := range tests {
+		if matched := MatchPattern(test.Pattern, test.Path); matched != test.Matched {
+			t.Errorf("pattern: %s, path: %s, expected matched: %t", test.Pattern, test.Path, test.Matched)
+		}
+	}
+}
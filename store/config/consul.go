@@ -14,32 +14,165 @@ limitations under the License.
 package config
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	consulapi "github.com/armon/consul-api"
 	"github.com/golang/glog"
 )
 
-var consul_datacenter, consul_token *string
+var consul_datacenter, consul_token, consul_consistency, consul_namespace, consul_prefix *string
+
+const (
+	/* the maximum time a blocking watch is held by consul */
+	CONSUL_WATCH_WAIT_TIME = 5 * time.Minute
+	/* the backoff used when a watch fails */
+	CONSUL_WATCH_MIN_BACKOFF = 1 * time.Second
+	CONSUL_WATCH_MAX_BACKOFF = 60 * time.Second
+	/* the maximum number of operations in a transaction */
+	CONSUL_TXN_MAX_OPERATIONS = 64
+)
+
+var InvalidConsistencyErr = errors.New("Invalid consistency mode, must be default, consistent or stale")
 
 func init() {
-	consul_datacenter = flag.String("-consul-dc", "DC1", "the consul datacenter parameter")
-	consul_token = flag.String("-consul-token", "", "the securirty token used for write operations")
+	consul_datacenter = flag.String("consul-dc", "", "the consul datacenter, defaults to the datacenter of the agent")
+	consul_token = flag.String("consul-token", "", "the acl token used for read and write operations")
+	consul_consistency = flag.String("consul-consistency", "default", "the consistency mode for reads, default, consistent or stale")
+	consul_namespace = flag.String("consul-namespace", "", "the consul namespace the keys live in")
+	consul_prefix = flag.String("consul-prefix", "", "a prefix applied to every key in consul")
+}
+
+/*
+The options for the consul backend; they're taken from the flags and can be overridden
+in the url, i.e. consul://127.0.0.1:8500?dc=dc2&token=xyz&consistency=stale&prefix=config
+*/
+type ConsulOptions struct {
+	/* the datacenter to read and write */
+	Datacenter string
+	/* the acl token */
+	Token string
+	/* the consistency mode for reads */
+	Consistency string
+	/* the namespace the keys are in */
+	Namespace string
+	/* the prefix applied to the keys */
+	Prefix string
 }
 
 type ConsulClient struct {
 	/* the consul client */
 	Client *consulapi.Client
+	/* the config the client was created with */
+	Config *consulapi.Config
+	/* the options for the client */
+	Options *ConsulOptions
 	/* the write options for client */
 	WriteOptions *consulapi.WriteOptions
 }
 
+/* the namespace isn't supported by the api client, so we add it to every request */
+type consulNamespaceTransport struct {
+	/* the namespace to add */
+	Namespace string
+	/* the underlining transport */
+	Transport http.RoundTripper
+}
+
+func (r *consulNamespaceTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	query := request.URL.Query()
+	query.Set("ns", r.Namespace)
+	request.URL.RawQuery = query.Encode()
+	return r.Transport.RoundTrip(request)
+}
+
+func (r *consulNamespaceTransport) CloseIdleConnections() {
+	if transport, ok := r.Transport.(interface{ CloseIdleConnections() }); ok {
+		transport.CloseIdleConnections()
+	}
+}
+
+/* the api client takes no context, so the requests are bound to one by the transport */
+type consulContextTransport struct {
+	/* the context the requests are cancelled with */
+	Context context.Context
+	/* the underlining transport */
+	Transport http.RoundTripper
+}
+
+func (r *consulContextTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	return r.Transport.RoundTrip(request.WithContext(r.Context))
+}
+
+func GetConsulOptions(uri *url.URL) (*ConsulOptions, error) {
+	params := uri.Query()
+	options := &ConsulOptions{
+		Datacenter:  *consul_datacenter,
+		Token:       *consul_token,
+		Consistency: *consul_consistency,
+		Namespace:   *consul_namespace,
+		Prefix:      *consul_prefix}
+	if value := params.Get("dc"); value != "" {
+		options.Datacenter = value
+	}
+	if value := params.Get("token"); value != "" {
+		options.Token = value
+	}
+	if value := params.Get("consistency"); value != "" {
+		options.Consistency = value
+	}
+	if value := params.Get("ns"); value != "" {
+		options.Namespace = value
+	}
+	if value := params.Get("prefix"); value != "" {
+		options.Prefix = value
+	}
+	switch options.Consistency {
+	case "", "default", "consistent", "stale":
+	default:
+		glog.Errorf("Invalid consistency mode: %s for consul", options.Consistency)
+		return nil, InvalidConsistencyErr
+	}
+	options.Prefix = strings.Trim(options.Prefix, "/")
+	return options, nil
+}
+
 func NewConsulStoreClient(uri *url.URL) (KVStore, error) {
-	glog.Infof("Creating a new Consul K/V Client, url: %s", uri)
+	glog.Infof("Creating a new Consul K/V Client, host: %s", uri.Host)
+	options, err := GetTLSOptions(uri)
+	if err != nil {
+		return nil, err
+	}
+	consulOptions, err := GetConsulOptions(uri)
+	if err != nil {
+		return nil, err
+	}
+	transport, err := options.Transport()
+	if err != nil {
+		glog.Errorf("Failed to create the transport for consul, error: %s", err)
+		return nil, err
+	}
 	config := consulapi.DefaultConfig()
 	config.Address = uri.Host
+	config.Scheme = options.Scheme()
+	config.HttpClient = &http.Client{Transport: transport}
+	if consulOptions.Namespace != "" {
+		config.HttpClient.Transport = &consulNamespaceTransport{consulOptions.Namespace, transport}
+	}
+	/* step: the datacenter and token on the config are added to every request, reads as well as writes */
+	config.Datacenter = consulOptions.Datacenter
+	config.Token = consulOptions.Token
 	client, err := consulapi.NewClient(config)
 	if err != nil {
 		glog.Errorf("Failed to create the Consul Clinet, error: %s", err)
@@ -47,27 +180,136 @@ func NewConsulStoreClient(uri *url.URL) (KVStore, error) {
 	}
 	kv := new(ConsulClient)
 	kv.Client = client
+	kv.Config = config
+	kv.Options = consulOptions
 	kv.WriteOptions = &consulapi.WriteOptions{
-		Datacenter: *consul_datacenter,
-		Token:      *consul_token}
+		Datacenter: consulOptions.Datacenter,
+		Token:      consulOptions.Token}
 	return kv, nil
 }
 
-func (r *ConsulClient) Get(key string) (*Node,error) {
-	if response, _, err := r.Client.KV().Get(key, &consulapi.QueryOptions{}); err != nil {
+/* The query options used for every read and watch */
+func (r *ConsulClient) QueryOptions(waitIndex uint64) *consulapi.QueryOptions {
+	return &consulapi.QueryOptions{
+		Datacenter:        r.Options.Datacenter,
+		Token:             r.Options.Token,
+		AllowStale:        r.Options.Consistency == "stale",
+		RequireConsistent: r.Options.Consistency == "consistent",
+		WaitIndex:         waitIndex}
+}
+
+/* Convert the path into a consul key, consul keys don't start with a / */
+func (r *ConsulClient) KeyPath(key string) string {
+	key = strings.Trim(key, "/")
+	if r.Options.Prefix == "" {
+		return key
+	}
+	if key == "" {
+		return r.Options.Prefix
+	}
+	return r.Options.Prefix + "/" + key
+}
+
+/* Convert the consul key back into a path, i.e. strip the prefix */
+func (r *ConsulClient) NodePath(key string) string {
+	key = strings.TrimPrefix(key, r.Options.Prefix)
+	return "/" + strings.Trim(key, "/")
+}
+
+/*
+The consul api client has no notion of a context, so the method is handed a copy of the client
+whose requests are bound to the context, and is expected to use it in place of the receiver.
+The call returns as soon as the context is done, with the request in flight cancelled
+*/
+func (r *ConsulClient) Call(ctx context.Context, method func(r *ConsulClient) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	client, err := r.WithContext(ctx)
+	if err != nil {
+		return err
+	}
+	result := make(chan error, 1)
+	go func() {
+		result <- method(client)
+	}()
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+/* A copy of the client whose requests are cancelled when the context is done */
+func (r *ConsulClient) WithContext(ctx context.Context) (*ConsulClient, error) {
+	config := *r.Config
+	httpClient := *r.Config.HttpClient
+	httpClient.Transport = &consulContextTransport{ctx, r.Config.HttpClient.Transport}
+	config.HttpClient = &httpClient
+	client, err := consulapi.NewClient(&config)
+	if err != nil {
+		return nil, err
+	}
+	copied := *r
+	copied.Client = client
+	copied.Config = &config
+	return &copied, nil
+}
+
+/*
+Consul has no real directories, so a key is a directory if it ends with a / (which is how
+we create them) or if there are keys under it
+*/
+func (r *ConsulClient) Get(ctx context.Context, key string) (*Node,error) {
+	path := r.KeyPath(key)
+	if path == "" {
+		return &Node{Path: "/", Directory: true}, nil
+	}
+	var response *consulapi.KVPair
+	var meta *consulapi.QueryMeta
+	var session *consulapi.SessionEntry
+	var keys []string
+	err := r.Call(ctx, func(r *ConsulClient) (err error) {
+		if response, meta, err = r.Client.KV().Get(path, r.QueryOptions(0)); err != nil {
+			return err
+		}
+		if response != nil {
+			/* step: a key held by a session expires with it */
+			if response.Session != "" {
+				session, _, err = r.Client.Session().Info(response.Session, r.QueryOptions(0))
+			}
+			return err
+		}
+		/* step: the key doesn't exist, check if anything lives under it */
+		keys, _, err = r.Client.KV().Keys(path+"/", "/", r.QueryOptions(0))
+		return err
+	})
+	if err != nil {
 		glog.Errorf("Get() failed to get key: %s, error: %s", key, err)
 		return nil, err
-	} else {
-		return &Node{
-			Path: key,
-			Value: string(response.Value[:]),
-			Directory: true}, nil
 	}
+	if response != nil {
+		node := r.CreateNode(response)
+		if session != nil && session.TTL != "" {
+			node.TTL, _ = time.ParseDuration(session.TTL)
+		}
+		/* step: a stale read can be answered by any server, which may have lost contact with the leader */
+		node.Stale = r.Options.Consistency == "stale" && (!meta.KnownLeader || meta.LastContact > 0)
+		return node, nil
+	}
+	if len(keys) > 0 {
+		return &Node{Path: r.NodePath(path), Directory: true}, nil
+	}
+	return nil, NodeNotFoundErr
 }
 
-func (r *ConsulClient) Set(key string, value string) error {
-	Verbose("Set() key: %s, value: %s", key, value)
-	_, err := r.Client.KV().Put(&consulapi.KVPair{Key: key, Value: []byte(value)}, nil)
+func (r *ConsulClient) Set(ctx context.Context, key string, value string) error {
+	Verbose("Set() key: %s, value: %s", key, Redact(key, value))
+	err := r.Call(ctx, func(r *ConsulClient) error {
+		_, err := r.Client.KV().Put(&consulapi.KVPair{Key: r.KeyPath(key), Value: []byte(value)}, r.WriteOptions)
+		return err
+	})
 	if err != nil {
 		glog.Errorf("Set() failed to set key: %s, error: %s", key, err)
 		return err
@@ -75,9 +317,67 @@ func (r *ConsulClient) Set(key string, value string) error {
 	return nil
 }
 
-func (r *ConsulClient) Delete(key string) error {
+/*
+Consul has no ttl on keys, so the key is held by a session with the delete behaviour and is
+removed when the session expires. Note consul only tells us the ttl the session was created
+with rather than the time left, can take up to twice the ttl to remove the key, and won't
+accept a ttl under 10s
+*/
+func (r *ConsulClient) SetWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error {
+	Verbose("SetWithTTL() key: %s, value: %s, ttl: %s", key, Redact(key, value), ttl)
+	path := r.KeyPath(key)
+	err := r.Call(ctx, func(r *ConsulClient) error {
+		/* step: release any session already holding the key, otherwise it takes the key with it */
+		if err := r.ReleaseSession(path); err != nil {
+			return err
+		}
+		if ttl <= 0 {
+			_, err := r.Client.KV().Put(&consulapi.KVPair{Key: path, Value: []byte(value)}, r.WriteOptions)
+			return err
+		}
+		session, _, err := r.Client.Session().CreateNoChecks(&consulapi.SessionEntry{
+			Name:      "config-store " + path,
+			Behavior:  "delete",
+			TTL:       ttl.String(),
+			LockDelay: time.Millisecond}, r.WriteOptions)
+		if err != nil {
+			return err
+		}
+		acquired, _, err := r.Client.KV().Acquire(&consulapi.KVPair{Key: path, Value: []byte(value), Session: session}, r.WriteOptions)
+		if err == nil && !acquired {
+			err = fmt.Errorf("unable to acquire the key: %s with the session", path)
+		}
+		if err != nil {
+			r.Client.Session().Destroy(session, r.WriteOptions)
+		}
+		return err
+	})
+	if err != nil {
+		glog.Errorf("SetWithTTL() failed to set key: %s, error: %s", key, err)
+		return err
+	}
+	return nil
+}
+
+/* Release the key from the session holding it, if there is one, and destroy the session */
+func (r *ConsulClient) ReleaseSession(path string) error {
+	pair, _, err := r.Client.KV().Get(path, r.QueryOptions(0))
+	if err != nil || pair == nil || pair.Session == "" {
+		return err
+	}
+	if _, _, err := r.Client.KV().Release(&consulapi.KVPair{Key: path, Value: pair.Value, Session: pair.Session}, r.WriteOptions); err != nil {
+		return err
+	}
+	_, err = r.Client.Session().Destroy(pair.Session, r.WriteOptions)
+	return err
+}
+
+func (r *ConsulClient) Delete(ctx context.Context, key string) error {
 	Verbose("Delete() deleting the key: %s", key)
-	_, err := r.Client.KV().Delete(key, r.WriteOptions)
+	err := r.Call(ctx, func(r *ConsulClient) error {
+		_, err := r.Client.KV().Delete(r.KeyPath(key), r.WriteOptions)
+		return err
+	})
 	if err != nil {
 		glog.Errorf("Delete() failed to delete key: %s, error: %s", key, err)
 		return err
@@ -85,79 +385,405 @@ func (r *ConsulClient) Delete(key string) error {
 	return nil
 }
 
-func (r *ConsulClient) RemovePath(path string) error {
+func (r *ConsulClient) CompareAndSwap(ctx context.Context, key string, oldValue string, oldIndex uint64, value string) error {
+	return r.CompareAndSwapWithTTL(ctx, key, oldValue, oldIndex, value, 0)
+}
+
+/*
+Consul only does a check-and-set on the modify index, so when we're comparing by value we read
+the key and use its index, which still guarantees nothing has changed in between. The swap is a
+transaction guarded by the index which releases any session holding the key and, with a ttl,
+locks it with a new session, as SetWithTTL does
+*/
+func (r *ConsulClient) CompareAndSwapWithTTL(ctx context.Context, key string, oldValue string, oldIndex uint64, value string, ttl time.Duration) error {
+	Verbose("CompareAndSwap() key: %s, index: %d, value: %s, ttl: %s", key, oldIndex, Redact(key, value), ttl)
+	path := r.KeyPath(key)
+	var swapped bool
+	var held string
+	err := r.Call(ctx, func(r *ConsulClient) error {
+		pair, _, err := r.Client.KV().Get(path, r.QueryOptions(0))
+		if err != nil {
+			return err
+		}
+		if pair == nil {
+			return NodeNotFoundErr
+		}
+		if !r.CreateNode(pair).Unchanged(oldValue, oldIndex) {
+			return CompareFailedErr
+		}
+		held = pair.Session
+		txn := []interface{}{map[string]interface{}{"KV": map[string]interface{}{
+			"Verb": "check-index", "Key": path, "Index": pair.ModifyIndex}}}
+		if held != "" {
+			txn = append(txn, map[string]interface{}{"KV": map[string]interface{}{
+				"Verb": "unlock", "Key": path, "Value": []byte(value), "Session": held}})
+		}
+		if ttl <= 0 {
+			txn = append(txn, map[string]interface{}{"KV": map[string]interface{}{
+				"Verb": "set", "Key": path, "Value": []byte(value)}})
+			swapped, err = r.ApplyTxn(txn)
+			return err
+		}
+		session, _, err := r.Client.Session().CreateNoChecks(&consulapi.SessionEntry{
+			Name:      "config-store " + path,
+			Behavior:  "delete",
+			TTL:       ttl.String(),
+			LockDelay: time.Millisecond}, r.WriteOptions)
+		if err != nil {
+			return err
+		}
+		txn = append(txn, map[string]interface{}{"KV": map[string]interface{}{
+			"Verb": "lock", "Key": path, "Value": []byte(value), "Session": session}})
+		if swapped, err = r.ApplyTxn(txn); err != nil || !swapped {
+			r.Client.Session().Destroy(session, r.WriteOptions)
+		}
+		return err
+	})
+	if err == nil && !swapped {
+		err = r.CompareFailure(ctx, key)
+	}
+	if err != nil {
+		glog.Errorf("CompareAndSwap() failed to set the key: %s, error: %s", key, err)
+		return err
+	}
+	/* step: the key has been released from the old session, which has nothing left to hold */
+	if held != "" {
+		if _, err := r.Client.Session().Destroy(held, r.WriteOptions); err != nil {
+			glog.Warningf("CompareAndSwap() failed to destroy the session: %s, error: %s", held, err)
+		}
+	}
+	return nil
+}
+
+func (r *ConsulClient) CompareAndDelete(ctx context.Context, key string, oldValue string, oldIndex uint64) error {
+	Verbose("CompareAndDelete() key: %s, index: %d", key, oldIndex)
+	var deleted bool
+	err := r.Call(ctx, func(r *ConsulClient) (err error) {
+		index, err := r.CompareIndex(key, oldValue, oldIndex)
+		if err != nil {
+			return err
+		}
+		deleted, err = r.DeleteCAS(r.KeyPath(key), index)
+		return err
+	})
+	if err == nil && !deleted {
+		err = r.CompareFailure(ctx, key)
+	}
+	if err != nil {
+		glog.Errorf("CompareAndDelete() failed to delete key: %s, error: %s", key, err)
+		return err
+	}
+	return nil
+}
+
+/* The modify index we expect the key to be at */
+func (r *ConsulClient) CompareIndex(key string, oldValue string, oldIndex uint64) (uint64, error) {
+	if oldIndex > 0 {
+		return oldIndex, nil
+	}
+	pair, _, err := r.Client.KV().Get(r.KeyPath(key), r.QueryOptions(0))
+	if err != nil {
+		return 0, err
+	}
+	if pair == nil {
+		return 0, NodeNotFoundErr
+	}
+	if string(pair.Value) != oldValue {
+		return 0, CompareFailedErr
+	}
+	return pair.ModifyIndex, nil
+}
+
+/* The check-and-set failed, work out if the key has changed or gone altogether */
+func (r *ConsulClient) CompareFailure(ctx context.Context, key string) error {
+	if _, err := r.Get(ctx, key); err == NodeNotFoundErr {
+		return err
+	}
+	return CompareFailedErr
+}
+
+/* The api client has no check-and-set for deletes, so we make the request ourselves */
+func (r *ConsulClient) DeleteCAS(key string, index uint64) (bool, error) {
+	content, status, err := r.RawRequest("DELETE", "/v1/kv/"+key,
+		url.Values{"cas": {strconv.FormatUint(index, 10)}}, nil)
+	if err != nil {
+		return false, err
+	}
+	if status != http.StatusOK {
+		return false, fmt.Errorf("consul returned status: %d, message: %s", status, strings.TrimSpace(string(content)))
+	}
+	return strings.TrimSpace(string(content)) == "true", nil
+}
+
+/* Drop the connections to consul; the watches are stopped by their contexts */
+func (r *ConsulClient) Close() error {
+	r.Config.HttpClient.CloseIdleConnections()
+	return nil
+}
+
+/*
+Apply the operations with the transaction endpoint; consul rolls the whole lot back if any of
+them fail, and limits a transaction to 64 operations
+*/
+func (r *ConsulClient) Txn(ctx context.Context, operations []*Operation) error {
+	Verbose("Txn() operations: %d", len(operations))
+	if len(operations) > CONSUL_TXN_MAX_OPERATIONS {
+		glog.Errorf("Txn() the transaction has %d operations, consul allows %d", len(operations), CONSUL_TXN_MAX_OPERATIONS)
+		return TxnTooLargeErr
+	}
+	txn := make([]interface{}, 0)
+	for _, operation := range operations {
+		if operation.Delete {
+			txn = append(txn, map[string]interface{}{"KV": map[string]interface{}{
+				"Verb": "delete", "Key": r.KeyPath(operation.Key)}})
+		} else {
+			txn = append(txn, map[string]interface{}{"KV": map[string]interface{}{
+				"Verb": "set", "Key": r.KeyPath(operation.Key), "Value": []byte(operation.Value)}})
+		}
+	}
+	err := r.Call(ctx, func(r *ConsulClient) error {
+		applied, err := r.ApplyTxn(txn)
+		if err == nil && !applied {
+			err = errors.New("consul rolled back the transaction")
+		}
+		return err
+	})
+	if err != nil {
+		glog.Errorf("Txn() failed to apply the transaction, error: %s", err)
+		return err
+	}
+	return nil
+}
+
+/* Apply the transaction, it isn't applied if one of its checks has failed */
+func (r *ConsulClient) ApplyTxn(txn []interface{}) (bool, error) {
+	body, err := json.Marshal(txn)
+	if err != nil {
+		return false, err
+	}
+	content, status, err := r.RawRequest("PUT", "/v1/txn", url.Values{}, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	switch status {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusConflict:
+		Verbose("ApplyTxn() the transaction was rolled back: %s", strings.TrimSpace(string(content)))
+		return false, nil
+	}
+	return false, fmt.Errorf("consul returned status: %d, message: %s", status, strings.TrimSpace(string(content)))
+}
+
+/* Make a request the api client doesn't support, with the datacenter and token applied */
+func (r *ConsulClient) RawRequest(method, path string, params url.Values, body io.Reader) ([]byte, int, error) {
+	if r.Config.Datacenter != "" {
+		params.Set("dc", r.Config.Datacenter)
+	}
+	uri := &url.URL{
+		Scheme:   r.Config.Scheme,
+		Host:     r.Config.Address,
+		Path:     path,
+		RawQuery: params.Encode()}
+	request, err := http.NewRequest(method, uri.String(), body)
+	if err != nil {
+		return nil, 0, err
+	}
+	/* step: the token goes in the header, so it isn't in the url where it ends up in the logs */
+	if r.Config.Token != "" {
+		request.Header.Set("X-Consul-Token", r.Config.Token)
+	}
+	response, err := r.Config.HttpClient.Do(request)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer response.Body.Close()
+	content, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	return content, response.StatusCode, nil
+}
+
+func (r *ConsulClient) RemovePath(ctx context.Context, path string) error {
 	Verbose("RemovePath() deleting the path: %s", path)
-	if _, err := r.Client.KV().DeleteTree(path, r.WriteOptions); err != nil {
+	/* step: we delete by the prefix with a trailing /, otherwise /app would remove /application as well */
+	prefix := r.KeyPath(path)
+	if prefix != "" {
+		prefix = prefix + "/"
+	}
+	err := r.Call(ctx, func(r *ConsulClient) error {
+		_, err := r.Client.KV().DeleteTree(prefix, r.WriteOptions)
+		return err
+	})
+	if err != nil {
 		glog.Errorf("RemovePath() failed to remove path: %s, error: %s", path, err)
 		return err
 	}
 	return nil
 }
 
-func (r *ConsulClient) Mkdir(path string) error {
+/* A directory is a key with a trailing /, this lets us have empty directories */
+func (r *ConsulClient) Mkdir(ctx context.Context, path string) error {
 	Verbose("Mkdir() path: %s", path)
+	err := r.Call(ctx, func(r *ConsulClient) error {
+		_, err := r.Client.KV().Put(&consulapi.KVPair{Key: r.KeyPath(path) + "/"}, r.WriteOptions)
+		return err
+	})
+	if err != nil {
+		glog.Errorf("Mkdir() failed to create directory node: %s, error: %s", path, err)
+		return err
+	}
 	return nil
 }
 
-func (r *ConsulClient) List(path string) ([]*Node, error) {
+/*
+The listing uses a separator so consul only hands back the immediate children; keys ending
+with a / are directories. Note the values of the keys are not retrieved
+*/
+func (r *ConsulClient) List(ctx context.Context, path string) ([]*Node, error) {
 	Verbose("List() path: %s", path)
-	if response, _, err := r.Client.KV().List(path, &consulapi.QueryOptions{}); err != nil {
+	prefix := r.KeyPath(path)
+	if prefix != "" {
+		prefix = prefix + "/"
+	}
+	var keys []string
+	err := r.Call(ctx, func(r *ConsulClient) (err error) {
+		keys, _, err = r.Client.KV().Keys(prefix, "/", r.QueryOptions(0))
+		return err
+	})
+	if err != nil {
 		glog.Errorf("List() failed to list path: %s, error: %s", path, err)
 		return nil, err
-	} else {
-		list := make([]*Node, 0)
-		for _, pair := range response {
-			node := &Node{
-				Path: pair.Key,
-				Directory: true,
-				Value: string(pair.Value[:])}
-			list = append(list, node)
+	}
+	if len(keys) <= 0 && prefix != "" {
+		if node, err := r.Get(ctx, path); err != nil {
+			return nil, err
+		} else if node.IsFile() {
+			glog.Errorf("List() path: %s is not a directory node", path)
+			return nil, InvalidDirectoryErr
 		}
-		return list, nil
 	}
+	list := make([]*Node, 0)
+	for _, key := range keys {
+		/* step: skip the directory marker for the path itself */
+		if key == prefix {
+			continue
+		}
+		list = append(list, &Node{
+			Path:      r.NodePath(key),
+			Directory: strings.HasSuffix(key, "/")})
+	}
+	return list, nil
 }
 
-func (r *ConsulClient) Watch(key string, updateChannel chan NodeChange) (chan bool,error) {
-	Verbose("Watch() key: %s, channel: %V", key, updateChannel)
-	stopChannel := make(chan bool,0)
-	killOffWatch := false
-	go func() {
-		/* step: wait for the shutdown signal */
-		<-stopChannel
-		glog.V(3).Infof("Watch() killing off the watch on key: %s", key)
-		killOffWatch = true
-	}()
-	waitIndex := uint64(0)
+/*
+Watch the key and everything under it; we perform a blocking recursive list on the prefix
+and diff the result against the last snapshot, which gives us the changes and deletions
+*/
+func (r *ConsulClient) Watch(ctx context.Context, key string) (<-chan NodeChange, context.CancelFunc, error) {
+	Verbose("Watch() key: %s", key)
+	path := r.KeyPath(key)
+	ctx, cancel := context.WithCancel(ctx)
+	updateChannel := make(chan NodeChange)
 	go func() {
+		defer close(updateChannel)
+		var snapshot map[string]*consulapi.KVPair
+		waitIndex := uint64(0)
+		backoff := CONSUL_WATCH_MIN_BACKOFF
 		for {
-			if killOffWatch {
+			var response consulapi.KVPairs
+			var meta *consulapi.QueryMeta
+			err := r.Call(ctx, func(r *ConsulClient) (err error) {
+				options := r.QueryOptions(waitIndex)
+				options.WaitTime = CONSUL_WATCH_WAIT_TIME
+				response, meta, err = r.Client.KV().List(path, options)
+				return err
+			})
+			if ctx.Err() != nil {
 				glog.V(3).Infof("Watch() exitting the watch on key: %s", key)
-				break
+				return
 			}
-			response, meta, err := r.Client.KV().Get(key, &consulapi.QueryOptions{WaitIndex: waitIndex})
 			if err != nil {
-				glog.Errorf("Watch() error attempting to watch the key: %s, error: %s", key, err)
-				time.Sleep(3 * time.Second)
-				continue
-			}
-			if killOffWatch {
+				glog.Errorf("Watch() error attempting to watch the key: %s, error: %s, retrying in %s", key, err, backoff)
+				WatchReconnected("consul")
+				select {
+				case <-ctx.Done():
+				case <-time.After(backoff):
+				}
+				if backoff *= 2; backoff > CONSUL_WATCH_MAX_BACKOFF {
+					backoff = CONSUL_WATCH_MAX_BACKOFF
+				}
 				continue
 			}
+			backoff = CONSUL_WATCH_MIN_BACKOFF
 			if waitIndex == meta.LastIndex {
 				Verbose("Watch() key: %s, skipping the change, indexes are the same", key)
 				continue
 			}
-			/* step: pass the change upstream */
-			Verbose("Watch() sending the change for key: %s upstream", key)
-			updateChannel <- r.GetNodeEvent(response)
+			/* step: if the index goes backwards, i.e. the servers were restored, we start again */
+			if meta.LastIndex < waitIndex {
+				waitIndex = 0
+			} else {
+				waitIndex = meta.LastIndex
+			}
+			current := make(map[string]*consulapi.KVPair, 0)
+			for _, pair := range response {
+				if path == "" || pair.Key == path || strings.HasPrefix(pair.Key, path+"/") {
+					current[pair.Key] = pair
+				}
+			}
+			/* step: the first listing is only the baseline */
+			if snapshot == nil {
+				snapshot = current
+				continue
+			}
+			for _, event := range r.GetNodeEvents(snapshot, current) {
+				/* step: pass the change upstream */
+				Verbose("Watch() sending the change for key: %s upstream", event.Node.Path)
+				select {
+				case updateChannel <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+			snapshot = current
 		}
 	}()
-	return stopChannel, nil
+	return updateChannel, cancel, nil
 }
 
-func (r *ConsulClient) GetNodeEvent(response *consulapi.KVPair) (event NodeChange) {
-	event.Node.Path = response.Key
-	event.Node.Value = string(response.Value[:])
-	event.Operation = CHANGED
-	return
+/* Diff the two snapshots of the prefix and produce the changes */
+func (r *ConsulClient) GetNodeEvents(previous, current map[string]*consulapi.KVPair) []NodeChange {
+	events := make([]NodeChange, 0)
+	for key, pair := range current {
+		if last, found := previous[key]; !found || last.ModifyIndex != pair.ModifyIndex {
+			events = append(events, NodeChange{*r.CreateNode(pair), CHANGED})
+		}
+	}
+	for key, pair := range previous {
+		if _, found := current[key]; !found {
+			events = append(events, NodeChange{*r.CreateNode(pair), DELETED})
+		}
+	}
+	return events
+}
+
+func (r *ConsulClient) CreateNode(pair *consulapi.KVPair) *Node {
+	node := &Node{
+		Path:        r.NodePath(pair.Key),
+		Index:       pair.ModifyIndex,
+		CreateIndex: pair.CreateIndex,
+		Metadata: map[string]string{
+			"consul.flags":      strconv.FormatUint(pair.Flags, 10),
+			"consul.lock_index": strconv.FormatUint(pair.LockIndex, 10)}}
+	if pair.Session != "" {
+		node.Metadata["consul.session"] = pair.Session
+	}
+	if strings.HasSuffix(pair.Key, "/") {
+		node.Directory = true
+	} else {
+		node.Value = string(pair.Value)
+	}
+	return node
 }
@@ -0,0 +1,134 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"testing"
+	"time"
+
+	consulapi "github.com/armon/consul-api"
+)
+
+func NewTestConsul(t *testing.T, handler http.Handler) *ConsulClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	location, _ := url.Parse(server.URL)
+	uri, _ := url.Parse("consul://" + location.Host)
+	store, err := NewConsulStoreClient(uri)
+	if err != nil {
+		t.Fatalf("failed to create the consul store, error: %s", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store.(*ConsulClient)
+}
+
+func TestConsulCallCancelsRequest(t *testing.T) {
+	cancelled := make(chan bool, 1)
+	store := NewTestConsul(t, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		select {
+		case <-request.Context().Done():
+			cancelled <- true
+		case <-time.After(5 * time.Second):
+			cancelled <- false
+		}
+	}))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := store.Get(ctx, "/key"); err != context.DeadlineExceeded {
+		t.Errorf("expected the deadline to be exceeded, got: %v", err)
+	}
+	if !<-cancelled {
+		t.Errorf("expected the request to consul to be cancelled")
+	}
+}
+
+func TestConsulGetNodeEvents(t *testing.T) {
+	pair := func(key string, index uint64) *consulapi.KVPair {
+		return &consulapi.KVPair{Key: key, Value: []byte("value"), ModifyIndex: index}
+	}
+	snapshot := func(pairs ...*consulapi.KVPair) map[string]*consulapi.KVPair {
+		keys := make(map[string]*consulapi.KVPair, 0)
+		for _, pair := range pairs {
+			keys[pair.Key] = pair
+		}
+		return keys
+	}
+	tests := []struct {
+		Name     string
+		Prefix   string
+		Previous map[string]*consulapi.KVPair
+		Current  map[string]*consulapi.KVPair
+		Changes  []string
+	}{
+		{
+			Name:     "unchanged",
+			Previous: snapshot(pair("app/a", 1), pair("app/b", 2)),
+			Current:  snapshot(pair("app/a", 1), pair("app/b", 2)),
+			Changes:  []string{},
+		},
+		{
+			Name:     "added",
+			Previous: snapshot(pair("app/a", 1)),
+			Current:  snapshot(pair("app/a", 1), pair("app/b", 2)),
+			Changes:  []string{"/app/b changed"},
+		},
+		{
+			Name:     "modified",
+			Previous: snapshot(pair("app/a", 1), pair("app/b", 2)),
+			Current:  snapshot(pair("app/a", 3), pair("app/b", 2)),
+			Changes:  []string{"/app/a changed"},
+		},
+		{
+			Name:     "removed",
+			Previous: snapshot(pair("app/a", 1), pair("app/b", 2)),
+			Current:  snapshot(pair("app/b", 2)),
+			Changes:  []string{"/app/a deleted"},
+		},
+		{
+			Name:     "recreated at a new index",
+			Previous: snapshot(pair("app/a", 1)),
+			Current:  snapshot(pair("app/a", 5), pair("app/dir/", 6)),
+			Changes:  []string{"/app/a changed", "/app/dir changed"},
+		},
+		{
+			Name:     "everything removed",
+			Previous: snapshot(pair("app/a", 1), pair("app/b", 2)),
+			Current:  snapshot(),
+			Changes:  []string{"/app/a deleted", "/app/b deleted"},
+		},
+		{
+			Name:     "under a prefix",
+			Prefix:   "config",
+			Previous: snapshot(pair("config/app/a", 1)),
+			Current:  snapshot(pair("config/app/a", 2), pair("config/app/b", 3)),
+			Changes:  []string{"/app/a changed", "/app/b changed"},
+		},
+	}
+	for _, test := range tests {
+		store := &ConsulClient{Options: &ConsulOptions{Prefix: test.Prefix}}
+		received := make([]string, 0)
+		for _, change := range store.GetNodeEvents(test.Previous, test.Current) {
+			received = append(received, change.Node.Path+" "+change.Operation.String())
+		}
+		sort.Strings(received)
+		if !EqualStrings(received, test.Changes) {
+			t.Errorf("%s: expected the changes: %v, got: %v", test.Name, test.Changes, received)
+		}
+	}
+}
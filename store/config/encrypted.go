@@ -0,0 +1,502 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bufio"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+const (
+	/* the marker at the start of an encrypted value, followed by <key id>:<base64 nonce + ciphertext> */
+	ENCRYPTED_PREFIX = "enc:v1:"
+	/* the size of the keys, AES-256 */
+	ENCRYPTION_KEY_SIZE = 32
+)
+
+var encrypt_prefixes, encrypt_keys *string
+var encrypt_allow_plaintext *bool
+
+var InvalidEncryptionKeyErr = errors.New("Invalid encryption key, must be a base64 encoded 32 byte key")
+var EncryptionKeyNotFoundErr = errors.New("The key the value was encrypted with is not in the keyring")
+var InvalidCiphertextErr = errors.New("The encrypted value is corrupt or has been tampered with")
+var NoEncryptionKeysErr = errors.New("The keyring has no keys to encrypt with")
+
+func init() {
+	encrypt_prefixes = flag.String("encrypt", "", "a comma separated list of the prefixes whose values are encrypted in the store, i.e. /secrets,/prod/db")
+	encrypt_keys = flag.String("encrypt-keys", "", "the keys to encrypt with, either a keyfile of <id> <base64 key> lines or the url of a key service")
+	encrypt_allow_plaintext = flag.Bool("encrypt-allow-plaintext", false, "read the plaintext values under the encrypted prefixes, while the keys written before encryption are migrated")
+}
+
+/* The source of the encryption keys */
+type Keyring interface {
+	/* the key new values are encrypted with */
+	CurrentKey(ctx context.Context) (string, []byte, error)
+	/* the key with the id, for decrypting */
+	Key(ctx context.Context, id string) ([]byte, error)
+}
+
+/*
+The encrypted store wraps another store, encrypting the values under the prefixes before they
+are written and decrypting them as they are read, so the backend and its backups only ever see
+ciphertext. Values are sealed with AES-GCM using the key as additional data, so a value can't
+be copied to another key, and carry the id of the key they were encrypted with; keys can be
+rotated by adding a new one to the keyring while keeping the old ones to read existing values
+*/
+type EncryptedStore struct {
+	/* the store we are wrapping */
+	Store KVStore
+	/* the prefixes which are encrypted */
+	Prefixes []string
+	/* where the keys come from */
+	Keys Keyring
+	/* plaintext under the prefixes is read as it is, rather than refused, while the keys are migrated */
+	AllowPlaintext bool
+}
+
+/* Wrap the store if any prefixes are to be encrypted, otherwise it's handed back as it is */
+func EncryptStore(store KVStore) (KVStore, error) {
+	if *encrypt_prefixes == "" {
+		return store, nil
+	}
+	if *encrypt_keys == "" {
+		glog.Errorf("Encrypting the prefixes: %s requires the keys, see -encrypt-keys", *encrypt_prefixes)
+		return nil, NoEncryptionKeysErr
+	}
+	var keyring Keyring
+	var err error
+	if strings.HasPrefix(*encrypt_keys, "http://") || strings.HasPrefix(*encrypt_keys, "https://") {
+		keyring = NewKeyService(*encrypt_keys)
+	} else if keyring, err = LoadKeyfile(*encrypt_keys); err != nil {
+		glog.Errorf("Failed to load the keyfile: %s, error: %s", *encrypt_keys, err)
+		return nil, err
+	}
+	encrypted := &EncryptedStore{Store: store, Keys: keyring, Prefixes: make([]string, 0), AllowPlaintext: *encrypt_allow_plaintext}
+	for _, prefix := range strings.Split(*encrypt_prefixes, ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			encrypted.Prefixes = append(encrypted.Prefixes, filepath.Clean("/"+prefix))
+		}
+	}
+	glog.Infof("Encrypting the values under the prefixes: %s", encrypted.Prefixes)
+	return encrypted, nil
+}
+
+/* Check if the key is under one of the encrypted prefixes */
+func (r *EncryptedStore) Encrypted(key string) bool {
+	key = filepath.Clean("/" + key)
+	for _, prefix := range r.Prefixes {
+		if prefix == "/" || key == prefix || strings.HasPrefix(key, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+/* Seal the value if the key is under an encrypted prefix */
+func (r *EncryptedStore) Encrypt(ctx context.Context, key, value string) (string, error) {
+	if !r.Encrypted(key) {
+		return value, nil
+	}
+	id, secret, err := r.Keys.CurrentKey(ctx)
+	if err != nil {
+		glog.Errorf("Failed to get the current encryption key, error: %s", err)
+		return "", err
+	}
+	aead, err := NewAEAD(secret)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(value), []byte(filepath.Clean("/"+key)))
+	return ENCRYPTED_PREFIX + id + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+/*
+Open the value if it's encrypted, whatever the prefix, so values keep reading after a prefix
+is dropped. Plaintext outside the prefixes is handed back as it is; under them it's refused,
+as anyone able to write to the backend could otherwise substitute a value of their own, unless
+plaintext is allowed while the keys are being migrated
+*/
+func (r *EncryptedStore) Decrypt(ctx context.Context, key, value string) (string, string, error) {
+	if !strings.HasPrefix(value, ENCRYPTED_PREFIX) {
+		if !r.Encrypted(key) {
+			return value, "", nil
+		}
+		if !r.AllowPlaintext {
+			glog.Errorf("Decrypt() key: %s is under an encrypted prefix but holds plaintext", key)
+			return "", "", InvalidCiphertextErr
+		}
+		Verbose("Decrypt() key: %s is under an encrypted prefix but holds plaintext, allowed while migrating", key)
+		return value, "", nil
+	}
+	fields := strings.SplitN(strings.TrimPrefix(value, ENCRYPTED_PREFIX), ":", 2)
+	if len(fields) != 2 {
+		return "", "", InvalidCiphertextErr
+	}
+	secret, err := r.Keys.Key(ctx, fields[0])
+	if err != nil {
+		glog.Errorf("Failed to get the encryption key: %s for the key: %s, error: %s", fields[0], key, err)
+		return "", "", err
+	}
+	aead, err := NewAEAD(secret)
+	if err != nil {
+		return "", "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", "", InvalidCiphertextErr
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(filepath.Clean("/"+key)))
+	if err != nil {
+		glog.Errorf("Failed to decrypt the key: %s, error: %s", key, err)
+		return "", "", InvalidCiphertextErr
+	}
+	return string(plaintext), fields[0], nil
+}
+
+/* Decrypt the value of the node in place, recording the key it was encrypted with */
+func (r *EncryptedStore) DecryptNode(ctx context.Context, node *Node) error {
+	if node == nil || node.IsDir() {
+		return nil
+	}
+	value, id, err := r.Decrypt(ctx, node.Path, node.Value)
+	if err != nil {
+		return err
+	}
+	node.Value = value
+	if id != "" {
+		if node.Metadata == nil {
+			node.Metadata = make(map[string]string, 0)
+		}
+		node.Metadata["encryption.key"] = id
+	}
+	return nil
+}
+
+func (r *EncryptedStore) Get(ctx context.Context, key string) (*Node, error) {
+	node, err := r.Store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.DecryptNode(ctx, node); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func (r *EncryptedStore) List(ctx context.Context, path string) ([]*Node, error) {
+	nodes, err := r.Store.List(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	/* step: a key we can't decrypt shouldn't hide the rest of the directory */
+	for _, node := range nodes {
+		if err := r.DecryptNode(ctx, node); err != nil {
+			node.Value = ""
+		}
+	}
+	return nodes, nil
+}
+
+func (r *EncryptedStore) Set(ctx context.Context, key string, value string) error {
+	return r.SetWithTTL(ctx, key, value, 0)
+}
+
+func (r *EncryptedStore) SetWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error {
+	sealed, err := r.Encrypt(ctx, key, value)
+	if err != nil {
+		return err
+	}
+	return r.Store.SetWithTTL(ctx, key, sealed, ttl)
+}
+
+func (r *EncryptedStore) Delete(ctx context.Context, key string) error {
+	return r.Store.Delete(ctx, key)
+}
+
+func (r *EncryptedStore) RemovePath(ctx context.Context, path string) error {
+	return r.Store.RemovePath(ctx, path)
+}
+
+func (r *EncryptedStore) Mkdir(ctx context.Context, path string) error {
+	return r.Store.Mkdir(ctx, path)
+}
+
+/*
+The ciphertext is different every time, so when comparing on the value we read the key,
+compare the plaintext and then swap on the stored ciphertext and its index
+*/
+func (r *EncryptedStore) Unchanged(ctx context.Context, key, oldValue string, oldIndex uint64) (string, uint64, error) {
+	if oldIndex != 0 {
+		return oldValue, oldIndex, nil
+	}
+	node, err := r.Store.Get(ctx, key)
+	if err != nil {
+		return "", 0, err
+	}
+	if node.IsDir() {
+		return "", 0, InvalidDirectoryErr
+	}
+	value, _, err := r.Decrypt(ctx, key, node.Value)
+	if err != nil {
+		return "", 0, err
+	}
+	if value != oldValue {
+		return "", 0, CompareFailedErr
+	}
+	return node.Value, node.Index, nil
+}
+
+func (r *EncryptedStore) CompareAndSwap(ctx context.Context, key string, oldValue string, oldIndex uint64, value string) error {
+	return r.CompareAndSwapWithTTL(ctx, key, oldValue, oldIndex, value, 0)
+}
+
+func (r *EncryptedStore) CompareAndSwapWithTTL(ctx context.Context, key string, oldValue string, oldIndex uint64, value string, ttl time.Duration) error {
+	storedValue, storedIndex, err := r.Unchanged(ctx, key, oldValue, oldIndex)
+	if err != nil {
+		return err
+	}
+	sealed, err := r.Encrypt(ctx, key, value)
+	if err != nil {
+		return err
+	}
+	return r.Store.CompareAndSwapWithTTL(ctx, key, storedValue, storedIndex, sealed, ttl)
+}
+
+func (r *EncryptedStore) CompareAndDelete(ctx context.Context, key string, oldValue string, oldIndex uint64) error {
+	storedValue, storedIndex, err := r.Unchanged(ctx, key, oldValue, oldIndex)
+	if err != nil {
+		return err
+	}
+	return r.Store.CompareAndDelete(ctx, key, storedValue, storedIndex)
+}
+
+func (r *EncryptedStore) Close() error {
+	return r.Store.Close()
+}
+
+func (r *EncryptedStore) Txn(ctx context.Context, operations []*Operation) error {
+	sealed := make([]*Operation, 0, len(operations))
+	for _, operation := range operations {
+		if operation.Delete {
+			sealed = append(sealed, operation)
+			continue
+		}
+		value, err := r.Encrypt(ctx, operation.Key, operation.Value)
+		if err != nil {
+			return err
+		}
+		sealed = append(sealed, &Operation{Key: operation.Key, Value: value})
+	}
+	return r.Store.Txn(ctx, sealed)
+}
+
+/* The changes are passed on with the values decrypted; one we can't decrypt is passed on without a value */
+func (r *EncryptedStore) Watch(ctx context.Context, key string) (<-chan NodeChange, context.CancelFunc, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	changes, stop, err := r.Store.Watch(ctx, key)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	updates := make(chan NodeChange, 10)
+	go func() {
+		defer close(updates)
+		for change := range changes {
+			if err := r.DecryptNode(ctx, &change.Node); err != nil {
+				glog.Errorf("Watch() failed to decrypt the change on key: %s, error: %s", change.Node.Path, err)
+				change.Node.Value = ""
+			}
+			select {
+			case updates <- change:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return updates, func() { cancel(); stop() }, nil
+}
+
+func NewAEAD(secret []byte) (cipher.AEAD, error) {
+	if len(secret) != ENCRYPTION_KEY_SIZE {
+		return nil, InvalidEncryptionKeyErr
+	}
+	block, err := aes.NewCipher(secret)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+/*
+A keyfile has a key per line, <id> <base64 key>; the last one is used to encrypt and the
+others are kept to decrypt the values written before a rotation. Blank lines and anything
+after a # are ignored
+*/
+type Keyfile struct {
+	/* the keys by id */
+	Keys map[string][]byte
+	/* the id of the key we encrypt with */
+	Current string
+}
+
+func LoadKeyfile(filename string) (*Keyfile, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	keyfile := &Keyfile{Keys: make(map[string][]byte, 0)}
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if index := strings.Index(text, "#"); index >= 0 {
+			text = text[:index]
+		}
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 || strings.Contains(fields[0], ":") {
+			return nil, fmt.Errorf("line %d: must be <id> <base64 key>, the id without a colon", line)
+		}
+		secret, err := base64.StdEncoding.DecodeString(fields[1])
+		if err != nil || len(secret) != ENCRYPTION_KEY_SIZE {
+			return nil, fmt.Errorf("line %d: %s", line, InvalidEncryptionKeyErr)
+		}
+		keyfile.Keys[fields[0]] = secret
+		keyfile.Current = fields[0]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if keyfile.Current == "" {
+		return nil, NoEncryptionKeysErr
+	}
+	return keyfile, nil
+}
+
+func (r *Keyfile) CurrentKey(ctx context.Context) (string, []byte, error) {
+	return r.Current, r.Keys[r.Current], nil
+}
+
+func (r *Keyfile) Key(ctx context.Context, id string) ([]byte, error) {
+	if secret, found := r.Keys[id]; found {
+		return secret, nil
+	}
+	return nil, EncryptionKeyNotFoundErr
+}
+
+/*
+A stand in for a KMS; the keys are fetched from a http service, GET <url>/current for the key
+to encrypt with and GET <url>/<id> for any other, each answering {"id": "...", "key": "<base64>"}.
+The keys are cached once fetched, the current one for a minute so a rotation is picked up
+*/
+type KeyService struct {
+	sync.Mutex
+	/* the url of the service */
+	URL string
+	/* the client used to talk to it */
+	Client *http.Client
+	/* the keys fetched so far */
+	Keys map[string][]byte
+	/* the current key and when we fetched it */
+	Current   string
+	Refreshed time.Time
+}
+
+type keyServiceResponse struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+}
+
+func NewKeyService(url string) *KeyService {
+	return &KeyService{
+		URL:    strings.TrimSuffix(url, "/"),
+		Client: &http.Client{Timeout: 10 * time.Second},
+		Keys:   make(map[string][]byte, 0)}
+}
+
+func (r *KeyService) CurrentKey(ctx context.Context) (string, []byte, error) {
+	r.Lock()
+	defer r.Unlock()
+	if r.Current != "" && time.Since(r.Refreshed) < time.Minute {
+		return r.Current, r.Keys[r.Current], nil
+	}
+	id, secret, err := r.Fetch(ctx, "current")
+	if err != nil {
+		return "", nil, err
+	}
+	r.Current, r.Refreshed = id, time.Now()
+	return id, secret, nil
+}
+
+func (r *KeyService) Key(ctx context.Context, id string) ([]byte, error) {
+	r.Lock()
+	defer r.Unlock()
+	if secret, found := r.Keys[id]; found {
+		return secret, nil
+	}
+	_, secret, err := r.Fetch(ctx, id)
+	return secret, err
+}
+
+func (r *KeyService) Fetch(ctx context.Context, id string) (string, []byte, error) {
+	request, err := http.NewRequest("GET", r.URL+"/"+id, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	response, err := r.Client.Do(request.WithContext(ctx))
+	if err != nil {
+		glog.Errorf("Failed to fetch the key: %s from the key service, error: %s", id, err)
+		return "", nil, err
+	}
+	defer response.Body.Close()
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return "", nil, EncryptionKeyNotFoundErr
+	default:
+		return "", nil, fmt.Errorf("the key service responded with: %s", response.Status)
+	}
+	decoded := new(keyServiceResponse)
+	if err := json.NewDecoder(response.Body).Decode(decoded); err != nil {
+		return "", nil, err
+	}
+	secret, err := base64.StdEncoding.DecodeString(decoded.Key)
+	if err != nil || len(secret) != ENCRYPTION_KEY_SIZE || decoded.ID == "" || strings.Contains(decoded.ID, ":") {
+		return "", nil, InvalidEncryptionKeyErr
+	}
+	r.Keys[decoded.ID] = secret
+	return decoded.ID, secret, nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+/* A store encrypting /secrets with the key k1, with k0 in the keyring from before a rotation */
+func NewTestEncryptedStore(t *testing.T) (*EncryptedStore, *MemoryStoreClient) {
+	backend := NewTestMemoryStore(t, "mem://")
+	keyring := &Keyfile{
+		Keys: map[string][]byte{
+			"k0": bytes.Repeat([]byte{0}, ENCRYPTION_KEY_SIZE),
+			"k1": bytes.Repeat([]byte{1}, ENCRYPTION_KEY_SIZE)},
+		Current: "k1"}
+	return &EncryptedStore{Store: backend, Keys: keyring, Prefixes: []string{"/secrets"}}, backend
+}
+
+func TestEncryptRoundTrip(t *testing.T) {
+	store, backend := NewTestEncryptedStore(t)
+	ctx := context.Background()
+	tests := []struct {
+		Key       string
+		Value     string
+		Encrypted bool
+	}{
+		{"/secrets/db/password", "hunter2", true},
+		{"/secrets/token", "s3cr3t", true},
+		{"/secrets/empty", "", true},
+		{"/secretsx/key", "not under the prefix", false},
+		{"/config/motd", "hello", false},
+	}
+	for _, test := range tests {
+		if err := store.Set(ctx, test.Key, test.Value); err != nil {
+			t.Errorf("failed to set the key: %s, error: %s", test.Key, err)
+			continue
+		}
+		raw, _ := backend.Get(ctx, test.Key)
+		if encrypted := strings.HasPrefix(raw.Value, ENCRYPTED_PREFIX+"k1:"); encrypted != test.Encrypted {
+			t.Errorf("key: %s, expected encrypted: %t, stored: %q", test.Key, test.Encrypted, raw.Value)
+		}
+		node, err := store.Get(ctx, test.Key)
+		if err != nil {
+			t.Errorf("failed to get the key: %s, error: %s", test.Key, err)
+			continue
+		}
+		if node.Value != test.Value {
+			t.Errorf("key: %s, expected: %q, got: %q", test.Key, test.Value, node.Value)
+		}
+		if test.Encrypted && node.Metadata["encryption.key"] != "k1" {
+			t.Errorf("key: %s, expected the encryption key in the metadata, got: %v", test.Key, node.Metadata)
+		}
+	}
+}
+
+func TestEncryptCiphertextIsUnique(t *testing.T) {
+	store, _ := NewTestEncryptedStore(t)
+	ctx := context.Background()
+	first, _ := store.Encrypt(ctx, "/secrets/a", "value")
+	second, _ := store.Encrypt(ctx, "/secrets/a", "value")
+	if first == second {
+		t.Errorf("expected a fresh nonce for every value, got the same ciphertext twice")
+	}
+}
+
+func TestDecryptTampering(t *testing.T) {
+	store, _ := NewTestEncryptedStore(t)
+	ctx := context.Background()
+	sealed, err := store.Encrypt(ctx, "/secrets/a", "value")
+	if err != nil {
+		t.Fatalf("failed to encrypt, error: %s", err)
+	}
+	encoded := strings.TrimPrefix(sealed, ENCRYPTED_PREFIX+"k1:")
+	raw, _ := base64.StdEncoding.DecodeString(encoded)
+	flipped := append([]byte{}, raw...)
+	flipped[len(flipped)-1] ^= 1
+	tests := []struct {
+		Name  string
+		Key   string
+		Value string
+		Error error
+	}{
+		{"untouched", "/secrets/a", sealed, nil},
+		{"flipped bit", "/secrets/a", ENCRYPTED_PREFIX + "k1:" + base64.StdEncoding.EncodeToString(flipped), InvalidCiphertextErr},
+		{"copied to another key", "/secrets/b", sealed, InvalidCiphertextErr},
+		{"truncated", "/secrets/a", ENCRYPTED_PREFIX + "k1:" + base64.StdEncoding.EncodeToString(raw[:4]), InvalidCiphertextErr},
+		{"not base64", "/secrets/a", ENCRYPTED_PREFIX + "k1:!!!", InvalidCiphertextErr},
+		{"no key id", "/secrets/a", ENCRYPTED_PREFIX + encoded, InvalidCiphertextErr},
+		{"other key", "/secrets/a", ENCRYPTED_PREFIX + "k0:" + encoded, InvalidCiphertextErr},
+		{"unknown key", "/secrets/a", ENCRYPTED_PREFIX + "k9:" + encoded, EncryptionKeyNotFoundErr},
+		{"plaintext", "/secrets/a", "value", InvalidCiphertextErr},
+		{"plaintext outside the prefixes", "/config/a", "value", nil},
+	}
+	for _, test := range tests {
+		_, _, err := store.Decrypt(ctx, test.Key, test.Value)
+		if err != test.Error {
+			t.Errorf("%s: expected: %v, got: %v", test.Name, test.Error, err)
+		}
+	}
+}
+
+func TestDecryptPlaintextWhileMigrating(t *testing.T) {
+	store, backend := NewTestEncryptedStore(t)
+	ctx := context.Background()
+	backend.Set(ctx, "/secrets/legacy", "plaintext")
+	if _, err := store.Get(ctx, "/secrets/legacy"); err != InvalidCiphertextErr {
+		t.Errorf("expected the plaintext to be refused, got: %v", err)
+	}
+	store.AllowPlaintext = true
+	node, err := store.Get(ctx, "/secrets/legacy")
+	if err != nil || node.Value != "plaintext" {
+		t.Fatalf("expected the plaintext while migrating, got: %v, error: %v", node, err)
+	}
+	/* step: the key is encrypted when next written */
+	if err := store.CompareAndSwap(ctx, "/secrets/legacy", node.Value, node.Index, "migrated"); err != nil {
+		t.Fatalf("failed to write the key, error: %s", err)
+	}
+	if raw, _ := backend.Get(ctx, "/secrets/legacy"); !strings.HasPrefix(raw.Value, ENCRYPTED_PREFIX) {
+		t.Errorf("expected the key to be encrypted once written, stored: %q", raw.Value)
+	}
+}
+
+func TestDecryptAfterRotation(t *testing.T) {
+	store, backend := NewTestEncryptedStore(t)
+	ctx := context.Background()
+	keyring := store.Keys.(*Keyfile)
+	keyring.Current = "k0"
+	store.Set(ctx, "/secrets/old", "written with k0")
+	keyring.Current = "k1"
+	node, err := store.Get(ctx, "/secrets/old")
+	if err != nil || node.Value != "written with k0" || node.Metadata["encryption.key"] != "k0" {
+		t.Errorf("expected the value written before the rotation, got: %v, error: %v", node, err)
+	}
+	delete(keyring.Keys, "k0")
+	if _, err := store.Get(ctx, "/secrets/old"); err != EncryptionKeyNotFoundErr {
+		t.Errorf("expected the key to be missing from the keyring, got: %v", err)
+	}
+	if raw, _ := backend.Get(ctx, "/secrets/old"); !strings.HasPrefix(raw.Value, ENCRYPTED_PREFIX+"k0:") {
+		t.Errorf("expected the value to be encrypted with k0, stored: %q", raw.Value)
+	}
+}
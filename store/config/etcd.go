@@ -14,7 +14,11 @@ limitations under the License.
 package config
 
 import (
+	"context"
+	"flag"
+	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -22,36 +26,116 @@ import (
 	"github.com/golang/glog"
 )
 
+const (
+	/* the error codes handed back by etcd */
+	ETCD_ERROR_KEY_NOT_FOUND = 100
+	ETCD_ERROR_TEST_FAILED   = 101
+	ETCD_ERROR_INDEX_CLEARED = 401
+)
+
+var etcd_username, etcd_password *string
+
+func init() {
+	etcd_username = flag.String("etcd-username", "", "the username used to authenticate to etcd")
+	etcd_password = flag.String("etcd-password", "", "the password used to authenticate to etcd")
+}
+
 type EtcdStoreClient struct {
 	/* a list of etcd hosts */
 	Hosts []string
 	/* the etcd client - under the hood is http client which should be pooled i believe */
 	Client *etcd.Client
+	/* reads go through the leader, otherwise any member can answer and may be behind */
+	Quorum bool
+	/* the transport of the client */
+	Transport *http.Transport
 }
 
 func NewEtcdStoreClient(uri *url.URL) (KVStore, error) {
-	glog.Infof("Creating a Etcd Agent for K/V Store, url: %s", uri)
+	glog.Infof("Creating a Etcd Agent for K/V Store, host: %s", uri.Host)
 	if uri.Scheme != "etcd" {
-		glog.Errorf("Invalid url: %s, must start with etcd", uri)
+		glog.Errorf("Invalid url scheme: %s, must start with etcd", uri.Scheme)
 		return nil, InvalidUrlErr
 	}
+	options, err := GetTLSOptions(uri)
+	if err != nil {
+		return nil, err
+	}
+	transport, err := options.Transport()
+	if err != nil {
+		glog.Errorf("Failed to create the transport for etcd, error: %s", err)
+		return nil, err
+	}
 	store := new(EtcdStoreClient)
 	store.Hosts = make([]string, 0)
 	for _, etcd_host := range strings.Split(uri.Host, ",") {
-		store.Hosts = append(store.Hosts, "http://"+etcd_host)
+		host := &url.URL{Scheme: options.Scheme(), Host: etcd_host}
+		store.Hosts = append(store.Hosts, host.String())
 	}
 	glog.Infof("Creating a Etcd Client, hosts: %s", store.Hosts)
 	store.Client = etcd.NewClient(store.Hosts)
+	store.Transport = transport
+	/*
+	step: the credentials are sent as a basic auth header, rather than living in the host urls; the
+	client only takes a transport, so the header is added by a round tripper registered for the schemes
+	*/
+	if credentials := GetEtcdCredentials(uri); credentials != nil {
+		authenticated := &EtcdCredentials{User: credentials, Transport: transport}
+		transport = new(http.Transport)
+		transport.RegisterProtocol("http", authenticated)
+		transport.RegisterProtocol("https", authenticated)
+	}
+	store.Client.SetTransport(transport)
 	store.Client.SetConsistency( etcd.WEAK_CONSISTENCY )
+	if quorum := uri.Query().Get("quorum"); quorum != "" {
+		if store.Quorum, err = strconv.ParseBool(quorum); err != nil {
+			glog.Errorf("Invalid quorum option: %s in url", quorum)
+			return nil, InvalidUrlErr
+		}
+	}
 	return store, nil
 }
 
-func (r *EtcdStoreClient) Get(key string) (*Node,error) {
+/* Get the basic auth credentials for etcd, either from the url or the flags */
+func GetEtcdCredentials(uri *url.URL) *url.Userinfo {
+	username, password := *etcd_username, *etcd_password
+	if uri.User != nil {
+		username = uri.User.Username()
+		password, _ = uri.User.Password()
+	}
+	if value := uri.Query().Get("username"); value != "" {
+		username = value
+	}
+	if value := uri.Query().Get("password"); value != "" {
+		password = value
+	}
+	if username == "" {
+		return nil
+	}
+	return url.UserPassword(username, password)
+}
+
+/* Sends the requests of the etcd client with the basic auth credentials */
+type EtcdCredentials struct {
+	/* the username and password sent with each request */
+	User *url.Userinfo
+	/* the transport the requests are sent on */
+	Transport *http.Transport
+}
+
+func (r *EtcdCredentials) RoundTrip(request *http.Request) (*http.Response, error) {
+	password, _ := r.User.Password()
+	request = request.Clone(request.Context())
+	request.SetBasicAuth(r.User.Username(), password)
+	return r.Transport.RoundTrip(request)
+}
+
+func (r *EtcdStoreClient) Get(ctx context.Context, key string) (*Node,error) {
 	if !strings.HasPrefix(key, "/") {
 		key = "/" + key
 	}
 	/* step: lets check the cache */
-	if response, err := r.GetRaw(key); err != nil {
+	if response, err := r.GetRaw(ctx, key); err != nil {
 		glog.Errorf("Failed to get the key: %s, error: %s", key, err)
 		return nil, err
 	} else {
@@ -59,59 +143,171 @@ func (r *EtcdStoreClient) Get(key string) (*Node,error) {
 	}
 }
 
-func (r *EtcdStoreClient) GetRaw(key string) (response *etcd.Response, err error) {
+func (r *EtcdStoreClient) GetRaw(ctx context.Context, key string) (response *etcd.Response, err error) {
 	Verbose("GetRaw() key: %s", key)
-	response, err = r.Client.Get( key, false, false)
+	var options url.Values
+	if r.Quorum {
+		options = url.Values{"quorum": {"true"}}
+	}
+	response, err = r.Request(ctx, "GET", key, options, nil)
 	if err != nil {
+		if etcdErr, found := err.(*etcd.EtcdError); found && etcdErr.ErrorCode == ETCD_ERROR_KEY_NOT_FOUND {
+			return nil, NodeNotFoundErr
+		}
 		glog.Errorf("Failed to get the key: %s, error: %s", key, err)
 		return nil, err
 	}
 	return response, nil
 }
 
-func (r *EtcdStoreClient) Set(key string, value string) error {
-	Verbose("Set() key: %s, value: %s", key, value)
-	_, err := r.Client.Set(key, value, uint64(0))
+/*
+The go-etcd client methods can't be cancelled, so we send the raw requests ourselves with
+a cancel channel which is closed when the context is done
+*/
+func (r *EtcdStoreClient) Request(ctx context.Context, method, key string, options, values url.Values) (*etcd.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	path := "keys/" + strings.TrimPrefix(key, "/")
+	if len(options) > 0 {
+		path += "?" + options.Encode()
+	}
+	cancel := make(chan bool)
+	finished := make(chan bool)
+	defer close(finished)
+	go func() {
+		select {
+		case <-ctx.Done():
+			close(cancel)
+		case <-finished:
+		}
+	}()
+	raw, err := r.Client.SendRequest(etcd.NewRawRequest(method, path, values, cancel))
 	if err != nil {
+		if err == etcd.ErrRequestCancelled {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	return raw.Unmarshal()
+}
+
+func (r *EtcdStoreClient) Set(ctx context.Context, key string, value string) error {
+	Verbose("Set() key: %s, value: %s", key, Redact(key, value))
+	if _, err := r.Request(ctx, "PUT", key, nil, url.Values{"value": {value}}); err != nil {
 		glog.Errorf("Failed to set the key: %s, error: %s", key, err)
 		return err
 	}
 	return nil
 }
 
-func (r *EtcdStoreClient) Delete(key string) error {
+/* A set without a ttl makes the key permanent again */
+func (r *EtcdStoreClient) SetWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error {
+	Verbose("SetWithTTL() key: %s, value: %s, ttl: %s", key, Redact(key, value), ttl)
+	if ttl <= 0 {
+		return r.Set(ctx, key, value)
+	}
+	/* step: etcd works in seconds, we round up so the key never expires early */
+	seconds := int64((ttl + time.Second - 1) / time.Second)
+	if _, err := r.Request(ctx, "PUT", key, nil, url.Values{
+		"value": {value}, "ttl": {strconv.FormatInt(seconds, 10)}}); err != nil {
+		glog.Errorf("Failed to set the key: %s, error: %s", key, err)
+		return err
+	}
+	return nil
+}
+
+func (r *EtcdStoreClient) Delete(ctx context.Context, key string) error {
 	Verbose("Delete() deleting the key: %s", key)
-	if _, err := r.Client.Delete(key, false); err != nil {
+	if _, err := r.Request(ctx, "DELETE", key, nil, nil); err != nil {
 		glog.Errorf("Delete() failed to delete key: %s, error: %s", key, err)
 		return err
 	}
 	return nil
 }
 
-func (r *EtcdStoreClient) RemovePath(path string) error {
+func (r *EtcdStoreClient) CompareAndSwap(ctx context.Context, key string, oldValue string, oldIndex uint64, value string) error {
+	return r.CompareAndSwapWithTTL(ctx, key, oldValue, oldIndex, value, 0)
+}
+
+func (r *EtcdStoreClient) CompareAndSwapWithTTL(ctx context.Context, key string, oldValue string, oldIndex uint64, value string, ttl time.Duration) error {
+	Verbose("CompareAndSwap() key: %s, index: %d, value: %s, ttl: %s", key, oldIndex, Redact(key, value), ttl)
+	values := url.Values{"value": {value}}
+	if ttl > 0 {
+		values.Set("ttl", strconv.FormatInt(int64((ttl+time.Second-1)/time.Second), 10))
+	}
+	if _, err := r.Request(ctx, "PUT", key, r.CompareOptions(oldValue, oldIndex), values); err != nil {
+		glog.Errorf("CompareAndSwap() failed to set the key: %s, error: %s", key, err)
+		return r.CompareError(err)
+	}
+	return nil
+}
+
+func (r *EtcdStoreClient) CompareAndDelete(ctx context.Context, key string, oldValue string, oldIndex uint64) error {
+	Verbose("CompareAndDelete() key: %s, index: %d", key, oldIndex)
+	if _, err := r.Request(ctx, "DELETE", key, r.CompareOptions(oldValue, oldIndex), nil); err != nil {
+		glog.Errorf("CompareAndDelete() failed to delete key: %s, error: %s", key, err)
+		return r.CompareError(err)
+	}
+	return nil
+}
+
+/* The conditions for a compare and swap, etcd checks the prevIndex or prevValue for us */
+func (r *EtcdStoreClient) CompareOptions(oldValue string, oldIndex uint64) url.Values {
+	if oldIndex > 0 {
+		return url.Values{"prevIndex": {strconv.FormatUint(oldIndex, 10)}}
+	}
+	return url.Values{"prevValue": {oldValue}}
+}
+
+func (r *EtcdStoreClient) CompareError(err error) error {
+	if etcdErr, found := err.(*etcd.EtcdError); found {
+		switch etcdErr.ErrorCode {
+		case ETCD_ERROR_KEY_NOT_FOUND:
+			return NodeNotFoundErr
+		case ETCD_ERROR_TEST_FAILED:
+			return CompareFailedErr
+		}
+	}
+	return err
+}
+
+/* Drop the connections to etcd; the watches are stopped by their contexts */
+func (r *EtcdStoreClient) Close() error {
+	r.Transport.CloseIdleConnections()
+	return nil
+}
+
+/* There are no transactions in the v2 api, so they're emulated */
+func (r *EtcdStoreClient) Txn(ctx context.Context, operations []*Operation) error {
+	Verbose("Txn() operations: %d", len(operations))
+	return EmulateTxn(ctx, r, operations)
+}
+
+func (r *EtcdStoreClient) RemovePath(ctx context.Context, path string) error {
 	Verbose("RemovePath() deleting the path: %s", path)
-	if _, err := r.Client.Delete(path, true); err != nil {
+	if _, err := r.Request(ctx, "DELETE", path, url.Values{"recursive": {"true"}}, nil); err != nil {
 		glog.Errorf("RemovePath() failed to delete key: %s, error: %s", path, err)
 		return err
 	}
 	return nil
 }
 
-func (r *EtcdStoreClient) Mkdir(path string) error {
+func (r *EtcdStoreClient) Mkdir(ctx context.Context, path string) error {
 	Verbose("Mkdir() path: %s", path)
-	if _, err := r.Client.CreateDir(path, uint64(0)); err != nil {
+	if _, err := r.Request(ctx, "PUT", path, url.Values{"dir": {"true"}, "prevExist": {"false"}}, nil); err != nil {
 		glog.Errorf("Mkdir() failed to create directory node: %s, error: %s", path, err)
 		return err
 	}
 	return nil
 }
 
-func (r *EtcdStoreClient) List(path string) ([]*Node, error) {
+func (r *EtcdStoreClient) List(ctx context.Context, path string) ([]*Node, error) {
 	if !strings.HasPrefix(path, "/" ) || path == "" {
 		path = "/" + path
 	}
 	Verbose("List() path: %s", path )
-	if response, err := r.GetRaw(path); err != nil {
+	if response, err := r.GetRaw(ctx, path); err != nil {
 		glog.Errorf("List() failed to get path: %s, error: %s", path, err)
 		return nil, err
 	} else {
@@ -127,42 +323,141 @@ func (r *EtcdStoreClient) List(path string) ([]*Node, error) {
 	}
 }
 
-func (r *EtcdStoreClient) Watch(key string, updateChannel chan NodeChange) (chan bool,error) {
-	Verbose("Watch() key: %s, channel: %V", key, updateChannel)
-	stopChannel := make(chan bool)
+/*
+Watch the key and everything under it. We keep the index of the last change we've seen and
+resume from the one after it, so nothing is lost between watches; we also keep the last
+known state of the tree, so if etcd has cleared the index we can resync and work out what
+changed in the meantime
+*/
+func (r *EtcdStoreClient) Watch(ctx context.Context, key string) (<-chan NodeChange, context.CancelFunc, error) {
+	Verbose("Watch() key: %s", key)
+	if !strings.HasPrefix(key, "/") {
+		key = "/" + key
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	updateChannel := make(chan NodeChange)
+	/* step: the go-etcd watch is stopped via a channel, so we close it when the context is done */
+	shutdown := make(chan bool)
 	go func() {
-		killOffWatch := false
-		go func() {
-			/* step: wait for the shutdown signal */
-			<-stopChannel
-			glog.V(3).Infof("Watch() killing off the watch on key: %s", key)
-			killOffWatch = true
-		}()
+		<-ctx.Done()
+		glog.V(3).Infof("Watch() killing off the watch on key: %s", key)
+		close(shutdown)
+	}()
+	go func() {
+		defer close(updateChannel)
+		var snapshot map[string]*Node
+		var waitIndex uint64
 		for {
-			if killOffWatch {
+			if ctx.Err() != nil {
 				glog.V(3).Infof("Watch() exitting the watch on key: %s", key)
-				break
+				return
+			}
+			/* step: grab the current state of the tree and the index to watch from */
+			if snapshot == nil {
+				var err error
+				if snapshot, waitIndex, err = r.Snapshot(ctx, key); err != nil {
+					glog.Errorf("Watch() failed to get the state of key: %s, error: %s", key, err)
+					WatchReconnected("etcd")
+					select {
+					case <-ctx.Done():
+					case <-time.After(3 * time.Second):
+					}
+					continue
+				}
 			}
-			response, err := r.Client.Watch(key, uint64(0), true, nil, stopChannel)
+			events := make([]NodeChange, 0)
+			response, err := r.Client.Watch(key, waitIndex, true, nil, shutdown)
 			if err != nil {
-				glog.Errorf("Watch() error attempting to watch the key: %s, error: %s", key, err)
-				time.Sleep(3 * time.Second)
-				continue
+				if err == etcd.ErrWatchStoppedByUser {
+					continue
+				}
+				etcdErr, found := err.(*etcd.EtcdError)
+				if !found || etcdErr.ErrorCode != ETCD_ERROR_INDEX_CLEARED {
+					glog.Errorf("Watch() error attempting to watch the key: %s, error: %s", key, err)
+					WatchReconnected("etcd")
+					select {
+					case <-ctx.Done():
+					case <-time.After(3 * time.Second):
+					}
+					continue
+				}
+				/* step: the index has been cleared, we resync and diff against what we knew */
+				glog.Warningf("Watch() the index: %d for key: %s has been cleared, resyncing", waitIndex, key)
+				current, index, err := r.Snapshot(ctx, key)
+				if err != nil {
+					glog.Errorf("Watch() failed to resync the key: %s, error: %s", key, err)
+					WatchReconnected("etcd")
+					select {
+					case <-ctx.Done():
+					case <-time.After(3 * time.Second):
+					}
+					continue
+				}
+				events = r.GetSnapshotEvents(snapshot, current)
+				snapshot, waitIndex = current, index
+			} else {
+				waitIndex = response.Node.ModifiedIndex + 1
+				events = r.GetNodeEvents(snapshot, response)
 			}
-			if killOffWatch {
-				continue
+			for _, event := range events {
+				/* step: pass the change upstream */
+				Verbose("Watch() sending the change for key: %s upstream", event.Node.Path)
+				select {
+				case updateChannel <- event:
+				case <-ctx.Done():
+					return
+				}
 			}
-			/* step: pass the change upstream */
-			Verbose("Watch() sending the change for key: %s upstream", key)
-			updateChannel <- r.GetNodeEvent(response)
 		}
 	}()
-	return stopChannel,nil
+	return updateChannel, cancel, nil
+}
+
+/* Retrieve the tree under the key as a map of path to node, along with the index to watch from */
+func (r *EtcdStoreClient) Snapshot(ctx context.Context, key string) (map[string]*Node, uint64, error) {
+	snapshot := make(map[string]*Node, 0)
+	response, err := r.Request(ctx, "GET", key, url.Values{"recursive": {"true"}}, nil)
+	if err != nil {
+		/* step: the key might not exist yet, which is fine, we watch for it being created */
+		if etcdErr, found := err.(*etcd.EtcdError); found && etcdErr.ErrorCode == ETCD_ERROR_KEY_NOT_FOUND {
+			return snapshot, etcdErr.Index + 1, nil
+		}
+		return nil, 0, err
+	}
+	r.FlattenNode(response.Node, snapshot)
+	return snapshot, response.EtcdIndex + 1, nil
+}
+
+func (r *EtcdStoreClient) FlattenNode(node *etcd.Node, snapshot map[string]*Node) {
+	snapshot[node.Key] = r.CreateNode(node)
+	for _, child := range node.Nodes {
+		r.FlattenNode(child, snapshot)
+	}
+}
+
+/* Diff the two snapshots of the tree and produce the changes */
+func (r *EtcdStoreClient) GetSnapshotEvents(previous, current map[string]*Node) []NodeChange {
+	events := make([]NodeChange, 0)
+	for path, node := range current {
+		if last, found := previous[path]; !found || last.Index != node.Index {
+			events = append(events, NodeChange{*node, CHANGED})
+		}
+	}
+	for path, node := range previous {
+		if _, found := current[path]; !found {
+			events = append(events, NodeChange{*node, DELETED})
+		}
+	}
+	return events
 }
 
 func (r *EtcdStoreClient) CreateNode(response *etcd.Node) (*Node) {
 	node := &Node{}
 	node.Path = response.Key
+	node.Index = response.ModifiedIndex
+	node.CreateIndex = response.CreatedIndex
+	node.TTL = time.Duration(response.TTL) * time.Second
+	node.Stale = !r.Quorum
 	if response.Dir == false {
 		node.Directory = false
 		node.Value     = response.Value
@@ -172,15 +467,31 @@ func (r *EtcdStoreClient) CreateNode(response *etcd.Node) (*Node) {
 	return node
 }
 
-func (r *EtcdStoreClient) GetNodeEvent(response *etcd.Response) (event NodeChange) {
-	event.Node.Path = response.Node.Key
-	event.Node.Value = response.Node.Value
+/* Convert the watch response into changes, updating the snapshot as we go */
+func (r *EtcdStoreClient) GetNodeEvents(snapshot map[string]*Node, response *etcd.Response) []NodeChange {
+	events := make([]NodeChange, 0)
+	node := r.CreateNode(response.Node)
 	switch response.Action {
-	case "set":
-		event.Operation = CHANGED
-	case "delete":
-		event.Operation = DELETED
+	case "set", "update", "create", "compareAndSwap":
+		snapshot[node.Path] = node
+		events = append(events, NodeChange{*node, CHANGED})
+	case "delete", "expire", "compareAndDelete":
+		/* step: a directory takes everything under it with it */
+		for path, child := range snapshot {
+			if strings.HasPrefix(path, node.Path+"/") {
+				delete(snapshot, path)
+				events = append(events, NodeChange{*child, DELETED})
+			}
+		}
+		if last, found := snapshot[node.Path]; found {
+			node.Directory = last.Directory
+		}
+		delete(snapshot, node.Path)
+		events = append(events, NodeChange{*node, DELETED})
+	default:
+		glog.Warningf("Unknown action: %s on the key: %s", response.Action, node.Path)
+		events = append(events, NodeChange{*node, UNKNOWN})
 	}
-	Verbose("GetNode() event: %s", event)
-	return
+	Verbose("GetNodeEvents() events: %v", events)
+	return events
 }
@@ -0,0 +1,762 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+/*
+The etcd v3 store talks to the v3 API through the grpc gateway which every etcd server
+exposes, the JSON mapping of the grpc api, rather than grpc itself; it saves vendoring grpc and
+the generated clients, at the cost of a stream per watch. The keyspace in v3 is flat, so
+directories are implied from the keys under a prefix, i.e. /prod/db/host gives the directories
+/prod and /prod/db
+*/
+type Etcd3StoreClient struct {
+	/* a list of etcd hosts */
+	Hosts []string
+	/* the api prefix on the gateway, v3 or v3beta for older servers */
+	API string
+	/* the http client used to talk to the gateway */
+	Client *http.Client
+	/* the credentials used to authenticate, if any */
+	Credentials *url.Userinfo
+	/* the auth token handed back by etcd */
+	Token string
+	/* the lock protecting the token */
+	TokenLock sync.RWMutex
+}
+
+const (
+	/* the backoff used when a watch fails */
+	ETCD3_WATCH_MIN_BACKOFF = 1 * time.Second
+	ETCD3_WATCH_MAX_BACKOFF = 60 * time.Second
+)
+
+var Etcd3CompactedErr = errors.New("The requested revision has been compacted")
+
+/* an int64 which the gateway encodes as a string */
+type etcd3Int int64
+
+func (r *etcd3Int) UnmarshalJSON(data []byte) error {
+	value, err := strconv.ParseInt(strings.Trim(string(data), "\""), 10, 64)
+	if err != nil {
+		return err
+	}
+	*r = etcd3Int(value)
+	return nil
+}
+
+type etcd3Header struct {
+	Revision etcd3Int `json:"revision"`
+}
+
+type etcd3KeyValue struct {
+	Key            []byte   `json:"key"`
+	Value          []byte   `json:"value"`
+	CreateRevision etcd3Int `json:"create_revision"`
+	ModRevision    etcd3Int `json:"mod_revision"`
+	Version        etcd3Int `json:"version"`
+	Lease          etcd3Int `json:"lease"`
+}
+
+type etcd3RangeResponse struct {
+	Header etcd3Header      `json:"header"`
+	Kvs    []*etcd3KeyValue `json:"kvs"`
+	Count  etcd3Int         `json:"count"`
+}
+
+type etcd3DeleteResponse struct {
+	Header  etcd3Header `json:"header"`
+	Deleted etcd3Int    `json:"deleted"`
+}
+
+type etcd3Event struct {
+	Type string         `json:"type"`
+	Kv   *etcd3KeyValue `json:"kv"`
+}
+
+type etcd3WatchResponse struct {
+	Result struct {
+		Header          etcd3Header   `json:"header"`
+		Created         bool          `json:"created"`
+		Canceled        bool          `json:"canceled"`
+		CompactRevision etcd3Int      `json:"compact_revision"`
+		Events          []*etcd3Event `json:"events"`
+	} `json:"result"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+type etcd3TxnResponse struct {
+	Header    etcd3Header `json:"header"`
+	Succeeded bool        `json:"succeeded"`
+	Responses []struct {
+		ResponseRange *etcd3RangeResponse `json:"response_range"`
+	} `json:"responses"`
+}
+
+type etcd3LeaseResponse struct {
+	ID    etcd3Int `json:"ID"`
+	TTL   etcd3Int `json:"TTL"`
+	Error string   `json:"error"`
+}
+
+func NewEtcd3StoreClient(uri *url.URL) (KVStore, error) {
+	glog.Infof("Creating a Etcd v3 Agent for K/V Store, host: %s", uri.Host)
+	if uri.Scheme != "etcd3" {
+		glog.Errorf("Invalid url scheme: %s, must start with etcd3", uri.Scheme)
+		return nil, InvalidUrlErr
+	}
+	options, err := GetTLSOptions(uri)
+	if err != nil {
+		return nil, err
+	}
+	transport, err := options.Transport()
+	if err != nil {
+		glog.Errorf("Failed to create the transport for etcd, error: %s", err)
+		return nil, err
+	}
+	store := new(Etcd3StoreClient)
+	store.Hosts = make([]string, 0)
+	for _, etcd_host := range strings.Split(uri.Host, ",") {
+		store.Hosts = append(store.Hosts, options.Scheme()+"://"+etcd_host)
+	}
+	store.API = "v3"
+	if api := uri.Query().Get("api"); api != "" {
+		store.API = api
+	}
+	store.Credentials = GetEtcdCredentials(uri)
+	store.Client = &http.Client{Transport: transport}
+	glog.Infof("Creating a Etcd v3 Client, hosts: %s", store.Hosts)
+	return store, nil
+}
+
+func (r *Etcd3StoreClient) Get(ctx context.Context, key string) (*Node, error) {
+	key = r.KeyPath(key)
+	Verbose("Get() key: %s", key)
+	if key == "/" {
+		return &Node{Path: key, Directory: true}, nil
+	}
+	response := new(etcd3RangeResponse)
+	if err := r.Request(ctx, "kv/range", map[string]interface{}{"key": []byte(key)}, response); err != nil {
+		glog.Errorf("Failed to get the key: %s, error: %s", key, err)
+		return nil, err
+	}
+	if len(response.Kvs) > 0 {
+		node := r.CreateNode(response.Kvs[0])
+		/* step: if the key is attached to a lease, the ttl is whatever is left on the lease */
+		if lease := int64(response.Kvs[0].Lease); lease != 0 {
+			if ttl, err := r.TimeToLive(ctx, lease); err != nil {
+				Verbose("Get() failed to get the ttl of key: %s, error: %s", key, err)
+			} else {
+				node.TTL = ttl
+			}
+		}
+		return node, nil
+	}
+	/* step: the key doesn't exist, but it could be a directory if anything lives under it */
+	prefix := key + "/"
+	if err := r.Request(ctx, "kv/range", map[string]interface{}{
+		"key":        []byte(prefix),
+		"range_end":  r.PrefixEnd(prefix),
+		"limit":      1,
+		"keys_only":  true,
+		"count_only": true}, response); err != nil {
+		glog.Errorf("Failed to get the key: %s, error: %s", key, err)
+		return nil, err
+	}
+	if response.Count > 0 {
+		return &Node{Path: key, Directory: true}, nil
+	}
+	return nil, NodeNotFoundErr
+}
+
+func (r *Etcd3StoreClient) Set(ctx context.Context, key string, value string) error {
+	Verbose("Set() key: %s, value: %s", key, Redact(key, value))
+	return r.SetWithLease(ctx, key, value, 0)
+}
+
+/* The key is attached to a new lease, setting it without one detaches it again */
+func (r *Etcd3StoreClient) SetWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error {
+	Verbose("SetWithTTL() key: %s, value: %s, ttl: %s", key, Redact(key, value), ttl)
+	if ttl <= 0 {
+		return r.SetWithLease(ctx, key, value, 0)
+	}
+	/* step: leases are in seconds, we round up so the key never expires early */
+	lease, err := r.GrantLease(ctx, (ttl+time.Second-1).Truncate(time.Second))
+	if err != nil {
+		return err
+	}
+	return r.SetWithLease(ctx, key, value, lease)
+}
+
+/* Set the key and attach it to a lease, the key is removed when the lease expires */
+func (r *Etcd3StoreClient) SetWithLease(ctx context.Context, key string, value string, lease int64) error {
+	key = r.KeyPath(key)
+	request := map[string]interface{}{"key": []byte(key), "value": []byte(value)}
+	if lease > 0 {
+		request["lease"] = strconv.FormatInt(lease, 10)
+	}
+	if err := r.Request(ctx, "kv/put", request, nil); err != nil {
+		glog.Errorf("Failed to set the key: %s, error: %s", key, err)
+		return err
+	}
+	return nil
+}
+
+func (r *Etcd3StoreClient) Delete(ctx context.Context, key string) error {
+	key = r.KeyPath(key)
+	Verbose("Delete() deleting the key: %s", key)
+	response := new(etcd3DeleteResponse)
+	if err := r.Request(ctx, "kv/deleterange", map[string]interface{}{"key": []byte(key)}, response); err != nil {
+		glog.Errorf("Delete() failed to delete key: %s, error: %s", key, err)
+		return err
+	}
+	if response.Deleted <= 0 {
+		return NodeNotFoundErr
+	}
+	return nil
+}
+
+func (r *Etcd3StoreClient) CompareAndSwap(ctx context.Context, key string, oldValue string, oldIndex uint64, value string) error {
+	return r.CompareAndSwapWithTTL(ctx, key, oldValue, oldIndex, value, 0)
+}
+
+/* The key is put on a new lease in the guarded transaction; a failed swap leaves the lease to expire */
+func (r *Etcd3StoreClient) CompareAndSwapWithTTL(ctx context.Context, key string, oldValue string, oldIndex uint64, value string, ttl time.Duration) error {
+	key = r.KeyPath(key)
+	Verbose("CompareAndSwap() key: %s, index: %d, value: %s, ttl: %s", key, oldIndex, Redact(key, value), ttl)
+	put := map[string]interface{}{"key": []byte(key), "value": []byte(value)}
+	if ttl > 0 {
+		lease, err := r.GrantLease(ctx, (ttl+time.Second-1).Truncate(time.Second))
+		if err != nil {
+			return err
+		}
+		put["lease"] = strconv.FormatInt(lease, 10)
+	}
+	if err := r.CompareTxn(ctx, key, oldValue, oldIndex, map[string]interface{}{"request_put": put}); err != nil {
+		glog.Errorf("CompareAndSwap() failed to set the key: %s, error: %s", key, err)
+		return err
+	}
+	return nil
+}
+
+func (r *Etcd3StoreClient) CompareAndDelete(ctx context.Context, key string, oldValue string, oldIndex uint64) error {
+	key = r.KeyPath(key)
+	Verbose("CompareAndDelete() key: %s, index: %d", key, oldIndex)
+	if err := r.CompareTxn(ctx, key, oldValue, oldIndex, map[string]interface{}{
+		"request_delete_range": map[string]interface{}{"key": []byte(key)}}); err != nil {
+		glog.Errorf("CompareAndDelete() failed to delete key: %s, error: %s", key, err)
+		return err
+	}
+	return nil
+}
+
+/* Drop the connections to etcd; the watches are stopped by their contexts */
+func (r *Etcd3StoreClient) Close() error {
+	r.Client.CloseIdleConnections()
+	return nil
+}
+
+/* Apply the operations in a single transaction; note etcd refuses a key appearing twice */
+func (r *Etcd3StoreClient) Txn(ctx context.Context, operations []*Operation) error {
+	Verbose("Txn() operations: %d", len(operations))
+	success := make([]interface{}, 0)
+	for _, operation := range operations {
+		key := r.KeyPath(operation.Key)
+		if operation.Delete {
+			success = append(success, map[string]interface{}{
+				"request_delete_range": map[string]interface{}{"key": []byte(key)}})
+		} else {
+			success = append(success, map[string]interface{}{
+				"request_put": map[string]interface{}{"key": []byte(key), "value": []byte(operation.Value)}})
+		}
+	}
+	if err := r.Request(ctx, "kv/txn", map[string]interface{}{"success": success}, new(etcd3TxnResponse)); err != nil {
+		glog.Errorf("Txn() failed to apply the transaction, error: %s", err)
+		return err
+	}
+	return nil
+}
+
+/*
+Perform the operation in a transaction guarded by the mod revision or the value of the key;
+if the guard fails we read the key back in the same transaction to tell a missing key from
+a changed one
+*/
+func (r *Etcd3StoreClient) CompareTxn(ctx context.Context, key string, oldValue string, oldIndex uint64, operation map[string]interface{}) error {
+	compare := map[string]interface{}{"key": []byte(key), "result": "EQUAL"}
+	if oldIndex > 0 {
+		compare["target"] = "MOD"
+		compare["mod_revision"] = strconv.FormatUint(oldIndex, 10)
+	} else {
+		compare["target"] = "VALUE"
+		compare["value"] = []byte(oldValue)
+	}
+	response := new(etcd3TxnResponse)
+	if err := r.Request(ctx, "kv/txn", map[string]interface{}{
+		"compare": []interface{}{compare},
+		"success": []interface{}{operation},
+		"failure": []interface{}{map[string]interface{}{
+			"request_range": map[string]interface{}{"key": []byte(key)}}}}, response); err != nil {
+		return err
+	}
+	if response.Succeeded {
+		return nil
+	}
+	if len(response.Responses) > 0 && response.Responses[0].ResponseRange != nil && len(response.Responses[0].ResponseRange.Kvs) > 0 {
+		return CompareFailedErr
+	}
+	return NodeNotFoundErr
+}
+
+func (r *Etcd3StoreClient) RemovePath(ctx context.Context, path string) error {
+	path = r.KeyPath(path)
+	Verbose("RemovePath() deleting the path: %s", path)
+	prefix := strings.TrimSuffix(path, "/") + "/"
+	response := new(etcd3DeleteResponse)
+	if err := r.Request(ctx, "kv/deleterange", map[string]interface{}{
+		"key":       []byte(prefix),
+		"range_end": r.PrefixEnd(prefix)}, response); err != nil {
+		glog.Errorf("RemovePath() failed to delete path: %s, error: %s", path, err)
+		return err
+	}
+	if path == "/" {
+		return nil
+	}
+	/* step: the path is only missing if there was neither a key nor anything under it */
+	err := r.Delete(ctx, path)
+	if err == NodeNotFoundErr && response.Deleted > 0 {
+		return nil
+	}
+	return err
+}
+
+/*
+There are no directories in v3, they only exist while there are keys under them; same as
+consul we simply accept the call
+*/
+func (r *Etcd3StoreClient) Mkdir(ctx context.Context, path string) error {
+	Verbose("Mkdir() path: %s", path)
+	return nil
+}
+
+func (r *Etcd3StoreClient) List(ctx context.Context, path string) ([]*Node, error) {
+	path = r.KeyPath(path)
+	Verbose("List() path: %s", path)
+	prefix := strings.TrimSuffix(path, "/") + "/"
+	response := new(etcd3RangeResponse)
+	if err := r.Request(ctx, "kv/range", map[string]interface{}{
+		"key":       []byte(prefix),
+		"range_end": r.PrefixEnd(prefix)}, response); err != nil {
+		glog.Errorf("List() failed to get path: %s, error: %s", path, err)
+		return nil, err
+	}
+	if len(response.Kvs) <= 0 && path != "/" {
+		/* step: is this is a key rather than a directory? */
+		if _, err := r.Get(ctx, path); err == nil {
+			glog.Errorf("List() path: %s is not a directory node", path)
+			return nil, InvalidDirectoryErr
+		}
+		return nil, NodeNotFoundErr
+	}
+	/* step: we only want the immediate children, anything deeper becomes a directory */
+	list := make([]*Node, 0)
+	directories := make(map[string]bool, 0)
+	for _, kv := range response.Kvs {
+		name := strings.TrimPrefix(string(kv.Key), prefix)
+		if name == "" {
+			continue
+		}
+		if index := strings.Index(name, "/"); index >= 0 {
+			name = name[:index]
+			if _, found := directories[name]; !found {
+				directories[name] = true
+				list = append(list, &Node{Path: prefix + name, Directory: true})
+			}
+			continue
+		}
+		list = append(list, r.CreateNode(kv))
+	}
+	return list, nil
+}
+
+/*
+Watch the key and everything under it; we keep track of the last revision we've seen, so
+when the stream is broken we can pick up from the next revision and not miss anything. We
+also keep the last known state of the prefix, so if the revision has been compacted we can
+resync and work out what changed in the meantime, deletes included
+*/
+func (r *Etcd3StoreClient) Watch(ctx context.Context, key string) (<-chan NodeChange, context.CancelFunc, error) {
+	key = r.KeyPath(key)
+	Verbose("Watch() key: %s", key)
+	ctx, cancel := context.WithCancel(ctx)
+	updateChannel := make(chan NodeChange)
+	go func() {
+		defer close(updateChannel)
+		var snapshot map[string]*Node
+		revision := int64(0)
+		backoff := ETCD3_WATCH_MIN_BACKOFF
+		for {
+			if ctx.Err() != nil {
+				glog.V(3).Infof("Watch() exitting the watch on key: %s", key)
+				return
+			}
+			var err error
+			if snapshot == nil {
+				/* step: grab the current state of the prefix and the revision to watch from */
+				snapshot, revision, err = r.Snapshot(ctx, key)
+			} else {
+				last := revision
+				err = r.WatchStream(ctx, key, &revision, snapshot, updateChannel)
+				if revision != last {
+					backoff = ETCD3_WATCH_MIN_BACKOFF
+				}
+				if err == Etcd3CompactedErr && ctx.Err() == nil {
+					/* step: we've missed events; resync the prefix and diff against what we knew */
+					glog.Warningf("Watch() revision: %d for key: %s has been compacted, resyncing", revision, key)
+					err = r.Resync(ctx, key, &revision, snapshot, updateChannel)
+				}
+			}
+			if err == nil || ctx.Err() != nil {
+				continue
+			}
+			glog.Errorf("Watch() error attempting to watch the key: %s, error: %s, retrying in %s", key, err, backoff)
+			WatchReconnected("etcd3")
+			select {
+			case <-ctx.Done():
+			case <-time.After(backoff):
+			}
+			if backoff *= 2; backoff > ETCD3_WATCH_MAX_BACKOFF {
+				backoff = ETCD3_WATCH_MAX_BACKOFF
+			}
+		}
+	}()
+	return updateChannel, cancel, nil
+}
+
+/* Watch from the revision, keeping the snapshot of the prefix up to date as the changes come in */
+func (r *Etcd3StoreClient) WatchStream(ctx context.Context, key string, revision *int64, snapshot map[string]*Node, updateChannel chan<- NodeChange) error {
+	create := map[string]interface{}{
+		"key":            []byte(key),
+		"range_end":      r.PrefixEnd(key),
+		"start_revision": strconv.FormatInt(*revision+1, 10)}
+	response, err := r.Post(ctx, "watch", map[string]interface{}{"create_request": create})
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	decoder := json.NewDecoder(response.Body)
+	for {
+		var update etcd3WatchResponse
+		if err := decoder.Decode(&update); err != nil {
+			return err
+		}
+		if update.Error != nil {
+			return errors.New(update.Error.Message)
+		}
+		if update.Result.CompactRevision > 0 {
+			return Etcd3CompactedErr
+		}
+		if update.Result.Canceled {
+			return errors.New("the watch was canceled by the server")
+		}
+		for _, event := range update.Result.Events {
+			*revision = int64(event.Kv.ModRevision)
+			/* step: the range is a byte prefix, so /prod would also match /production */
+			if !r.UnderKey(key, string(event.Kv.Key)) {
+				continue
+			}
+			change := r.GetNodeEvent(event)
+			if change.Operation == DELETED {
+				delete(snapshot, change.Node.Path)
+			} else {
+				snapshot[change.Node.Path] = r.CreateNode(event.Kv)
+			}
+			/* step: pass the change upstream */
+			Verbose("Watch() sending the change for key: %s upstream", change.Node.Path)
+			select {
+			case updateChannel <- change:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+/*
+Read the prefix again and send the changes against the snapshot, the keys which have gone
+included; the snapshot and revision are moved on to the state we've read
+*/
+func (r *Etcd3StoreClient) Resync(ctx context.Context, key string, revision *int64, snapshot map[string]*Node, updateChannel chan<- NodeChange) error {
+	current, index, err := r.Snapshot(ctx, key)
+	if err != nil {
+		return err
+	}
+	events := r.GetSnapshotEvents(snapshot, current)
+	for path := range snapshot {
+		delete(snapshot, path)
+	}
+	for path, node := range current {
+		snapshot[path] = node
+	}
+	*revision = index
+	for _, event := range events {
+		Verbose("Resync() sending the change for key: %s upstream", event.Node.Path)
+		select {
+		case updateChannel <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+/* The keys under the prefix by path, along with the revision they were read at */
+func (r *Etcd3StoreClient) Snapshot(ctx context.Context, key string) (map[string]*Node, int64, error) {
+	response := new(etcd3RangeResponse)
+	if err := r.Request(ctx, "kv/range", map[string]interface{}{
+		"key":       []byte(key),
+		"range_end": r.PrefixEnd(key)}, response); err != nil {
+		return nil, 0, err
+	}
+	snapshot := make(map[string]*Node, 0)
+	for _, kv := range response.Kvs {
+		if r.UnderKey(key, string(kv.Key)) {
+			snapshot[string(kv.Key)] = r.CreateNode(kv)
+		}
+	}
+	return snapshot, int64(response.Header.Revision), nil
+}
+
+/* Diff the two snapshots of the prefix and produce the changes */
+func (r *Etcd3StoreClient) GetSnapshotEvents(previous, current map[string]*Node) []NodeChange {
+	events := make([]NodeChange, 0)
+	for path, node := range current {
+		if last, found := previous[path]; !found || last.Index != node.Index {
+			events = append(events, NodeChange{*node, CHANGED})
+		}
+	}
+	for path, node := range previous {
+		if _, found := current[path]; !found {
+			events = append(events, NodeChange{*node, DELETED})
+		}
+	}
+	sort.Sort(changesByPath(events))
+	return events
+}
+
+/* Grant a lease with the ttl, returning the lease id */
+func (r *Etcd3StoreClient) GrantLease(ctx context.Context, ttl time.Duration) (int64, error) {
+	response := new(etcd3LeaseResponse)
+	if err := r.Request(ctx, "lease/grant", map[string]interface{}{
+		"TTL": strconv.FormatInt(int64(ttl.Seconds()), 10)}, response); err != nil {
+		glog.Errorf("GrantLease() failed to grant a lease, error: %s", err)
+		return 0, err
+	}
+	if response.Error != "" {
+		return 0, errors.New(response.Error)
+	}
+	return int64(response.ID), nil
+}
+
+/* Refresh the lease, returning the remaining ttl */
+func (r *Etcd3StoreClient) KeepAlive(ctx context.Context, lease int64) (time.Duration, error) {
+	var response struct {
+		Result etcd3LeaseResponse `json:"result"`
+	}
+	if err := r.Request(ctx, "lease/keepalive", map[string]interface{}{
+		"ID": strconv.FormatInt(lease, 10)}, &response); err != nil {
+		glog.Errorf("KeepAlive() failed to refresh the lease: %d, error: %s", lease, err)
+		return 0, err
+	}
+	if response.Result.TTL <= 0 {
+		return 0, fmt.Errorf("the lease: %d has expired", lease)
+	}
+	return time.Duration(response.Result.TTL) * time.Second, nil
+}
+
+/* The time left on the lease */
+func (r *Etcd3StoreClient) TimeToLive(ctx context.Context, lease int64) (time.Duration, error) {
+	response := new(etcd3LeaseResponse)
+	if err := r.Request(ctx, "lease/timetolive", map[string]interface{}{
+		"ID": strconv.FormatInt(lease, 10)}, response); err != nil {
+		return 0, err
+	}
+	if response.TTL <= 0 {
+		return 0, fmt.Errorf("the lease: %d has expired", lease)
+	}
+	return time.Duration(response.TTL) * time.Second, nil
+}
+
+/* Revoke the lease, removing any keys attached to it */
+func (r *Etcd3StoreClient) RevokeLease(ctx context.Context, lease int64) error {
+	if err := r.Request(ctx, "lease/revoke", map[string]interface{}{
+		"ID": strconv.FormatInt(lease, 10)}, nil); err != nil {
+		glog.Errorf("RevokeLease() failed to revoke the lease: %d, error: %s", lease, err)
+		return err
+	}
+	return nil
+}
+
+/* Perform a request against the gateway and decode the response */
+func (r *Etcd3StoreClient) Request(ctx context.Context, method string, request interface{}, result interface{}) error {
+	response, err := r.Post(ctx, method, request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if result == nil {
+		io.Copy(ioutil.Discard, response.Body)
+		return nil
+	}
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+/* Authenticate with the credentials, the token is handed back on every request */
+func (r *Etcd3StoreClient) Authenticate(ctx context.Context) (string, error) {
+	r.TokenLock.RLock()
+	token := r.Token
+	r.TokenLock.RUnlock()
+	if token != "" || r.Credentials == nil {
+		return token, nil
+	}
+	password, _ := r.Credentials.Password()
+	var response struct {
+		Token string `json:"token"`
+	}
+	if err := r.Request(ctx, "auth/authenticate", map[string]interface{}{
+		"name":     r.Credentials.Username(),
+		"password": password}, &response); err != nil {
+		glog.Errorf("Failed to authenticate to etcd as: %s, error: %s", r.Credentials.Username(), err)
+		return "", err
+	}
+	r.TokenLock.Lock()
+	r.Token = response.Token
+	r.TokenLock.Unlock()
+	return response.Token, nil
+}
+
+/* Post the request to the first host which answers */
+func (r *Etcd3StoreClient) Post(ctx context.Context, method string, request interface{}) (*http.Response, error) {
+	content, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	token := ""
+	if method != "auth/authenticate" {
+		if token, err = r.Authenticate(ctx); err != nil {
+			return nil, err
+		}
+	}
+	var lastErr error
+	for _, host := range r.Hosts {
+		req, err := http.NewRequest("POST", host+"/"+r.API+"/"+method, bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		req = req.WithContext(ctx)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		response, err := r.Client.Do(req)
+		if err != nil {
+			Verbose("Post() host: %s failed, error: %s", host, err)
+			lastErr = err
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			continue
+		}
+		if response.StatusCode != http.StatusOK {
+			message, _ := ioutil.ReadAll(response.Body)
+			response.Body.Close()
+			/* step: the token has expired, we authenticate again on the next request */
+			if response.StatusCode == http.StatusUnauthorized && token != "" {
+				r.TokenLock.Lock()
+				r.Token = ""
+				r.TokenLock.Unlock()
+			}
+			return nil, fmt.Errorf("etcd returned status: %d, message: %s", response.StatusCode, strings.TrimSpace(string(message)))
+		}
+		return response, nil
+	}
+	return nil, lastErr
+}
+
+/* The end of the range covering every key with the prefix */
+func (r *Etcd3StoreClient) PrefixEnd(prefix string) []byte {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return []byte{0}
+}
+
+func (r *Etcd3StoreClient) UnderKey(key, path string) bool {
+	return key == "/" || path == key || strings.HasPrefix(path, key+"/")
+}
+
+func (r *Etcd3StoreClient) KeyPath(key string) string {
+	if !strings.HasPrefix(key, "/") {
+		key = "/" + key
+	}
+	return key
+}
+
+func (r *Etcd3StoreClient) CreateNode(kv *etcd3KeyValue) *Node {
+	node := &Node{
+		Path:        string(kv.Key),
+		Value:       string(kv.Value),
+		Index:       uint64(kv.ModRevision),
+		CreateIndex: uint64(kv.CreateRevision),
+		Metadata:    map[string]string{"etcd.version": strconv.FormatInt(int64(kv.Version), 10)}}
+	if kv.Lease != 0 {
+		node.Metadata["etcd.lease"] = strconv.FormatInt(int64(kv.Lease), 16)
+	}
+	return node
+}
+
+func (r *Etcd3StoreClient) GetNodeEvent(event *etcd3Event) (change NodeChange) {
+	change.Node = *r.CreateNode(event.Kv)
+	switch event.Type {
+	case "DELETE":
+		change.Operation = DELETED
+	default:
+		change.Operation = CHANGED
+	}
+	return
+}
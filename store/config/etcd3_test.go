@@ -0,0 +1,660 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func FreePort(t *testing.T) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to find a free port, error: %s", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+/*
+The tests run against a stand-in for the gateway, or a single member etcd when $ETCD_BIN
+points at one
+*/
+func NewTestEtcd3(t *testing.T) *Etcd3StoreClient {
+	if binary := os.Getenv("ETCD_BIN"); binary != "" {
+		return NewTestEtcd3Server(t, binary)
+	}
+	store, _ := NewTestFakeEtcd3(t, "")
+	return store
+}
+
+func NewTestFakeEtcd3(t *testing.T, credentials string) (*Etcd3StoreClient, *FakeEtcd3) {
+	etcd := NewFakeEtcd3()
+	server := httptest.NewServer(etcd)
+	t.Cleanup(server.Close)
+	t.Cleanup(etcd.Close)
+	location, _ := url.Parse(server.URL)
+	uri, _ := url.Parse("etcd3://" + credentials + location.Host)
+	if credentials != "" {
+		password, _ := uri.User.Password()
+		etcd.Users = map[string]string{uri.User.Username(): password}
+	}
+	store, err := NewEtcd3StoreClient(uri)
+	if err != nil {
+		t.Fatalf("failed to create the etcd v3 store, error: %s", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store.(*Etcd3StoreClient), etcd
+}
+
+func NewTestEtcd3Server(t *testing.T, binary string) *Etcd3StoreClient {
+	directory, err := ioutil.TempDir("", "etcd3")
+	if err != nil {
+		t.Fatalf("failed to create a directory, error: %s", err)
+	}
+	client := fmt.Sprintf("127.0.0.1:%d", FreePort(t))
+	peer := fmt.Sprintf("http://127.0.0.1:%d", FreePort(t))
+	server := exec.Command(binary,
+		"--name", "test",
+		"--data-dir", directory,
+		"--listen-client-urls", "http://"+client,
+		"--advertise-client-urls", "http://"+client,
+		"--listen-peer-urls", peer,
+		"--initial-advertise-peer-urls", peer,
+		"--initial-cluster", "test="+peer)
+	if err := server.Start(); err != nil {
+		t.Fatalf("failed to start etcd, error: %s", err)
+	}
+	t.Cleanup(func() {
+		server.Process.Kill()
+		server.Wait()
+		os.RemoveAll(directory)
+	})
+	store, err := NewEtcd3StoreClient(&url.URL{Scheme: "etcd3", Host: client})
+	if err != nil {
+		t.Fatalf("failed to create the etcd v3 store, error: %s", err)
+	}
+	for deadline := time.Now().Add(10 * time.Second); ; time.Sleep(100 * time.Millisecond) {
+		if _, err := store.Get(context.Background(), "/ready"); err == NodeNotFoundErr {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("etcd didn't come up on: %s", client)
+		}
+	}
+	return store.(*Etcd3StoreClient)
+}
+
+/*
+Enough of the v3 gateway to test against: ranges, puts, deletes, transactions, compaction,
+watches, leases and authentication. Leases never expire on their own, they have to be revoked
+*/
+type FakeEtcd3 struct {
+	sync.Mutex
+	/* the keys in the store */
+	Keys map[string]*etcd3KeyValue
+	/* the changes since the last compaction, for the watches */
+	Events []*etcd3Event
+	/* the current and compacted revisions */
+	Revision  int64
+	Compacted int64
+	/* the ttl of the leases by id */
+	Leases map[int64]int64
+	/* the usernames and passwords, any request is allowed if empty */
+	Users map[string]string
+	/* closed and replaced whenever the store changes, to wake the watches */
+	Changed chan struct{}
+	/* closed when the server is shutting down */
+	Shutdown chan struct{}
+}
+
+type fakeEtcd3Request struct {
+	Key       []byte   `json:"key"`
+	RangeEnd  []byte   `json:"range_end"`
+	Value     []byte   `json:"value"`
+	Lease     etcd3Int `json:"lease"`
+	Limit     etcd3Int `json:"limit"`
+	KeysOnly  bool     `json:"keys_only"`
+	CountOnly bool     `json:"count_only"`
+	Revision  etcd3Int `json:"revision"`
+	TTL       etcd3Int `json:"TTL"`
+	ID        etcd3Int `json:"ID"`
+	Name      string   `json:"name"`
+	Password  string   `json:"password"`
+	Compare   []struct {
+		Key         []byte   `json:"key"`
+		Target      string   `json:"target"`
+		Result      string   `json:"result"`
+		ModRevision etcd3Int `json:"mod_revision"`
+		Value       []byte   `json:"value"`
+	} `json:"compare"`
+	Success       []*fakeEtcd3Operation `json:"success"`
+	Failure       []*fakeEtcd3Operation `json:"failure"`
+	CreateRequest *struct {
+		Key           []byte   `json:"key"`
+		RangeEnd      []byte   `json:"range_end"`
+		StartRevision etcd3Int `json:"start_revision"`
+	} `json:"create_request"`
+}
+
+type fakeEtcd3Operation struct {
+	RequestPut         *fakeEtcd3Request `json:"request_put"`
+	RequestDeleteRange *fakeEtcd3Request `json:"request_delete_range"`
+	RequestRange       *fakeEtcd3Request `json:"request_range"`
+}
+
+func NewFakeEtcd3() *FakeEtcd3 {
+	return &FakeEtcd3{
+		Keys:     make(map[string]*etcd3KeyValue, 0),
+		Leases:   make(map[int64]int64, 0),
+		Changed:  make(chan struct{}),
+		Shutdown: make(chan struct{})}
+}
+
+/* Stop the watches, otherwise the server waits on them when it's closed */
+func (r *FakeEtcd3) Close() {
+	r.Lock()
+	defer r.Unlock()
+	close(r.Shutdown)
+}
+
+func (r *FakeEtcd3) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	method := strings.TrimPrefix(request.URL.Path, "/v3/")
+	var body fakeEtcd3Request
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.Lock()
+	if method != "auth/authenticate" && len(r.Users) > 0 && request.Header.Get("Authorization") != "token" {
+		r.Unlock()
+		http.Error(writer, "invalid auth token", http.StatusUnauthorized)
+		return
+	}
+	if method == "watch" {
+		r.Unlock()
+		r.Watch(writer, request, &body)
+		return
+	}
+	defer r.Unlock()
+	var response interface{}
+	switch method {
+	case "kv/range":
+		response = r.Range(&body)
+	case "kv/put":
+		r.Revision++
+		r.Put(&body)
+		response = map[string]interface{}{"header": r.Header()}
+	case "kv/deleterange":
+		r.Revision++
+		response = map[string]interface{}{"header": r.Header(), "deleted": r.DeleteRange(&body)}
+	case "kv/txn":
+		response = r.Txn(&body)
+	case "kv/compaction":
+		r.Compacted = int64(body.Revision)
+		events := make([]*etcd3Event, 0)
+		for _, event := range r.Events {
+			if int64(event.Kv.ModRevision) > r.Compacted {
+				events = append(events, event)
+			}
+		}
+		r.Events = events
+		response = map[string]interface{}{"header": r.Header()}
+	case "lease/grant":
+		id := int64(len(r.Leases) + 1)
+		r.Leases[id] = int64(body.TTL)
+		response = map[string]interface{}{"ID": strconv.FormatInt(id, 10), "TTL": strconv.FormatInt(int64(body.TTL), 10)}
+	case "lease/timetolive":
+		ttl, found := r.Leases[int64(body.ID)]
+		if !found {
+			ttl = -1
+		}
+		response = map[string]interface{}{"ID": strconv.FormatInt(int64(body.ID), 10), "TTL": strconv.FormatInt(ttl, 10)}
+	case "lease/keepalive":
+		response = map[string]interface{}{"result": map[string]interface{}{"ID": strconv.FormatInt(int64(body.ID), 10), "TTL": strconv.FormatInt(r.Leases[int64(body.ID)], 10)}}
+	case "lease/revoke":
+		delete(r.Leases, int64(body.ID))
+		r.Revision++
+		for key, kv := range r.Keys {
+			if kv.Lease == body.ID {
+				r.DeleteRange(&fakeEtcd3Request{Key: []byte(key)})
+			}
+		}
+		response = map[string]interface{}{"header": r.Header()}
+	case "auth/authenticate":
+		if password, found := r.Users[body.Name]; !found || password != body.Password {
+			http.Error(writer, "authentication failed, invalid user ID or password", http.StatusBadRequest)
+			return
+		}
+		response = map[string]interface{}{"header": r.Header(), "token": "token"}
+	default:
+		http.Error(writer, "Not Found", http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(writer).Encode(response)
+}
+
+func (r *FakeEtcd3) Header() etcd3Header {
+	return etcd3Header{Revision: etcd3Int(r.Revision)}
+}
+
+/* The keys in the range, in order */
+func (r *FakeEtcd3) Matching(start, end []byte) []string {
+	keys := make([]string, 0)
+	for key := range r.Keys {
+		if InRange([]byte(key), start, end) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (r *FakeEtcd3) Range(request *fakeEtcd3Request) *etcd3RangeResponse {
+	keys := r.Matching(request.Key, request.RangeEnd)
+	response := &etcd3RangeResponse{Header: r.Header(), Kvs: make([]*etcd3KeyValue, 0), Count: etcd3Int(len(keys))}
+	if request.CountOnly {
+		return response
+	}
+	for i, key := range keys {
+		if request.Limit > 0 && i >= int(request.Limit) {
+			break
+		}
+		kv := *r.Keys[key]
+		if request.KeysOnly {
+			kv.Value = nil
+		}
+		response.Kvs = append(response.Kvs, &kv)
+	}
+	return response
+}
+
+/* Put the key at the current revision, the caller moves the revision on */
+func (r *FakeEtcd3) Put(request *fakeEtcd3Request) {
+	kv := &etcd3KeyValue{Key: request.Key, Value: request.Value, CreateRevision: etcd3Int(r.Revision), ModRevision: etcd3Int(r.Revision), Version: 1, Lease: request.Lease}
+	if previous, found := r.Keys[string(request.Key)]; found {
+		kv.CreateRevision = previous.CreateRevision
+		kv.Version = previous.Version + 1
+	}
+	r.Keys[string(request.Key)] = kv
+	r.Notify(&etcd3Event{Type: "PUT", Kv: kv})
+}
+
+func (r *FakeEtcd3) DeleteRange(request *fakeEtcd3Request) int {
+	keys := r.Matching(request.Key, request.RangeEnd)
+	for _, key := range keys {
+		delete(r.Keys, key)
+		r.Notify(&etcd3Event{Type: "DELETE", Kv: &etcd3KeyValue{Key: []byte(key), ModRevision: etcd3Int(r.Revision)}})
+	}
+	return len(keys)
+}
+
+func (r *FakeEtcd3) Txn(request *fakeEtcd3Request) *etcd3TxnResponse {
+	succeeded := true
+	for _, compare := range request.Compare {
+		kv, found := r.Keys[string(compare.Key)]
+		switch compare.Target {
+		case "MOD":
+			revision := etcd3Int(0)
+			if found {
+				revision = kv.ModRevision
+			}
+			succeeded = succeeded && revision == compare.ModRevision
+		case "VALUE":
+			succeeded = succeeded && found && string(kv.Value) == string(compare.Value)
+		}
+	}
+	operations := request.Success
+	if !succeeded {
+		operations = request.Failure
+	}
+	response := &etcd3TxnResponse{Succeeded: succeeded}
+	r.Revision++
+	for _, operation := range operations {
+		var result struct {
+			ResponseRange *etcd3RangeResponse `json:"response_range"`
+		}
+		switch {
+		case operation.RequestPut != nil:
+			r.Put(operation.RequestPut)
+		case operation.RequestDeleteRange != nil:
+			r.DeleteRange(operation.RequestDeleteRange)
+		case operation.RequestRange != nil:
+			result.ResponseRange = r.Range(operation.RequestRange)
+		}
+		response.Responses = append(response.Responses, result)
+	}
+	response.Header = r.Header()
+	return response
+}
+
+func (r *FakeEtcd3) Notify(event *etcd3Event) {
+	r.Events = append(r.Events, event)
+	close(r.Changed)
+	r.Changed = make(chan struct{})
+}
+
+/* Stream the changes in the range from the start revision, until the client goes away */
+func (r *FakeEtcd3) Watch(writer http.ResponseWriter, request *http.Request, body *fakeEtcd3Request) {
+	create := body.CreateRequest
+	next := int64(create.StartRevision)
+	encoder := json.NewEncoder(writer)
+	for {
+		r.Lock()
+		var update etcd3WatchResponse
+		update.Result.Header = r.Header()
+		if next > 0 && next <= r.Compacted {
+			update.Result.CompactRevision = etcd3Int(r.Compacted)
+			update.Result.Canceled = true
+			r.Unlock()
+			encoder.Encode(update)
+			return
+		}
+		for _, event := range r.Events {
+			if int64(event.Kv.ModRevision) >= next && InRange(event.Kv.Key, create.Key, create.RangeEnd) {
+				update.Result.Events = append(update.Result.Events, event)
+			}
+		}
+		next = r.Revision + 1
+		changed, shutdown := r.Changed, r.Shutdown
+		r.Unlock()
+		if err := encoder.Encode(update); err != nil {
+			return
+		}
+		writer.(http.Flusher).Flush()
+		select {
+		case <-changed:
+		case <-shutdown:
+			return
+		case <-request.Context().Done():
+			return
+		}
+	}
+}
+
+/* No range end is the key alone, a range end of \x00 is every key from the start */
+func InRange(key, start, end []byte) bool {
+	if len(end) == 0 {
+		return string(key) == string(start)
+	}
+	return string(key) >= string(start) && (string(end) == "\x00" || string(key) < string(end))
+}
+
+func TestEtcd3GetAndList(t *testing.T) {
+	store := NewTestEtcd3(t)
+	ctx := context.Background()
+	for _, key := range []string{"/app/a", "/app/db/url", "/application"} {
+		if err := store.Set(ctx, key, "value"); err != nil {
+			t.Fatalf("failed to set the key: %s, error: %s", key, err)
+		}
+	}
+	tests := []struct {
+		Key       string
+		Directory bool
+		Error     error
+	}{
+		{"/app", true, nil},
+		{"/app/a", false, nil},
+		{"/app/db", true, nil},
+		{"/app/missing", false, NodeNotFoundErr},
+	}
+	for _, test := range tests {
+		node, err := store.Get(ctx, test.Key)
+		if err != test.Error {
+			t.Errorf("key: %s, expected: %v, got: %v", test.Key, test.Error, err)
+			continue
+		}
+		if err == nil && node.IsDir() != test.Directory {
+			t.Errorf("key: %s, expected directory: %t, got: %t", test.Key, test.Directory, node.IsDir())
+		}
+	}
+	expected := []string{"/app/a", "/app/db"}
+	if names := ListNames(t, store, "/app"); !EqualStrings(names, expected) {
+		t.Errorf("expected the listing: %v, got: %v", expected, names)
+	}
+}
+
+func TestEtcd3CompareAndSwap(t *testing.T) {
+	store := NewTestEtcd3(t)
+	ctx := context.Background()
+	store.Set(ctx, "/key", "first")
+	node, _ := store.Get(ctx, "/key")
+	store.Set(ctx, "/key", "second")
+	if err := store.CompareAndSwap(ctx, "/key", node.Value, node.Index, "third"); err != CompareFailedErr {
+		t.Errorf("expected the compare to fail on a stale revision, got: %v", err)
+	}
+	node, _ = store.Get(ctx, "/key")
+	if err := store.CompareAndSwap(ctx, "/key", node.Value, node.Index, "third"); err != nil {
+		t.Errorf("failed to swap the key, error: %s", err)
+	}
+}
+
+/* A watch from a compacted revision resyncs and sends the keys lost in the gap as deletes */
+func TestEtcd3ResyncAfterCompaction(t *testing.T) {
+	store := NewTestEtcd3(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store.Set(ctx, "/app/a", "a")
+	store.Set(ctx, "/app/b", "b")
+	snapshot, revision, err := store.Snapshot(ctx, "/app")
+	if err != nil {
+		t.Fatalf("failed to snapshot the prefix, error: %s", err)
+	}
+	/* step: the changes in the gap */
+	store.Delete(ctx, "/app/b")
+	store.Set(ctx, "/app/c", "c")
+	_, compact, _ := store.Snapshot(ctx, "/")
+	if err := store.Request(ctx, "kv/compaction", map[string]interface{}{"revision": strconv.FormatInt(compact, 10)}, nil); err != nil {
+		t.Fatalf("failed to compact, error: %s", err)
+	}
+	changes := make(chan NodeChange, 10)
+	if err := store.WatchStream(ctx, "/app", &revision, snapshot, changes); err != Etcd3CompactedErr {
+		t.Fatalf("expected the revision to have been compacted, got: %v", err)
+	}
+	if err := store.Resync(ctx, "/app", &revision, snapshot, changes); err != nil {
+		t.Fatalf("failed to resync, error: %s", err)
+	}
+	close(changes)
+	received := make([]string, 0)
+	for change := range changes {
+		received = append(received, change.Node.Path+" "+change.Operation.String())
+	}
+	expected := []string{"/app/b deleted", "/app/c changed"}
+	if !EqualStrings(received, expected) {
+		t.Errorf("expected the changes: %v, got: %v", expected, received)
+	}
+	if _, found := snapshot["/app/b"]; found || len(snapshot) != 2 {
+		t.Errorf("expected the snapshot to be moved on, got: %v", snapshot)
+	}
+}
+
+func TestEtcd3GetSnapshotEvents(t *testing.T) {
+	store := new(Etcd3StoreClient)
+	previous := map[string]*Node{
+		"/app/a": {Path: "/app/a", Index: 1},
+		"/app/b": {Path: "/app/b", Index: 2},
+		"/app/c": {Path: "/app/c", Index: 3}}
+	current := map[string]*Node{
+		"/app/a": {Path: "/app/a", Index: 1},
+		"/app/c": {Path: "/app/c", Index: 5},
+		"/app/d": {Path: "/app/d", Index: 6}}
+	tests := []struct {
+		Path      string
+		Operation Action
+	}{
+		{"/app/b", DELETED},
+		{"/app/c", CHANGED},
+		{"/app/d", CHANGED},
+	}
+	events := store.GetSnapshotEvents(previous, current)
+	if len(events) != len(tests) {
+		t.Fatalf("expected %d changes, got: %v", len(tests), events)
+	}
+	for i, test := range tests {
+		if events[i].Node.Path != test.Path || events[i].Operation != test.Operation {
+			t.Errorf("change %d, expected: %s %s, got: %s %s", i, test.Path, test.Operation, events[i].Node.Path, events[i].Operation)
+		}
+	}
+}
+
+func TestEtcd3InvalidUrl(t *testing.T) {
+	for _, uri := range []string{"etcd://127.0.0.1:2379", "consul://127.0.0.1:2379"} {
+		location, _ := url.Parse(uri)
+		if _, err := NewEtcd3StoreClient(location); err != InvalidUrlErr {
+			t.Errorf("url: %s, expected an invalid url, got: %v", uri, err)
+		}
+	}
+}
+
+func TestEtcd3Delete(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		Keys   []string
+		Delete func(store *Etcd3StoreClient) error
+		Error  error
+		Left   []string
+	}{
+		{
+			Keys:   []string{"/app/a", "/app/b"},
+			Delete: func(store *Etcd3StoreClient) error { return store.Delete(ctx, "/app/a") },
+			Left:   []string{"/app/b"},
+		},
+		{
+			Keys:   []string{"/app/a"},
+			Delete: func(store *Etcd3StoreClient) error { return store.Delete(ctx, "/app/missing") },
+			Error:  NodeNotFoundErr,
+			Left:   []string{"/app/a"},
+		},
+		{
+			Keys:   []string{"/app/a", "/app/db/url", "/application"},
+			Delete: func(store *Etcd3StoreClient) error { return store.RemovePath(ctx, "/app") },
+			Left:   []string{"/application"},
+		},
+		{
+			Keys:   []string{"/app/a"},
+			Delete: func(store *Etcd3StoreClient) error { return store.RemovePath(ctx, "/missing") },
+			Error:  NodeNotFoundErr,
+			Left:   []string{"/app/a"},
+		},
+		{
+			Keys:   []string{"/app/a", "/app/b"},
+			Delete: func(store *Etcd3StoreClient) error { return store.CompareAndDelete(ctx, "/app/a", "other", 0) },
+			Error:  CompareFailedErr,
+			Left:   []string{"/app/a", "/app/b"},
+		},
+		{
+			Keys:   []string{"/app/a"},
+			Delete: func(store *Etcd3StoreClient) error { return store.CompareAndDelete(ctx, "/app/missing", "value", 0) },
+			Error:  NodeNotFoundErr,
+			Left:   []string{"/app/a"},
+		},
+	}
+	for i, test := range tests {
+		store := NewTestEtcd3(t)
+		for _, key := range test.Keys {
+			store.Set(ctx, key, "value")
+		}
+		if err := test.Delete(store); err != test.Error {
+			t.Errorf("test %d, expected the error: %v, got: %v", i, test.Error, err)
+		}
+		snapshot, _, _ := store.Snapshot(ctx, "/")
+		left := make([]string, 0)
+		for path := range snapshot {
+			left = append(left, path)
+		}
+		sort.Strings(left)
+		if !EqualStrings(left, test.Left) {
+			t.Errorf("test %d, expected the keys: %v, got: %v", i, test.Left, left)
+		}
+	}
+}
+
+func TestEtcd3Watch(t *testing.T) {
+	store := NewTestEtcd3(t)
+	ctx := context.Background()
+	store.Set(ctx, "/app/a", "a")
+	changes, cancel, err := store.Watch(ctx, "/app")
+	if err != nil {
+		t.Fatalf("failed to watch the prefix, error: %s", err)
+	}
+	defer cancel()
+	/* step: the watch has to be in place before the changes are made */
+	time.Sleep(100 * time.Millisecond)
+	store.Set(ctx, "/application", "ignored")
+	store.Set(ctx, "/app/b", "b")
+	store.Delete(ctx, "/app/a")
+	expected := []string{"/app/b changed", "/app/a deleted"}
+	for _, change := range expected {
+		select {
+		case received := <-changes:
+			if value := received.Node.Path + " " + received.Operation.String(); value != change {
+				t.Errorf("expected the change: %s, got: %s", change, value)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected the change: %s", change)
+		}
+	}
+}
+
+func TestEtcd3SetWithTTL(t *testing.T) {
+	store := NewTestEtcd3(t)
+	ctx := context.Background()
+	if err := store.SetWithTTL(ctx, "/session", "token", 1500*time.Millisecond); err != nil {
+		t.Fatalf("failed to set the key, error: %s", err)
+	}
+	node, err := store.Get(ctx, "/session")
+	if err != nil || node.TTL <= 0 || node.TTL > 2*time.Second || node.Metadata["etcd.lease"] == "" {
+		t.Fatalf("expected the key to be on a lease, got: %v, error: %v", node, err)
+	}
+	/* step: setting the key without a ttl takes it off the lease */
+	store.Set(ctx, "/session", "token")
+	if node, err := store.Get(ctx, "/session"); err != nil || node.TTL != 0 {
+		t.Errorf("expected the key to be off the lease, got: %v, error: %v", node, err)
+	}
+}
+
+func TestEtcd3Authenticate(t *testing.T) {
+	ctx := context.Background()
+	store, _ := NewTestFakeEtcd3(t, "root:secret@")
+	if err := store.Set(ctx, "/key", "value"); err != nil {
+		t.Errorf("expected to be authenticated, error: %s", err)
+	}
+	/* step: the token expires and a request is refused, the next one authenticates again */
+	store.Token = "expired"
+	if _, err := store.Get(ctx, "/key"); err == nil {
+		t.Errorf("expected the expired token to be refused")
+	}
+	if node, err := store.Get(ctx, "/key"); err != nil || node.Value != "value" {
+		t.Errorf("expected to authenticate again, got: %v, error: %v", node, err)
+	}
+	store.Credentials = url.UserPassword("root", "wrong")
+	store.Token = ""
+	if _, err := store.Get(ctx, "/key"); err == nil {
+		t.Errorf("expected the wrong password to be refused")
+	}
+}
@@ -0,0 +1,337 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-etcd/etcd"
+)
+
+func TestEtcdCredentials(t *testing.T) {
+	tests := []struct {
+		Options  string
+		Username string
+		Password string
+	}{
+		{Options: "", Username: ""},
+		{Options: "user:secret@", Username: "user", Password: "secret"},
+		{Options: "?username=admin&password=pass%23word", Username: "admin", Password: "pass#word"},
+	}
+	for _, test := range tests {
+		var username, password string
+		var found bool
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			username, password, found = request.BasicAuth()
+			writer.Header().Set("X-Etcd-Index", "1")
+			writer.Write([]byte(`{"action":"get","node":{"key":"/key","value":"value","modifiedIndex":1,"createdIndex":1}}`))
+		}))
+		location, _ := url.Parse(server.URL)
+		var uri *url.URL
+		if test.Options != "" && test.Options[0] == '?' {
+			uri, _ = url.Parse("etcd://" + location.Host + test.Options)
+		} else {
+			uri, _ = url.Parse("etcd://" + test.Options + location.Host)
+		}
+		store, err := NewEtcdStoreClient(uri)
+		if err != nil {
+			t.Fatalf("failed to create the etcd store, error: %s", err)
+		}
+		if node, err := store.Get(context.Background(), "/key"); err != nil || node.Value != "value" {
+			t.Errorf("options: %s, expected the key, got: %v, error: %v", test.Options, node, err)
+		}
+		if found != (test.Username != "") || username != test.Username || password != test.Password {
+			t.Errorf("options: %s, expected the credentials: %s:%s, got: %s:%s", test.Options, test.Username, test.Password, username, password)
+		}
+		store.Close()
+		server.Close()
+	}
+}
+
+/* Enough of the v2 keys api of etcd to test the watches against; the keys are flat, there are no directories */
+type FakeEtcd struct {
+	sync.Mutex
+	/* the keys by path */
+	Keys map[string]*etcd.Node
+	/* the changes, in the order they were made */
+	Events []*etcd.Response
+	/* the current index */
+	Index uint64
+	/* the changes at or below the index have been cleared */
+	Cleared uint64
+	/* the indexes the watches have waited on */
+	Waits []uint64
+	/* closed and replaced on every change, so the watches wake up */
+	Changed chan bool
+}
+
+func NewTestFakeEtcd(t *testing.T) (*EtcdStoreClient, *FakeEtcd) {
+	fake := &FakeEtcd{Keys: make(map[string]*etcd.Node, 0), Index: 1, Changed: make(chan bool)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	location, _ := url.Parse(server.URL)
+	uri, _ := url.Parse("etcd://" + location.Host)
+	store, err := NewEtcdStoreClient(uri)
+	if err != nil {
+		t.Fatalf("failed to create the etcd store, error: %s", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store.(*EtcdStoreClient), fake
+}
+
+func (r *FakeEtcd) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	key := strings.TrimPrefix(request.URL.Path, "/v2/keys")
+	query := request.URL.Query()
+	r.Lock()
+	defer r.Unlock()
+	writer.Header().Set("X-Etcd-Index", strconv.FormatUint(r.Index, 10))
+	if request.Method != "GET" {
+		writer.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if query.Get("wait") != "true" {
+		r.Get(writer, key)
+		return
+	}
+	index, _ := strconv.ParseUint(query.Get("waitIndex"), 10, 64)
+	r.Waits = append(r.Waits, index)
+	for {
+		if index <= r.Cleared {
+			r.Error(writer, ETCD_ERROR_INDEX_CLEARED)
+			return
+		}
+		for _, event := range r.Events {
+			if event.Node.ModifiedIndex >= index && (event.Node.Key == key || strings.HasPrefix(event.Node.Key, key+"/")) {
+				json.NewEncoder(writer).Encode(event)
+				return
+			}
+		}
+		changed := r.Changed
+		r.Unlock()
+		select {
+		case <-changed:
+			r.Lock()
+		case <-request.Context().Done():
+			r.Lock()
+			return
+		}
+	}
+}
+
+func (r *FakeEtcd) Get(writer http.ResponseWriter, key string) {
+	if node, found := r.Keys[key]; found {
+		json.NewEncoder(writer).Encode(&etcd.Response{Action: "get", Node: node})
+		return
+	}
+	directory := &etcd.Node{Key: key, Dir: true}
+	for path, node := range r.Keys {
+		if strings.HasPrefix(path, strings.TrimSuffix(key, "/")+"/") {
+			directory.Nodes = append(directory.Nodes, node)
+		}
+	}
+	if len(directory.Nodes) == 0 {
+		r.Error(writer, ETCD_ERROR_KEY_NOT_FOUND)
+		return
+	}
+	json.NewEncoder(writer).Encode(&etcd.Response{Action: "get", Node: directory})
+}
+
+func (r *FakeEtcd) Error(writer http.ResponseWriter, code int) {
+	status := http.StatusNotFound
+	if code == ETCD_ERROR_INDEX_CLEARED {
+		status = http.StatusBadRequest
+	}
+	writer.WriteHeader(status)
+	json.NewEncoder(writer).Encode(&etcd.EtcdError{ErrorCode: code, Message: "error", Index: r.Index})
+}
+
+/* Make the change; the caller holds the lock and notifies the watches once done */
+func (r *FakeEtcd) Change(action, key, value string) {
+	r.Index++
+	node := &etcd.Node{Key: key, Value: value, ModifiedIndex: r.Index, CreatedIndex: r.Index}
+	if action == "delete" {
+		delete(r.Keys, key)
+	} else {
+		r.Keys[key] = node
+	}
+	r.Events = append(r.Events, &etcd.Response{Action: action, Node: node})
+}
+
+func (r *FakeEtcd) Notify() {
+	r.Lock()
+	defer r.Unlock()
+	close(r.Changed)
+	r.Changed = make(chan bool)
+}
+
+/* Wait for the watches to have waited the number of times */
+func (r *FakeEtcd) WaitForWatches(t *testing.T, count int) []uint64 {
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		r.Lock()
+		waits := append([]uint64{}, r.Waits...)
+		r.Unlock()
+		if len(waits) >= count {
+			return waits
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d watches, got: %v", count, waits)
+		}
+	}
+}
+
+/* The watch resumes from the index after the last change, so nothing made between the watches is lost */
+func TestEtcdWatchResumes(t *testing.T) {
+	store, fake := NewTestFakeEtcd(t)
+	fake.Lock()
+	fake.Change("set", "/app/a", "a")
+	fake.Unlock()
+	changes, cancel, err := store.Watch(context.Background(), "/app")
+	if err != nil {
+		t.Fatalf("failed to watch the key, error: %s", err)
+	}
+	defer cancel()
+	if waits := fake.WaitForWatches(t, 1); waits[0] != 3 {
+		t.Errorf("expected the watch to start after the snapshot at index: 3, got: %v", waits)
+	}
+	/* step: the changes are made together, the second before the watch is back in place */
+	fake.Lock()
+	fake.Change("set", "/app/b", "b")
+	fake.Change("set", "/other", "other")
+	fake.Change("compareAndSwap", "/app/a", "changed")
+	fake.Change("expire", "/app/b", "")
+	fake.Unlock()
+	fake.Notify()
+	expected := []string{"/app/b changed", "/app/a changed", "/app/b deleted"}
+	if received := WatchedChanges(changes, len(expected), 5*time.Second); !EqualStrings(received, expected) {
+		t.Errorf("expected the changes: %v, got: %v", expected, received)
+	}
+	if waits := fake.WaitForWatches(t, 4); !reflect.DeepEqual(waits[:4], []uint64{3, 4, 6, 7}) {
+		t.Errorf("expected the watches to resume after each change, got: %v", waits)
+	}
+}
+
+/* When the index has been cleared the watch resyncs and sends what changed in the meantime */
+func TestEtcdWatchResyncsAfterIndexCleared(t *testing.T) {
+	store, fake := NewTestFakeEtcd(t)
+	fake.Lock()
+	fake.Change("set", "/app/a", "a")
+	fake.Change("set", "/app/b", "b")
+	fake.Unlock()
+	changes, cancel, err := store.Watch(context.Background(), "/app")
+	if err != nil {
+		t.Fatalf("failed to watch the key, error: %s", err)
+	}
+	defer cancel()
+	fake.WaitForWatches(t, 1)
+	fake.Lock()
+	fake.Change("set", "/app/a", "changed")
+	fake.Change("delete", "/app/b", "")
+	fake.Change("set", "/app/c", "c")
+	fake.Cleared = fake.Index
+	fake.Events = nil
+	fake.Unlock()
+	fake.Notify()
+	expected := []string{"/app/a changed", "/app/b deleted", "/app/c changed"}
+	received := WatchedChanges(changes, len(expected), 5*time.Second)
+	sort.Strings(received)
+	if !EqualStrings(received, expected) {
+		t.Errorf("expected the changes: %v, got: %v", expected, received)
+	}
+	/* step: the watch carries on from the index of the resync */
+	if waits := fake.WaitForWatches(t, 2); waits[1] != fake.Cleared+1 {
+		t.Errorf("expected the watch to resume from: %d, got: %v", fake.Cleared+1, waits)
+	}
+}
+
+func TestEtcdGetNodeEvents(t *testing.T) {
+	tests := []struct {
+		Action  string
+		Key     string
+		Changes []string
+		Left    []string
+	}{
+		{"set", "/app/a", []string{"/app/a changed"}, []string{"/app", "/app/a", "/app/db", "/app/db/host"}},
+		{"update", "/app/new", []string{"/app/new changed"}, []string{"/app", "/app/a", "/app/db", "/app/db/host", "/app/new"}},
+		{"create", "/app/new", []string{"/app/new changed"}, []string{"/app", "/app/a", "/app/db", "/app/db/host", "/app/new"}},
+		{"compareAndSwap", "/app/a", []string{"/app/a changed"}, []string{"/app", "/app/a", "/app/db", "/app/db/host"}},
+		{"delete", "/app/a", []string{"/app/a deleted"}, []string{"/app", "/app/db", "/app/db/host"}},
+		{"expire", "/app/a", []string{"/app/a deleted"}, []string{"/app", "/app/db", "/app/db/host"}},
+		{"compareAndDelete", "/app/a", []string{"/app/a deleted"}, []string{"/app", "/app/db", "/app/db/host"}},
+		{"delete", "/app/db", []string{"/app/db deleted", "/app/db/host deleted"}, []string{"/app", "/app/a"}},
+		{"get", "/app/a", []string{"/app/a unknown"}, []string{"/app", "/app/a", "/app/db", "/app/db/host"}},
+	}
+	store := &EtcdStoreClient{}
+	for _, test := range tests {
+		snapshot := map[string]*Node{
+			"/app":         {Path: "/app", Directory: true},
+			"/app/a":       {Path: "/app/a", Value: "a"},
+			"/app/db":      {Path: "/app/db", Directory: true},
+			"/app/db/host": {Path: "/app/db/host", Value: "127.0.0.1"}}
+		received := make([]string, 0)
+		for _, change := range store.GetNodeEvents(snapshot, &etcd.Response{Action: test.Action, Node: &etcd.Node{Key: test.Key, ModifiedIndex: 10}}) {
+			received = append(received, change.Node.Path+" "+change.Operation.String())
+		}
+		sort.Strings(received)
+		if !EqualStrings(received, test.Changes) {
+			t.Errorf("action: %s, key: %s, expected the changes: %v, got: %v", test.Action, test.Key, test.Changes, received)
+		}
+		left := make([]string, 0)
+		for path := range snapshot {
+			left = append(left, path)
+		}
+		sort.Strings(left)
+		if !EqualStrings(left, test.Left) {
+			t.Errorf("action: %s, key: %s, expected the snapshot: %v, got: %v", test.Action, test.Key, test.Left, left)
+		}
+	}
+}
+
+func TestEtcdGetSnapshotEvents(t *testing.T) {
+	node := func(path string, index uint64) *Node {
+		return &Node{Path: path, Index: index}
+	}
+	tests := []struct {
+		Previous map[string]*Node
+		Current  map[string]*Node
+		Changes  []string
+	}{
+		{map[string]*Node{"/a": node("/a", 1)}, map[string]*Node{"/a": node("/a", 1)}, []string{}},
+		{map[string]*Node{"/a": node("/a", 1)}, map[string]*Node{"/a": node("/a", 2)}, []string{"/a changed"}},
+		{map[string]*Node{"/a": node("/a", 1)}, map[string]*Node{"/a": node("/a", 1), "/b": node("/b", 3)}, []string{"/b changed"}},
+		{map[string]*Node{"/a": node("/a", 1), "/b": node("/b", 3)}, map[string]*Node{}, []string{"/a deleted", "/b deleted"}},
+		{map[string]*Node{}, map[string]*Node{"/a": node("/a", 1)}, []string{"/a changed"}},
+	}
+	store := &EtcdStoreClient{}
+	for i, test := range tests {
+		received := make([]string, 0)
+		for _, change := range store.GetSnapshotEvents(test.Previous, test.Current) {
+			received = append(received, change.Node.Path+" "+change.Operation.String())
+		}
+		sort.Strings(received)
+		if !EqualStrings(received, test.Changes) {
+			t.Errorf("test %d, expected the changes: %v, got: %v", i, test.Changes, received)
+		}
+	}
+}
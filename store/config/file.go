@@ -0,0 +1,293 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+/*
+The file store is a K/V store backed by a plain directory tree; keys are files and
+directories are directories. It's mainly here for testing and air-gapped hosts, but
+it also acts as the reference behaviour for the other backends. The index of a key is
+the modification time of the file in nanoseconds
+*/
+type FileStoreClient struct {
+	/* the base directory of the store */
+	Root string
+	/* the lock serializing the compare and swaps; note this only covers this process */
+	CompareLock sync.Mutex
+}
+
+func NewFileStoreClient(uri *url.URL) (KVStore, error) {
+	glog.Infof("Creating a File K/V Store, url: %s", uri)
+	if uri.Scheme != "file" {
+		glog.Errorf("Invalid url: %s, must start with file", uri)
+		return nil, InvalidUrlErr
+	}
+	/* step: we take the root from the path, i.e. file:///var/lib/config */
+	root := filepath.Clean(uri.Host + uri.Path)
+	if !filepath.IsAbs(root) {
+		glog.Errorf("Invalid url: %s, the directory must be an absolute path", uri)
+		return nil, InvalidUrlErr
+	}
+	if stat, err := os.Stat(root); err != nil {
+		glog.Errorf("Failed to stat the base directory: %s, error: %s", root, err)
+		return nil, err
+	} else if !stat.IsDir() {
+		glog.Errorf("The base directory: %s is not a directory", root)
+		return nil, InvalidDirectoryErr
+	}
+	store := new(FileStoreClient)
+	store.Root = root
+	return store, nil
+}
+
+func (r *FileStoreClient) Get(ctx context.Context, key string) (*Node, error) {
+	Verbose("Get() key: %s", key)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	filename := r.FilePath(key)
+	stat, err := os.Stat(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, NodeNotFoundErr
+		}
+		glog.Errorf("Failed to get the key: %s, error: %s", key, err)
+		return nil, err
+	}
+	return r.CreateNode(filename, stat)
+}
+
+func (r *FileStoreClient) Set(ctx context.Context, key string, value string) error {
+	Verbose("Set() key: %s, value: %s", key, Redact(key, value))
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	filename := r.FilePath(key)
+	/* step: make sure the parent directory exists, same as etcd would */
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		glog.Errorf("Set() failed to create the parent of key: %s, error: %s", key, err)
+		return err
+	}
+	/* step: write to a temporary file and rename, so readers never see a partial value */
+	temp, err := ioutil.TempFile(filepath.Dir(filename), "."+filepath.Base(filename))
+	if err != nil {
+		glog.Errorf("Set() failed to create a temporary file for key: %s, error: %s", key, err)
+		return err
+	}
+	if _, err := temp.WriteString(value); err != nil {
+		temp.Close()
+		os.Remove(temp.Name())
+		glog.Errorf("Set() failed to write the key: %s, error: %s", key, err)
+		return err
+	}
+	temp.Close()
+	if err := os.Rename(temp.Name(), filename); err != nil {
+		os.Remove(temp.Name())
+		glog.Errorf("Set() failed to set the key: %s, error: %s", key, err)
+		return err
+	}
+	return nil
+}
+
+/* Files don't expire, so we only accept a ttl of zero */
+func (r *FileStoreClient) SetWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error {
+	if ttl > 0 {
+		glog.Errorf("SetWithTTL() key: %s, the file store does not support a ttl", key)
+		return TTLNotSupportedErr
+	}
+	return r.Set(ctx, key, value)
+}
+
+func (r *FileStoreClient) Delete(ctx context.Context, key string) error {
+	Verbose("Delete() deleting the key: %s", key)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	filename := r.FilePath(key)
+	if stat, err := os.Stat(filename); err != nil {
+		glog.Errorf("Delete() failed to delete key: %s, error: %s", key, err)
+		return err
+	} else if stat.IsDir() {
+		glog.Errorf("Delete() key: %s is a directory", key)
+		return InvalidDirectoryErr
+	}
+	if err := os.Remove(filename); err != nil {
+		glog.Errorf("Delete() failed to delete key: %s, error: %s", key, err)
+		return err
+	}
+	return nil
+}
+
+func (r *FileStoreClient) CompareAndSwapWithTTL(ctx context.Context, key string, oldValue string, oldIndex uint64, value string, ttl time.Duration) error {
+	if ttl > 0 {
+		glog.Errorf("CompareAndSwap() key: %s, the file store does not support a ttl", key)
+		return TTLNotSupportedErr
+	}
+	return r.CompareAndSwap(ctx, key, oldValue, oldIndex, value)
+}
+
+func (r *FileStoreClient) CompareAndSwap(ctx context.Context, key string, oldValue string, oldIndex uint64, value string) error {
+	Verbose("CompareAndSwap() key: %s, index: %d, value: %s", key, oldIndex, Redact(key, value))
+	r.CompareLock.Lock()
+	defer r.CompareLock.Unlock()
+	if err := r.Compare(ctx, key, oldValue, oldIndex); err != nil {
+		return err
+	}
+	return r.Set(ctx, key, value)
+}
+
+func (r *FileStoreClient) CompareAndDelete(ctx context.Context, key string, oldValue string, oldIndex uint64) error {
+	Verbose("CompareAndDelete() key: %s, index: %d", key, oldIndex)
+	r.CompareLock.Lock()
+	defer r.CompareLock.Unlock()
+	if err := r.Compare(ctx, key, oldValue, oldIndex); err != nil {
+		return err
+	}
+	return r.Delete(ctx, key)
+}
+
+/* Check the key is a file and is unchanged; the caller must be holding the compare lock */
+func (r *FileStoreClient) Compare(ctx context.Context, key string, oldValue string, oldIndex uint64) error {
+	node, err := r.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if node.IsDir() {
+		glog.Errorf("Compare() key: %s is a directory", key)
+		return InvalidDirectoryErr
+	}
+	if !node.Unchanged(oldValue, oldIndex) {
+		return CompareFailedErr
+	}
+	return nil
+}
+
+/* There's nothing held open between the calls */
+func (r *FileStoreClient) Close() error {
+	return nil
+}
+
+/* There are no transactions in a directory tree, so they're emulated */
+func (r *FileStoreClient) Txn(ctx context.Context, operations []*Operation) error {
+	Verbose("Txn() operations: %d", len(operations))
+	return EmulateTxn(ctx, r, operations)
+}
+
+func (r *FileStoreClient) RemovePath(ctx context.Context, path string) error {
+	Verbose("RemovePath() deleting the path: %s", path)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	filename := r.FilePath(path)
+	if filename == r.Root {
+		glog.Errorf("RemovePath() refusing to remove the root of the store")
+		return InvalidDirectoryErr
+	}
+	if err := os.RemoveAll(filename); err != nil {
+		glog.Errorf("RemovePath() failed to remove path: %s, error: %s", path, err)
+		return err
+	}
+	return nil
+}
+
+func (r *FileStoreClient) Mkdir(ctx context.Context, path string) error {
+	Verbose("Mkdir() path: %s", path)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(r.FilePath(path), 0755); err != nil {
+		glog.Errorf("Mkdir() failed to create directory node: %s, error: %s", path, err)
+		return err
+	}
+	return nil
+}
+
+func (r *FileStoreClient) List(ctx context.Context, path string) ([]*Node, error) {
+	Verbose("List() path: %s", path)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	directory := r.FilePath(path)
+	if stat, err := os.Stat(directory); err != nil {
+		glog.Errorf("List() failed to get path: %s, error: %s", path, err)
+		return nil, err
+	} else if !stat.IsDir() {
+		glog.Errorf("List() path: %s is not a directory node", path)
+		return nil, InvalidDirectoryErr
+	}
+	files, err := ioutil.ReadDir(directory)
+	if err != nil {
+		glog.Errorf("List() failed to read the directory: %s, error: %s", path, err)
+		return nil, err
+	}
+	list := make([]*Node, 0)
+	for _, stat := range files {
+		/* step: skip any hidden or temporary files */
+		if strings.HasPrefix(stat.Name(), ".") {
+			continue
+		}
+		node, err := r.CreateNode(filepath.Join(directory, stat.Name()), stat)
+		if err != nil {
+			glog.Errorf("List() failed to read the node: %s, error: %s", stat.Name(), err)
+			continue
+		}
+		list = append(list, node)
+	}
+	return list, nil
+}
+
+/*
+Convert the key into a filename under the root; the key is cleaned against / first
+so it's not possible to walk out of the root with ../
+*/
+func (r *FileStoreClient) FilePath(key string) string {
+	return filepath.Join(r.Root, filepath.Clean("/"+key))
+}
+
+/* Convert the filename back into a key, i.e. /var/lib/config/a/b => /a/b */
+func (r *FileStoreClient) KeyPath(filename string) string {
+	key := strings.TrimPrefix(filename, r.Root)
+	if !strings.HasPrefix(key, "/") {
+		key = "/" + key
+	}
+	return key
+}
+
+func (r *FileStoreClient) CreateNode(filename string, stat os.FileInfo) (*Node, error) {
+	node := &Node{}
+	node.Path = r.KeyPath(filename)
+	node.Index = uint64(stat.ModTime().UnixNano())
+	if stat.IsDir() {
+		node.Directory = true
+		return node, nil
+	}
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	node.Value = string(content)
+	return node, nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"github.com/golang/glog"
+)
+
+const INOTIFY_WATCH_MASK = syscall.IN_CREATE | syscall.IN_CLOSE_WRITE | syscall.IN_DELETE |
+	syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO
+
+/*
+The watch is implemented with inotify; we place a watch on every directory under
+the key and add new ones as directories are created
+*/
+func (r *FileStoreClient) Watch(ctx context.Context, key string) (<-chan NodeChange, context.CancelFunc, error) {
+	Verbose("Watch() key: %s", key)
+	filename := r.FilePath(key)
+	stat, err := os.Stat(filename)
+	if err != nil {
+		glog.Errorf("Watch() failed to stat the key: %s, error: %s", key, err)
+		return nil, nil, err
+	}
+	/* step: if the key is a file, we have to watch the parent directory */
+	directory := filename
+	if !stat.IsDir() {
+		directory = filepath.Dir(filename)
+	}
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		glog.Errorf("Watch() failed to create an inotify instance, error: %s", err)
+		return nil, nil, err
+	}
+	/* step: wrap the descriptor so reads go through the poller and a close unblocks them */
+	inotify := os.NewFile(uintptr(fd), "inotify")
+	watches := make(map[int32]string, 0)
+	if err := r.AddWatches(fd, directory, watches); err != nil {
+		inotify.Close()
+		return nil, nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	updateChannel := make(chan NodeChange)
+	go func() {
+		/* step: wait for the watch to be cancelled */
+		<-ctx.Done()
+		glog.V(3).Infof("Watch() killing off the watch on key: %s", key)
+		inotify.Close()
+	}()
+	go func() {
+		defer close(updateChannel)
+		buffer := make([]byte, 64*1024)
+		for {
+			length, err := inotify.Read(buffer)
+			if err != nil {
+				if ctx.Err() != nil {
+					glog.V(3).Infof("Watch() exitting the watch on key: %s", key)
+				} else {
+					glog.Errorf("Watch() error reading the inotify events for key: %s, error: %s", key, err)
+				}
+				return
+			}
+			for offset := 0; offset+syscall.SizeofInotifyEvent <= length; {
+				event := (*syscall.InotifyEvent)(unsafe.Pointer(&buffer[offset]))
+				name := string(buffer[offset+syscall.SizeofInotifyEvent : offset+syscall.SizeofInotifyEvent+int(event.Len)])
+				offset += syscall.SizeofInotifyEvent + int(event.Len)
+				/* step: the watch has been removed, i.e. the directory is gone */
+				if event.Mask&syscall.IN_IGNORED != 0 {
+					delete(watches, event.Wd)
+					continue
+				}
+				name = strings.TrimRight(name, "\x00")
+				parent, found := watches[event.Wd]
+				if !found || name == "" || strings.HasPrefix(name, ".") {
+					continue
+				}
+				path := filepath.Join(parent, name)
+				if path != filename && !strings.HasPrefix(path, filename+"/") {
+					continue
+				}
+				for _, change := range r.GetNodeEvents(fd, path, event.Mask, watches) {
+					/* step: pass the change upstream */
+					Verbose("Watch() sending the change for key: %s upstream", change.Node.Path)
+					select {
+					case updateChannel <- change:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+	return updateChannel, cancel, nil
+}
+
+/* Walk the directory and add a watch to it and any directories under it */
+func (r *FileStoreClient) AddWatches(fd int, directory string, watches map[int32]string) error {
+	return filepath.Walk(directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		wd, err := syscall.InotifyAddWatch(fd, path, INOTIFY_WATCH_MASK)
+		if err != nil {
+			glog.Errorf("Failed to add a watch on directory: %s, error: %s", path, err)
+			return err
+		}
+		watches[int32(wd)] = path
+		return nil
+	})
+}
+
+/*
+Convert the inotify event into node changes; when a directory is created we have to
+watch it and emit changes for anything which was written into it before the watch was
+in place
+*/
+func (r *FileStoreClient) GetNodeEvents(fd int, path string, mask uint32, watches map[int32]string) []NodeChange {
+	events := make([]NodeChange, 0)
+	directory := mask&syscall.IN_ISDIR != 0
+	switch {
+	case mask&(syscall.IN_DELETE|syscall.IN_MOVED_FROM) != 0:
+		events = append(events, NodeChange{Node{Path: r.KeyPath(path), Directory: directory}, DELETED})
+	case directory && mask&(syscall.IN_CREATE|syscall.IN_MOVED_TO) != 0:
+		if err := r.AddWatches(fd, path, watches); err != nil {
+			glog.Errorf("Failed to watch the new directory: %s, error: %s", path, err)
+		}
+		filepath.Walk(path, func(filename string, info os.FileInfo, err error) error {
+			if err != nil || strings.HasPrefix(info.Name(), ".") {
+				return nil
+			}
+			if node, err := r.CreateNode(filename, info); err == nil {
+				events = append(events, NodeChange{*node, CHANGED})
+			}
+			return nil
+		})
+	case !directory && mask&(syscall.IN_CLOSE_WRITE|syscall.IN_MOVED_TO) != 0:
+		if node, err := r.Get(context.Background(), r.KeyPath(path)); err == nil {
+			events = append(events, NodeChange{*node, CHANGED})
+		}
+	}
+	return events
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"testing"
+	"time"
+)
+
+/* The changes the watch sends within the timeout, as "<path> <operation>" */
+func WatchedChanges(changes <-chan NodeChange, count int, timeout time.Duration) []string {
+	received := make([]string, 0)
+	for len(received) < count {
+		select {
+		case change, open := <-changes:
+			if !open {
+				return received
+			}
+			received = append(received, change.Node.Path+" "+change.Operation.String())
+		case <-time.After(timeout):
+			return received
+		}
+	}
+	return received
+}
+
+func TestFileStoreWatch(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		Name    string
+		Key     string
+		Change  func(store *FileStoreClient)
+		Changes []string
+	}{
+		{
+			Name:    "set",
+			Key:     "/app",
+			Change:  func(store *FileStoreClient) { store.Set(ctx, "/app/b", "b") },
+			Changes: []string{"/app/b changed"},
+		},
+		{
+			Name:    "update",
+			Key:     "/app",
+			Change:  func(store *FileStoreClient) { store.Set(ctx, "/app/a", "changed") },
+			Changes: []string{"/app/a changed"},
+		},
+		{
+			Name:    "delete",
+			Key:     "/app",
+			Change:  func(store *FileStoreClient) { store.Delete(ctx, "/app/a") },
+			Changes: []string{"/app/a deleted"},
+		},
+		{
+			Name:    "new directory",
+			Key:     "/app",
+			Change:  func(store *FileStoreClient) { store.Set(ctx, "/app/db/host", "127.0.0.1") },
+			Changes: []string{"/app/db changed", "/app/db/host changed"},
+		},
+		{
+			Name:    "remove directory",
+			Key:     "/app",
+			Change:  func(store *FileStoreClient) { store.RemovePath(ctx, "/app/sub") },
+			Changes: []string{"/app/sub/c deleted", "/app/sub deleted"},
+		},
+		{
+			Name:    "single key",
+			Key:     "/app/a",
+			Change:  func(store *FileStoreClient) { store.Set(ctx, "/app/b", "b"); store.Set(ctx, "/app/a", "changed") },
+			Changes: []string{"/app/a changed"},
+		},
+		{
+			Name:    "outside the key",
+			Key:     "/app/sub",
+			Change:  func(store *FileStoreClient) { store.Set(ctx, "/other", "other"); store.Set(ctx, "/app/b", "b") },
+			Changes: []string{},
+		},
+	}
+	for _, test := range tests {
+		store := NewTestFileStore(t, map[string]string{"/app/a": "a", "/app/sub/c": "c"})
+		changes, cancel, err := store.Watch(ctx, test.Key)
+		if err != nil {
+			t.Fatalf("%s: failed to watch the key, error: %s", test.Name, err)
+		}
+		test.Change(store)
+		received := WatchedChanges(changes, len(test.Changes)+1, 500*time.Millisecond)
+		cancel()
+		if !EqualStrings(received, test.Changes) {
+			t.Errorf("%s: expected the changes: %v, got: %v", test.Name, test.Changes, received)
+		}
+	}
+}
+
+/* The watch is closed once it's cancelled */
+func TestFileStoreWatchCancel(t *testing.T) {
+	store := NewTestFileStore(t, map[string]string{"/app/a": "a"})
+	changes, cancel, err := store.Watch(context.Background(), "/app")
+	if err != nil {
+		t.Fatalf("failed to watch the key, error: %s", err)
+	}
+	cancel()
+	select {
+	case _, open := <-changes:
+		if open {
+			t.Errorf("expected no changes once the watch is cancelled")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the watch to be closed")
+	}
+	if _, _, err := store.Watch(context.Background(), "/missing"); err == nil {
+		t.Errorf("expected the watch of a missing key to fail")
+	}
+}
+
+/* A directory moved into the tree is watched, and everything already in it is sent as changed */
+func TestFileStoreGetNodeEvents(t *testing.T) {
+	store := NewTestFileStore(t, map[string]string{"/app/a": "a", "/app/db/host": "127.0.0.1", "/app/db/.partial": "ignored"})
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC)
+	if err != nil {
+		t.Fatalf("failed to create an inotify instance, error: %s", err)
+	}
+	defer syscall.Close(fd)
+	tests := []struct {
+		Path    string
+		Mask    uint32
+		Changes []string
+		Watched bool
+	}{
+		{Path: "/app/a", Mask: syscall.IN_CLOSE_WRITE, Changes: []string{"/app/a changed"}},
+		{Path: "/app/a", Mask: syscall.IN_MOVED_TO, Changes: []string{"/app/a changed"}},
+		{Path: "/app/gone", Mask: syscall.IN_CLOSE_WRITE, Changes: []string{}},
+		{Path: "/app/a", Mask: syscall.IN_DELETE, Changes: []string{"/app/a deleted"}},
+		{Path: "/app/a", Mask: syscall.IN_MOVED_FROM, Changes: []string{"/app/a deleted"}},
+		{Path: "/app/db", Mask: syscall.IN_DELETE | syscall.IN_ISDIR, Changes: []string{"/app/db deleted"}},
+		{Path: "/app/db", Mask: syscall.IN_CREATE | syscall.IN_ISDIR, Changes: []string{"/app/db changed", "/app/db/host changed"}, Watched: true},
+		{Path: "/app/db", Mask: syscall.IN_MOVED_TO | syscall.IN_ISDIR, Changes: []string{"/app/db changed", "/app/db/host changed"}, Watched: true},
+		{Path: "/app/a", Mask: syscall.IN_CREATE, Changes: []string{}},
+	}
+	for _, test := range tests {
+		watches := make(map[int32]string, 0)
+		received := make([]string, 0)
+		for _, change := range store.GetNodeEvents(fd, store.FilePath(test.Path), test.Mask, watches) {
+			received = append(received, change.Node.Path+" "+change.Operation.String())
+		}
+		sort.Strings(received)
+		if !EqualStrings(received, test.Changes) {
+			t.Errorf("path: %s, mask: %x, expected the changes: %v, got: %v", test.Path, test.Mask, test.Changes, received)
+		}
+		if watched := len(watches) > 0; watched != test.Watched {
+			t.Errorf("path: %s, mask: %x, expected the directory to be watched: %t, got: %v", test.Path, test.Mask, test.Watched, watches)
+		}
+	}
+	/* step: a file written with the watch in place is read as it is now */
+	if err := ioutil.WriteFile(filepath.Join(store.Root, "app/a"), []byte("changed"), 0644); err != nil {
+		t.Fatalf("failed to write the file, error: %s", err)
+	}
+	if changes := store.GetNodeEvents(fd, store.FilePath("/app/a"), syscall.IN_CLOSE_WRITE, nil); len(changes) != 1 || changes[0].Node.Value != "changed" {
+		t.Errorf("expected the change to carry the value, got: %v", changes)
+	}
+}
@@ -0,0 +1,29 @@
+//go:build !linux
+// +build !linux
+
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"errors"
+)
+
+var WatchNotSupportedErr = errors.New("Watching a file store is only supported on linux")
+
+func (r *FileStoreClient) Watch(ctx context.Context, key string) (<-chan NodeChange, context.CancelFunc, error) {
+	Verbose("Watch() key: %s", key)
+	return nil, nil, WatchNotSupportedErr
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+/* A file store rooted at a temporary directory, holding the files given */
+func NewTestFileStore(t *testing.T, files map[string]string) *FileStoreClient {
+	root, err := ioutil.TempDir("", "filestore")
+	if err != nil {
+		t.Fatalf("failed to create the directory, error: %s", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(root) })
+	for name, content := range files {
+		filename := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatalf("failed to create the directory of: %s, error: %s", name, err)
+		}
+		if err := ioutil.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write the file: %s, error: %s", name, err)
+		}
+	}
+	uri, _ := url.Parse("file://" + root)
+	store, err := NewFileStoreClient(uri)
+	if err != nil {
+		t.Fatalf("failed to create the file store, error: %s", err)
+	}
+	return store.(*FileStoreClient)
+}
+
+func TestFileStoreInvalidUrl(t *testing.T) {
+	file, err := ioutil.TempFile("", "filestore")
+	if err != nil {
+		t.Fatalf("failed to create a file, error: %s", err)
+	}
+	defer os.Remove(file.Name())
+	file.Close()
+	for _, location := range []string{"etcd:///tmp", "file://relative", "file://" + file.Name(), "file:///no/such/directory"} {
+		uri, _ := url.Parse(location)
+		if _, err := NewFileStoreClient(uri); err == nil {
+			t.Errorf("url: %s, expected an error", location)
+		}
+	}
+}
+
+func TestFileStoreGet(t *testing.T) {
+	store := NewTestFileStore(t, map[string]string{"/app/config": "value", "/app/db/host": "127.0.0.1", "/empty": ""})
+	ctx := context.Background()
+	tests := []struct {
+		Key       string
+		Path      string
+		Value     string
+		Directory bool
+		Error     error
+	}{
+		{Key: "/app/config", Path: "/app/config", Value: "value"},
+		{Key: "app/config", Path: "/app/config", Value: "value"},
+		{Key: "/empty", Path: "/empty"},
+		{Key: "/app/db", Path: "/app/db", Directory: true},
+		{Key: "/", Path: "/", Directory: true},
+		{Key: "/../../app/config", Path: "/app/config", Value: "value"},
+		{Key: "/missing", Error: NodeNotFoundErr},
+		{Key: "/app/config/below"},
+	}
+	for _, test := range tests {
+		node, err := store.Get(ctx, test.Key)
+		if test.Path == "" {
+			if err == nil {
+				t.Errorf("key: %s, expected an error, got: %v", test.Key, node)
+			} else if test.Error != nil && err != test.Error {
+				t.Errorf("key: %s, expected the error: %s, got: %s", test.Key, test.Error, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("key: %s, failed to get the key, error: %s", test.Key, err)
+			continue
+		}
+		if node.Path != test.Path || node.Value != test.Value || node.IsDir() != test.Directory || node.Index == 0 {
+			t.Errorf("key: %s, expected: %s %q (directory: %t), got: %v", test.Key, test.Path, test.Value, test.Directory, node)
+		}
+	}
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := store.Get(cancelled, "/app/config"); err != context.Canceled {
+		t.Errorf("expected a cancelled get to fail, got: %v", err)
+	}
+}
+
+func TestFileStoreList(t *testing.T) {
+	store := NewTestFileStore(t, map[string]string{
+		"/app/a":         "a",
+		"/app/.a123":     "partial write",
+		"/app/db/host":   "127.0.0.1",
+		"/app/.hidden/b": "b",
+		"/other":         "other"})
+	tests := []struct {
+		Path  string
+		Names []string
+		Error error
+	}{
+		{Path: "/app", Names: []string{"/app/a", "/app/db"}},
+		{Path: "/app/db", Names: []string{"/app/db/host"}},
+		{Path: "/", Names: []string{"/app", "/other"}},
+		{Path: "/other", Error: InvalidDirectoryErr},
+	}
+	for _, test := range tests {
+		if test.Error != nil {
+			if _, err := store.List(context.Background(), test.Path); err != test.Error {
+				t.Errorf("path: %s, expected the error: %s, got: %v", test.Path, test.Error, err)
+			}
+			continue
+		}
+		if names := ListNames(t, store, test.Path); !EqualStrings(names, test.Names) {
+			t.Errorf("path: %s, expected the listing: %v, got: %v", test.Path, test.Names, names)
+		}
+	}
+	if _, err := store.List(context.Background(), "/missing"); err == nil {
+		t.Errorf("expected the listing of a missing path to fail")
+	}
+}
+
+func TestFileStoreCompareAndSwap(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		Name     string
+		Key      string
+		OldValue string
+		Stale    bool
+		Error    error
+	}{
+		{Name: "by index", Key: "/key"},
+		{Name: "by value", Key: "/key", OldValue: "first"},
+		{Name: "stale index", Key: "/key", Stale: true, Error: CompareFailedErr},
+		{Name: "stale value", Key: "/key", OldValue: "other", Error: CompareFailedErr},
+		{Name: "directory", Key: "/dir", Error: InvalidDirectoryErr},
+		{Name: "missing", Key: "/missing", Error: NodeNotFoundErr},
+	}
+	for _, test := range tests {
+		store := NewTestFileStore(t, map[string]string{"/key": "first", "/dir/key": "value"})
+		index := uint64(0)
+		if node, err := store.Get(ctx, test.Key); err == nil && test.OldValue == "" {
+			index = node.Index
+		}
+		if test.Stale {
+			index--
+		}
+		err := store.CompareAndSwap(ctx, test.Key, test.OldValue, index, "second")
+		if err != test.Error {
+			t.Errorf("%s: expected the error: %v, got: %v", test.Name, test.Error, err)
+			continue
+		}
+		expected := "first"
+		if test.Error == nil {
+			expected = "second"
+		}
+		if node, err := store.Get(ctx, "/key"); err != nil || node.Value != expected {
+			t.Errorf("%s: expected the key to be: %q, got: %v, error: %v", test.Name, expected, node, err)
+		}
+	}
+}
+
+func TestFileStoreCompareAndDelete(t *testing.T) {
+	store := NewTestFileStore(t, map[string]string{"/key": "value"})
+	ctx := context.Background()
+	if err := store.CompareAndDelete(ctx, "/key", "other", 0); err != CompareFailedErr {
+		t.Errorf("expected the delete of a changed key to fail, got: %v", err)
+	}
+	if err := store.CompareAndDelete(ctx, "/key", "value", 0); err != nil {
+		t.Errorf("failed to delete the key, error: %s", err)
+	}
+	if _, err := store.Get(ctx, "/key"); err != NodeNotFoundErr {
+		t.Errorf("expected the key to be gone, got: %v", err)
+	}
+	if err := store.RemovePath(ctx, "/"); err != InvalidDirectoryErr {
+		t.Errorf("expected the root not to be removed, got: %v", err)
+	}
+	if err := store.SetWithTTL(ctx, "/key", "value", 1); err != TTLNotSupportedErr {
+		t.Errorf("expected a ttl to be refused, got: %v", err)
+	}
+}
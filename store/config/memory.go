@@ -0,0 +1,607 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+var BackendDownErr = errors.New("The backend is currently unavailable")
+var InjectedFaultErr = errors.New("Injected fault on the key")
+
+/*
+The memory store keeps the whole tree in a map and is used to test the filesystem, cache
+and templates without a real backend. Faults can be programmed in to simulate latency,
+errors on specific paths, dropped watch events and the backend going away, i.e.
+
+	mem://?fixture=/tmp/fixture.json&latency=100ms&drop=0.1
+*/
+type MemoryStoreClient struct {
+	sync.RWMutex
+	/* the nodes in the store, keyed by path */
+	Nodes map[string]*Node
+	/* the watches on the store */
+	Watches map[*MemoryWatch]bool
+	/* the faults to inject */
+	Faults MemoryFaults
+	/* the revision of the store, bumped on every change */
+	Revision uint64
+	/* the events held back while a transaction is applied, nil when there isn't one */
+	Held []NodeChange
+	/* the time the keys with a ttl expire */
+	Expiries map[string]time.Time
+}
+
+type MemoryFaults struct {
+	/* a delay added to every operation */
+	Latency time.Duration
+	/* a map of path prefix to the error returned on it */
+	Errors map[string]error
+	/* the probability (0 - 1) a watch event is dropped */
+	DropRate float64
+	/* the backend is down until this time */
+	DownUntil time.Time
+}
+
+type MemoryWatch struct {
+	sync.Mutex
+	/* the key / prefix being watched */
+	Key string
+	/* the events waiting to be sent upstream */
+	Pending []NodeChange
+	/* a signal there are events pending */
+	Signal chan bool
+}
+
+func NewMemoryStoreClient(uri *url.URL) (KVStore, error) {
+	glog.Infof("Creating a Memory K/V Store, url: %s", uri)
+	if uri.Scheme != "mem" {
+		glog.Errorf("Invalid url: %s, must start with mem", uri)
+		return nil, InvalidUrlErr
+	}
+	store := new(MemoryStoreClient)
+	store.Nodes = make(map[string]*Node, 0)
+	store.Nodes["/"] = &Node{Path: "/", Directory: true}
+	store.Watches = make(map[*MemoryWatch]bool, 0)
+	store.Expiries = make(map[string]time.Time, 0)
+	store.Faults.Errors = make(map[string]error, 0)
+	params := uri.Query()
+	if fixture := params.Get("fixture"); fixture != "" {
+		if err := store.LoadFixture(fixture); err != nil {
+			glog.Errorf("Failed to load the fixture: %s, error: %s", fixture, err)
+			return nil, err
+		}
+	}
+	if latency := params.Get("latency"); latency != "" {
+		duration, err := time.ParseDuration(latency)
+		if err != nil {
+			glog.Errorf("Invalid latency: %s specified in url: %s", latency, uri)
+			return nil, InvalidUrlErr
+		}
+		store.SetLatency(duration)
+	}
+	if drop := params.Get("drop"); drop != "" {
+		rate, err := strconv.ParseFloat(drop, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			glog.Errorf("Invalid drop rate: %s specified in url: %s", drop, uri)
+			return nil, InvalidUrlErr
+		}
+		store.DropEvents(rate)
+	}
+	return store, nil
+}
+
+/*
+Load a JSON fixture into the store; objects are directories and everything else is
+a key, i.e. {"prod": {"db": {"host": "10.0.0.1", "port": 3306}}}
+*/
+func (r *MemoryStoreClient) LoadFixture(filename string) error {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	var fixture map[string]interface{}
+	if err := json.Unmarshal(content, &fixture); err != nil {
+		return err
+	}
+	r.Lock()
+	defer r.Unlock()
+	r.LoadTree("/", fixture)
+	return nil
+}
+
+func (r *MemoryStoreClient) LoadTree(path string, tree map[string]interface{}) {
+	for name, value := range tree {
+		key := filepath.Join(path, name)
+		switch value := value.(type) {
+		case map[string]interface{}:
+			r.UpdateNode(&Node{Path: key, Directory: true})
+			r.LoadTree(key, value)
+		case string:
+			r.UpdateNode(&Node{Path: key, Value: value})
+		default:
+			encoded, _ := json.Marshal(value)
+			r.UpdateNode(&Node{Path: key, Value: string(encoded)})
+		}
+	}
+}
+
+/* Add a delay to every operation on the store */
+func (r *MemoryStoreClient) SetLatency(latency time.Duration) {
+	r.Lock()
+	defer r.Unlock()
+	r.Faults.Latency = latency
+}
+
+/* Fail any operation on a key under the prefix with the error */
+func (r *MemoryStoreClient) FailPath(prefix string, err error) {
+	r.Lock()
+	defer r.Unlock()
+	if err == nil {
+		err = InjectedFaultErr
+	}
+	r.Faults.Errors[r.KeyPath(prefix)] = err
+}
+
+/* Drop watch events with the given probability */
+func (r *MemoryStoreClient) DropEvents(rate float64) {
+	r.Lock()
+	defer r.Unlock()
+	r.Faults.DropRate = rate
+}
+
+/* Take the backend down for the duration */
+func (r *MemoryStoreClient) Outage(duration time.Duration) {
+	r.Lock()
+	defer r.Unlock()
+	r.Faults.DownUntil = time.Now().Add(duration)
+}
+
+/* Remove all the faults from the store */
+func (r *MemoryStoreClient) ClearFaults() {
+	r.Lock()
+	defer r.Unlock()
+	r.Faults = MemoryFaults{Errors: make(map[string]error, 0)}
+}
+
+/* Apply any faults which have been programmed for the key */
+func (r *MemoryStoreClient) Fault(ctx context.Context, key string) error {
+	r.RLock()
+	latency := r.Faults.Latency
+	r.RUnlock()
+	if latency > 0 {
+		select {
+		case <-ctx.Done():
+		case <-time.After(latency):
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	/* step: the errors map is changed by FailPath, so it's only read under the lock */
+	r.RLock()
+	defer r.RUnlock()
+	if time.Now().Before(r.Faults.DownUntil) {
+		return BackendDownErr
+	}
+	for prefix, err := range r.Faults.Errors {
+		if key == prefix || strings.HasPrefix(key, strings.TrimSuffix(prefix, "/")+"/") {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MemoryStoreClient) Get(ctx context.Context, key string) (*Node, error) {
+	key = r.KeyPath(key)
+	Verbose("Get() key: %s", key)
+	if err := r.Fault(ctx, key); err != nil {
+		glog.Errorf("Failed to get the key: %s, error: %s", key, err)
+		return nil, err
+	}
+	r.RLock()
+	defer r.RUnlock()
+	node, found := r.Nodes[key]
+	if !found {
+		return nil, NodeNotFoundErr
+	}
+	copied := *node
+	if expires, found := r.Expiries[key]; found {
+		copied.TTL = expires.Sub(time.Now())
+	}
+	return &copied, nil
+}
+
+func (r *MemoryStoreClient) Set(ctx context.Context, key string, value string) error {
+	return r.SetWithTTL(ctx, key, value, 0)
+}
+
+/* The key is removed by a timer, unless it's been changed in the meantime */
+func (r *MemoryStoreClient) SetWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error {
+	key = r.KeyPath(key)
+	Verbose("SetWithTTL() key: %s, value: %s, ttl: %s", key, Redact(key, value), ttl)
+	if err := r.Fault(ctx, key); err != nil {
+		glog.Errorf("Failed to set the key: %s, error: %s", key, err)
+		return err
+	}
+	r.Lock()
+	defer r.Unlock()
+	if node, found := r.Nodes[key]; found && node.IsDir() {
+		glog.Errorf("Set() key: %s is a directory", key)
+		return InvalidDirectoryErr
+	}
+	return r.UpdateNodeWithTTL(&Node{Path: key, Value: value}, ttl)
+}
+
+/* Update the node and have it removed once the ttl has expired; the caller must be holding the lock */
+func (r *MemoryStoreClient) UpdateNodeWithTTL(node *Node, ttl time.Duration) error {
+	if err := r.UpdateNode(node); err != nil {
+		return err
+	}
+	if ttl > 0 {
+		r.Expiries[node.Path] = time.Now().Add(ttl)
+		key, index := node.Path, node.Index
+		time.AfterFunc(ttl, func() {
+			r.Expire(key, index)
+		})
+	}
+	return nil
+}
+
+/* Remove the key once the ttl has expired, as long as it's the same version of the key */
+func (r *MemoryStoreClient) Expire(key string, index uint64) {
+	r.Lock()
+	defer r.Unlock()
+	node, found := r.Nodes[key]
+	if !found || node.Index != index {
+		return
+	}
+	Verbose("Expire() the key: %s has expired", key)
+	r.RemoveNode(node)
+	r.Notify(NodeChange{*node, DELETED})
+}
+
+func (r *MemoryStoreClient) Delete(ctx context.Context, key string) error {
+	key = r.KeyPath(key)
+	Verbose("Delete() deleting the key: %s", key)
+	if err := r.Fault(ctx, key); err != nil {
+		glog.Errorf("Delete() failed to delete key: %s, error: %s", key, err)
+		return err
+	}
+	r.Lock()
+	defer r.Unlock()
+	node, found := r.Nodes[key]
+	if !found {
+		return NodeNotFoundErr
+	}
+	if node.IsDir() {
+		glog.Errorf("Delete() key: %s is a directory", key)
+		return InvalidDirectoryErr
+	}
+	r.RemoveNode(node)
+	r.Notify(NodeChange{*node, DELETED})
+	return nil
+}
+
+func (r *MemoryStoreClient) CompareAndSwap(ctx context.Context, key string, oldValue string, oldIndex uint64, value string) error {
+	return r.CompareAndSwapWithTTL(ctx, key, oldValue, oldIndex, value, 0)
+}
+
+func (r *MemoryStoreClient) CompareAndSwapWithTTL(ctx context.Context, key string, oldValue string, oldIndex uint64, value string, ttl time.Duration) error {
+	key = r.KeyPath(key)
+	Verbose("CompareAndSwap() key: %s, index: %d, value: %s, ttl: %s", key, oldIndex, Redact(key, value), ttl)
+	if err := r.Fault(ctx, key); err != nil {
+		glog.Errorf("CompareAndSwap() failed to set the key: %s, error: %s", key, err)
+		return err
+	}
+	r.Lock()
+	defer r.Unlock()
+	node, found := r.Nodes[key]
+	if !found {
+		return NodeNotFoundErr
+	}
+	if node.IsDir() {
+		glog.Errorf("CompareAndSwap() key: %s is a directory", key)
+		return InvalidDirectoryErr
+	}
+	if !node.Unchanged(oldValue, oldIndex) {
+		return CompareFailedErr
+	}
+	return r.UpdateNodeWithTTL(&Node{Path: key, Value: value}, ttl)
+}
+
+func (r *MemoryStoreClient) CompareAndDelete(ctx context.Context, key string, oldValue string, oldIndex uint64) error {
+	key = r.KeyPath(key)
+	Verbose("CompareAndDelete() key: %s, index: %d", key, oldIndex)
+	if err := r.Fault(ctx, key); err != nil {
+		glog.Errorf("CompareAndDelete() failed to delete key: %s, error: %s", key, err)
+		return err
+	}
+	r.Lock()
+	defer r.Unlock()
+	node, found := r.Nodes[key]
+	if !found {
+		return NodeNotFoundErr
+	}
+	if node.IsDir() {
+		glog.Errorf("CompareAndDelete() key: %s is a directory", key)
+		return InvalidDirectoryErr
+	}
+	if !node.Unchanged(oldValue, oldIndex) {
+		return CompareFailedErr
+	}
+	r.RemoveNode(node)
+	r.Notify(NodeChange{*node, DELETED})
+	return nil
+}
+
+/* The nodes are simply left for the garbage collector */
+func (r *MemoryStoreClient) Close() error {
+	return nil
+}
+
+/*
+The transaction is applied under the lock with the events held back; if any of the operations
+fail the nodes are put back as they were and the events are never sent
+*/
+func (r *MemoryStoreClient) Txn(ctx context.Context, operations []*Operation) error {
+	Verbose("Txn() operations: %d", len(operations))
+	for _, operation := range operations {
+		if err := r.Fault(ctx, r.KeyPath(operation.Key)); err != nil {
+			glog.Errorf("Txn() failed to apply: %s, error: %s", operation, err)
+			return err
+		}
+	}
+	r.Lock()
+	defer r.Unlock()
+	nodes := make(map[string]*Node, len(r.Nodes))
+	for key, node := range r.Nodes {
+		nodes[key] = node
+	}
+	expiries := make(map[string]time.Time, len(r.Expiries))
+	for key, expires := range r.Expiries {
+		expiries[key] = expires
+	}
+	revision := r.Revision
+	r.Held = make([]NodeChange, 0)
+	for _, operation := range operations {
+		if err := r.ApplyOperation(operation); err != nil {
+			glog.Errorf("Txn() failed to apply: %s, error: %s", operation, err)
+			r.Nodes = nodes
+			r.Expiries = expiries
+			r.Revision = revision
+			r.Held = nil
+			return err
+		}
+	}
+	events := r.Held
+	r.Held = nil
+	for _, event := range events {
+		r.Notify(event)
+	}
+	return nil
+}
+
+/* Apply an operation from a transaction; the caller must be holding the lock */
+func (r *MemoryStoreClient) ApplyOperation(operation *Operation) error {
+	key := r.KeyPath(operation.Key)
+	node, found := r.Nodes[key]
+	if found && node.IsDir() {
+		return InvalidDirectoryErr
+	}
+	if !operation.Delete {
+		return r.UpdateNode(&Node{Path: key, Value: operation.Value})
+	}
+	if found {
+		r.RemoveNode(node)
+		r.Notify(NodeChange{*node, DELETED})
+	}
+	return nil
+}
+
+func (r *MemoryStoreClient) RemovePath(ctx context.Context, path string) error {
+	path = r.KeyPath(path)
+	Verbose("RemovePath() deleting the path: %s", path)
+	if err := r.Fault(ctx, path); err != nil {
+		glog.Errorf("RemovePath() failed to remove path: %s, error: %s", path, err)
+		return err
+	}
+	r.Lock()
+	defer r.Unlock()
+	if _, found := r.Nodes[path]; !found {
+		return NodeNotFoundErr
+	}
+	/* step: remove the children first, deepest paths first */
+	keys := make([]string, 0)
+	for key := range r.Nodes {
+		if key == path || (path != "/" && strings.HasPrefix(key, path+"/")) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	for _, key := range keys {
+		if key == "/" {
+			continue
+		}
+		node := r.Nodes[key]
+		r.RemoveNode(node)
+		r.Notify(NodeChange{*node, DELETED})
+	}
+	return nil
+}
+
+func (r *MemoryStoreClient) Mkdir(ctx context.Context, path string) error {
+	path = r.KeyPath(path)
+	Verbose("Mkdir() path: %s", path)
+	if err := r.Fault(ctx, path); err != nil {
+		glog.Errorf("Mkdir() failed to create directory node: %s, error: %s", path, err)
+		return err
+	}
+	r.Lock()
+	defer r.Unlock()
+	if node, found := r.Nodes[path]; found {
+		if node.IsDir() {
+			return nil
+		}
+		return InvalidDirectoryErr
+	}
+	return r.UpdateNode(&Node{Path: path, Directory: true})
+}
+
+func (r *MemoryStoreClient) List(ctx context.Context, path string) ([]*Node, error) {
+	path = r.KeyPath(path)
+	Verbose("List() path: %s", path)
+	if err := r.Fault(ctx, path); err != nil {
+		glog.Errorf("List() failed to list path: %s, error: %s", path, err)
+		return nil, err
+	}
+	r.RLock()
+	defer r.RUnlock()
+	directory, found := r.Nodes[path]
+	if !found {
+		return nil, NodeNotFoundErr
+	}
+	if !directory.IsDir() {
+		glog.Errorf("List() path: %s is not a directory node", path)
+		return nil, InvalidDirectoryErr
+	}
+	list := make([]*Node, 0)
+	for key, node := range r.Nodes {
+		if key != "/" && filepath.Dir(key) == path {
+			copied := *node
+			list = append(list, &copied)
+		}
+	}
+	return list, nil
+}
+
+func (r *MemoryStoreClient) Watch(ctx context.Context, key string) (<-chan NodeChange, context.CancelFunc, error) {
+	key = r.KeyPath(key)
+	Verbose("Watch() key: %s", key)
+	if err := r.Fault(ctx, key); err != nil {
+		glog.Errorf("Watch() error attempting to watch the key: %s, error: %s", key, err)
+		return nil, nil, err
+	}
+	watch := &MemoryWatch{Key: key, Signal: make(chan bool, 1)}
+	r.Lock()
+	r.Watches[watch] = true
+	r.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	updateChannel := make(chan NodeChange)
+	go func() {
+		defer close(updateChannel)
+		defer func() {
+			glog.V(3).Infof("Watch() killing off the watch on key: %s", key)
+			r.Lock()
+			delete(r.Watches, watch)
+			r.Unlock()
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-watch.Signal:
+			}
+			watch.Lock()
+			pending := watch.Pending
+			watch.Pending = nil
+			watch.Unlock()
+			for _, event := range pending {
+				/* step: pass the change upstream */
+				Verbose("Watch() sending the change for key: %s upstream", event.Node.Path)
+				select {
+				case updateChannel <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return updateChannel, cancel, nil
+}
+
+/*
+Queue the change on any watches which cover the key; the caller must be holding the lock.
+The events are queued so a slow consumer can never block a writer, and held back while a
+transaction is being applied
+*/
+func (r *MemoryStoreClient) Notify(event NodeChange) {
+	if r.Held != nil {
+		r.Held = append(r.Held, event)
+		return
+	}
+	for watch := range r.Watches {
+		if watch.Key != "/" && event.Node.Path != watch.Key && !strings.HasPrefix(event.Node.Path, watch.Key+"/") {
+			continue
+		}
+		if r.Faults.DropRate > 0 && rand.Float64() < r.Faults.DropRate {
+			Verbose("Notify() dropping the event for key: %s", event.Node.Path)
+			continue
+		}
+		watch.Lock()
+		watch.Pending = append(watch.Pending, event)
+		watch.Unlock()
+		select {
+		case watch.Signal <- true:
+		default:
+		}
+	}
+}
+
+/* Add or update the node, creating any missing parents; the caller must be holding the lock */
+func (r *MemoryStoreClient) UpdateNode(node *Node) error {
+	parent := filepath.Dir(node.Path)
+	if existing, found := r.Nodes[parent]; !found {
+		if err := r.UpdateNode(&Node{Path: parent, Directory: true}); err != nil {
+			return err
+		}
+	} else if !existing.IsDir() {
+		glog.Errorf("The parent: %s of key: %s is not a directory", parent, node.Path)
+		return InvalidDirectoryErr
+	}
+	r.Revision++
+	node.Index = r.Revision
+	node.CreateIndex = r.Revision
+	if previous, found := r.Nodes[node.Path]; found {
+		node.CreateIndex = previous.CreateIndex
+	}
+	delete(r.Expiries, node.Path)
+	r.Nodes[node.Path] = node
+	r.Notify(NodeChange{*node, CHANGED})
+	return nil
+}
+
+/* Remove the node and its expiry, if any; the caller must be holding the lock */
+func (r *MemoryStoreClient) RemoveNode(node *Node) {
+	delete(r.Nodes, node.Path)
+	delete(r.Expiries, node.Path)
+}
+
+func (r *MemoryStoreClient) KeyPath(key string) string {
+	return filepath.Clean("/" + key)
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func NewTestMemoryStore(t *testing.T, uri string) *MemoryStoreClient {
+	location, err := url.Parse(uri)
+	if err != nil {
+		t.Fatalf("invalid url: %s, error: %s", uri, err)
+	}
+	store, err := NewMemoryStoreClient(location)
+	if err != nil {
+		t.Fatalf("failed to create the memory store: %s, error: %s", uri, err)
+	}
+	return store.(*MemoryStoreClient)
+}
+
+func TestMemoryFailPath(t *testing.T) {
+	store := NewTestMemoryStore(t, "mem://")
+	ctx := context.Background()
+	for _, key := range []string{"/prod/db/host", "/production/db", "/staging/db"} {
+		if err := store.Set(ctx, key, "value"); err != nil {
+			t.Fatalf("failed to set the key: %s, error: %s", key, err)
+		}
+	}
+	denied := errors.New("denied")
+	store.FailPath("/prod", nil)
+	store.FailPath("staging/", denied)
+	tests := []struct {
+		Key   string
+		Error error
+	}{
+		{"/prod", InjectedFaultErr},
+		{"/prod/db/host", InjectedFaultErr},
+		{"/production/db", nil},
+		{"/staging/db", denied},
+	}
+	for _, test := range tests {
+		if _, err := store.Get(ctx, test.Key); err != test.Error {
+			t.Errorf("get of the key: %s, expected: %v, got: %v", test.Key, test.Error, err)
+		}
+	}
+	store.ClearFaults()
+	if _, err := store.Get(ctx, "/prod/db/host"); err != nil {
+		t.Errorf("the faults were cleared, got: %s", err)
+	}
+}
+
+/* FailPath changes the errors while the operations are reading them, run with -race */
+func TestMemoryFailPathConcurrently(t *testing.T) {
+	store := NewTestMemoryStore(t, "mem://")
+	ctx := context.Background()
+	var group sync.WaitGroup
+	group.Add(2)
+	go func() {
+		defer group.Done()
+		for i := 0; i < 200; i++ {
+			store.FailPath(filepath.Join("/fail", string(rune('a'+i%26))), nil)
+		}
+	}()
+	go func() {
+		defer group.Done()
+		for i := 0; i < 200; i++ {
+			store.Get(ctx, "/fail/z/key")
+		}
+	}()
+	group.Wait()
+}
+
+func TestMemoryLatency(t *testing.T) {
+	store := NewTestMemoryStore(t, "mem://?latency=50ms")
+	started := time.Now()
+	if _, err := store.Get(context.Background(), "/"); err != nil {
+		t.Fatalf("failed to get the root, error: %s", err)
+	}
+	if elapsed := time.Since(started); elapsed < 50*time.Millisecond {
+		t.Errorf("expected the get to take at least 50ms, took: %s", elapsed)
+	}
+	/* step: the deadline cuts the latency short */
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	if _, err := store.Get(ctx, "/"); err != context.DeadlineExceeded {
+		t.Errorf("expected the deadline to be exceeded, got: %v", err)
+	}
+}
+
+func TestMemoryOutage(t *testing.T) {
+	store := NewTestMemoryStore(t, "mem://")
+	ctx := context.Background()
+	store.Outage(50 * time.Millisecond)
+	if err := store.Set(ctx, "/key", "value"); err != BackendDownErr {
+		t.Errorf("expected the backend to be down, got: %v", err)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if err := store.Set(ctx, "/key", "value"); err != nil {
+		t.Errorf("expected the backend to be back up, got: %v", err)
+	}
+}
+
+func TestMemoryDropEvents(t *testing.T) {
+	tests := []struct {
+		Rate float64
+		/* the directory /app is created with the first key */
+		Events int
+	}{
+		{0, 4},
+		{1, 0},
+	}
+	for _, test := range tests {
+		store := NewTestMemoryStore(t, "mem://")
+		store.DropEvents(test.Rate)
+		ctx, cancel := context.WithCancel(context.Background())
+		changes, stop, err := store.Watch(ctx, "/app")
+		if err != nil {
+			t.Fatalf("failed to watch, error: %s", err)
+		}
+		for _, key := range []string{"/app/a", "/app/b", "/other/c", "/app/c"} {
+			store.Set(ctx, key, "value")
+		}
+		received := 0
+		timeout := time.After(100 * time.Millisecond)
+	loop:
+		for {
+			select {
+			case <-changes:
+				received++
+			case <-timeout:
+				break loop
+			}
+		}
+		if received != test.Events {
+			t.Errorf("drop rate: %v, expected: %d events, got: %d", test.Rate, test.Events, received)
+		}
+		stop()
+		cancel()
+	}
+}
+
+func TestMemoryFixture(t *testing.T) {
+	directory, err := ioutil.TempDir("", "memory")
+	if err != nil {
+		t.Fatalf("failed to create a directory, error: %s", err)
+	}
+	defer os.RemoveAll(directory)
+	fixture := filepath.Join(directory, "fixture.json")
+	content := `{"prod": {"db": {"host": "10.0.0.1", "port": 3306, "tags": ["a", "b"]}}, "motd": "hello"}`
+	if err := ioutil.WriteFile(fixture, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write the fixture, error: %s", err)
+	}
+	store := NewTestMemoryStore(t, "mem://?fixture="+fixture)
+	ctx := context.Background()
+	tests := []struct {
+		Key       string
+		Value     string
+		Directory bool
+	}{
+		{"/prod", "", true},
+		{"/prod/db", "", true},
+		{"/prod/db/host", "10.0.0.1", false},
+		{"/prod/db/port", "3306", false},
+		{"/prod/db/tags", `["a","b"]`, false},
+		{"/motd", "hello", false},
+	}
+	for _, test := range tests {
+		node, err := store.Get(ctx, test.Key)
+		if err != nil {
+			t.Errorf("failed to get the key: %s, error: %s", test.Key, err)
+			continue
+		}
+		if node.Value != test.Value || node.IsDir() != test.Directory {
+			t.Errorf("key: %s, expected: %q (directory: %t), got: %q (directory: %t)",
+				test.Key, test.Value, test.Directory, node.Value, node.IsDir())
+		}
+	}
+	if _, err := NewMemoryStoreClient(&url.URL{Scheme: "mem", RawQuery: "fixture=" + filepath.Join(directory, "missing.json")}); err == nil {
+		t.Errorf("expected an error for a missing fixture")
+	}
+}
+
+func TestMemoryInvalidUrl(t *testing.T) {
+	for _, uri := range []string{"mem://?latency=soon", "mem://?drop=2", "file:///tmp"} {
+		location, _ := url.Parse(uri)
+		if _, err := NewMemoryStoreClient(location); err == nil {
+			t.Errorf("expected the url: %s to be rejected", uri)
+		}
+	}
+}
+
+func TestMemoryCompareAndSwapWithTTL(t *testing.T) {
+	store := NewTestMemoryStore(t, "mem://")
+	ctx := context.Background()
+	store.Set(ctx, "/session", "token")
+	node, _ := store.Get(ctx, "/session")
+	if err := store.CompareAndSwapWithTTL(ctx, "/session", node.Value, node.Index+1, "token", time.Second); err != CompareFailedErr {
+		t.Errorf("expected the compare to fail on a stale index, got: %v", err)
+	}
+	if err := store.CompareAndSwapWithTTL(ctx, "/session", node.Value, node.Index, "token", 50*time.Millisecond); err != nil {
+		t.Fatalf("failed to swap the key, error: %s", err)
+	}
+	if node, err := store.Get(ctx, "/session"); err != nil || node.TTL <= 0 {
+		t.Errorf("expected the key to have a ttl, got: %v, error: %v", node, err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if _, err := store.Get(ctx, "/session"); err != NodeNotFoundErr {
+		t.Errorf("expected the key to have expired, got: %v", err)
+	}
+}
+
+func TestMemoryDeleteForgetsExpiry(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		Name   string
+		Delete func(store *MemoryStoreClient, node *Node) error
+	}{
+		{"delete", func(store *MemoryStoreClient, node *Node) error { return store.Delete(ctx, "/app/session") }},
+		{"compare and delete", func(store *MemoryStoreClient, node *Node) error {
+			return store.CompareAndDelete(ctx, "/app/session", node.Value, node.Index)
+		}},
+		{"txn", func(store *MemoryStoreClient, node *Node) error {
+			return store.Txn(ctx, []*Operation{{Key: "/app/session", Delete: true}})
+		}},
+		{"remove path", func(store *MemoryStoreClient, node *Node) error { return store.RemovePath(ctx, "/app") }},
+	}
+	for _, test := range tests {
+		store := NewTestMemoryStore(t, "mem://")
+		if err := store.SetWithTTL(ctx, "/app/session", "token", 50*time.Millisecond); err != nil {
+			t.Fatalf("%s: failed to set the key, error: %s", test.Name, err)
+		}
+		node, _ := store.Get(ctx, "/app/session")
+		if err := test.Delete(store, node); err != nil {
+			t.Errorf("%s: failed to delete the key, error: %s", test.Name, err)
+			continue
+		}
+		if len(store.Expiries) != 0 {
+			t.Errorf("%s: expected the expiry to be removed, got: %v", test.Name, store.Expiries)
+		}
+		/* step: the key is created again without a ttl and outlives the old one */
+		store.Set(ctx, "/app/session", "token")
+		time.Sleep(100 * time.Millisecond)
+		if node, err := store.Get(ctx, "/app/session"); err != nil || node.TTL != 0 {
+			t.Errorf("%s: expected the key without a ttl, got: %v, error: %v", test.Name, node, err)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+/* the buckets of the durations, in seconds */
+var DURATION_BUCKETS = []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+var (
+	kv_requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "config_store_kv_requests_total",
+		Help: "The calls to the backend k/v stores by backend, method and error, none if it succeeded"},
+		[]string{"backend", "method", "error"})
+	kv_durations = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "config_store_kv_request_duration_seconds",
+		Help:    "The time taken by the calls to the backend k/v stores",
+		Buckets: DURATION_BUCKETS},
+		[]string{"backend", "method"})
+	watch_events = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "config_store_watch_events_total",
+		Help: "The changes received from the watches on the backends"},
+		[]string{"backend", "operation"})
+	watch_delivery = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "config_store_watch_delivery_seconds",
+		Help:    "The time taken to hand a change from a backend on to the mount",
+		Buckets: DURATION_BUCKETS},
+		[]string{"backend"})
+	watch_reconnects = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "config_store_watch_reconnects_total",
+		Help: "The times a watch on a backend has failed and been started again"},
+		[]string{"backend"})
+)
+
+func init() {
+	prometheus.MustRegister(kv_requests, kv_durations, watch_events, watch_delivery, watch_reconnects)
+}
+
+/* Count the watch on the backend failing and being retried */
+func WatchReconnected(backend string) {
+	watch_reconnects.WithLabelValues(backend).Inc()
+}
+
+/*
+The metered store counts the calls to the backend and times them; it wraps the client itself, so
+what's measured is the backend rather than the encryption or the layers above it
+*/
+type MeteredStore struct {
+	/* the store we are wrapping */
+	Store KVStore
+	/* the name of the backend, i.e. etcd or consul */
+	Backend string
+}
+
+func NewMeteredStore(store KVStore, backend string) KVStore {
+	return &MeteredStore{Store: store, Backend: backend}
+}
+
+/* The error as a label, the errors we know by name and anything else simply as error */
+func ErrorName(err error) string {
+	switch err {
+	case nil:
+		return "none"
+	case NodeNotFoundErr:
+		return "not_found"
+	case CompareFailedErr:
+		return "compare_failed"
+	case TTLNotSupportedErr:
+		return "not_supported"
+	case TxnTooLargeErr:
+		return "txn_too_large"
+	case VaultPermissionDeniedErr:
+		return "permission_denied"
+	case context.DeadlineExceeded:
+		return "timeout"
+	case context.Canceled:
+		return "canceled"
+	}
+	return "error"
+}
+
+func (r *MeteredStore) Measure(method string, started time.Time, err error) {
+	kv_requests.WithLabelValues(r.Backend, method, ErrorName(err)).Inc()
+	kv_durations.WithLabelValues(r.Backend, method).Observe(time.Since(started).Seconds())
+}
+
+func (r *MeteredStore) Get(ctx context.Context, key string) (node *Node, err error) {
+	defer func(started time.Time) { r.Measure("get", started, err) }(time.Now())
+	return r.Store.Get(ctx, key)
+}
+
+func (r *MeteredStore) List(ctx context.Context, path string) (nodes []*Node, err error) {
+	defer func(started time.Time) { r.Measure("list", started, err) }(time.Now())
+	return r.Store.List(ctx, path)
+}
+
+func (r *MeteredStore) Set(ctx context.Context, key string, value string) (err error) {
+	defer func(started time.Time) { r.Measure("set", started, err) }(time.Now())
+	return r.Store.Set(ctx, key, value)
+}
+
+func (r *MeteredStore) SetWithTTL(ctx context.Context, key string, value string, ttl time.Duration) (err error) {
+	defer func(started time.Time) { r.Measure("set_with_ttl", started, err) }(time.Now())
+	return r.Store.SetWithTTL(ctx, key, value, ttl)
+}
+
+func (r *MeteredStore) Delete(ctx context.Context, key string) (err error) {
+	defer func(started time.Time) { r.Measure("delete", started, err) }(time.Now())
+	return r.Store.Delete(ctx, key)
+}
+
+func (r *MeteredStore) RemovePath(ctx context.Context, path string) (err error) {
+	defer func(started time.Time) { r.Measure("remove_path", started, err) }(time.Now())
+	return r.Store.RemovePath(ctx, path)
+}
+
+func (r *MeteredStore) Mkdir(ctx context.Context, path string) (err error) {
+	defer func(started time.Time) { r.Measure("mkdir", started, err) }(time.Now())
+	return r.Store.Mkdir(ctx, path)
+}
+
+func (r *MeteredStore) CompareAndSwap(ctx context.Context, key string, oldValue string, oldIndex uint64, value string) (err error) {
+	defer func(started time.Time) { r.Measure("compare_and_swap", started, err) }(time.Now())
+	return r.Store.CompareAndSwap(ctx, key, oldValue, oldIndex, value)
+}
+
+func (r *MeteredStore) CompareAndSwapWithTTL(ctx context.Context, key string, oldValue string, oldIndex uint64, value string, ttl time.Duration) (err error) {
+	defer func(started time.Time) { r.Measure("compare_and_swap_with_ttl", started, err) }(time.Now())
+	return r.Store.CompareAndSwapWithTTL(ctx, key, oldValue, oldIndex, value, ttl)
+}
+
+func (r *MeteredStore) CompareAndDelete(ctx context.Context, key string, oldValue string, oldIndex uint64) (err error) {
+	defer func(started time.Time) { r.Measure("compare_and_delete", started, err) }(time.Now())
+	return r.Store.CompareAndDelete(ctx, key, oldValue, oldIndex)
+}
+
+func (r *MeteredStore) Close() error {
+	return r.Store.Close()
+}
+
+func (r *MeteredStore) Txn(ctx context.Context, operations []*Operation) (err error) {
+	defer func(started time.Time) { r.Measure("txn", started, err) }(time.Now())
+	return r.Store.Txn(ctx, operations)
+}
+
+/* The changes are counted as they arrive, and timed until they're taken by the mount */
+func (r *MeteredStore) Watch(ctx context.Context, key string) (<-chan NodeChange, context.CancelFunc, error) {
+	started := time.Now()
+	ctx, cancel := context.WithCancel(ctx)
+	changes, stop, err := r.Store.Watch(ctx, key)
+	r.Measure("watch", started, err)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	updates := make(chan NodeChange)
+	go func() {
+		defer close(updates)
+		for change := range changes {
+			watch_events.WithLabelValues(r.Backend, change.Operation.String()).Inc()
+			received := time.Now()
+			select {
+			case updates <- change:
+			case <-ctx.Done():
+				return
+			}
+			watch_delivery.WithLabelValues(r.Backend).Observe(time.Since(received).Seconds())
+		}
+	}()
+	return updates, func() { cancel(); stop() }, nil
+}
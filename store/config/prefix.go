@@ -0,0 +1,168 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+/*
+The prefix store makes a path in the backend the root, i.e. with the prefix /prod/payments
+the key /db/host is /prod/payments/db/host in the backend; nothing outside the prefix can
+be reached through it
+*/
+type PrefixStore struct {
+	/* the store we are wrapping */
+	Store KVStore
+	/* the path in the store which is the root */
+	Prefix string
+}
+
+/* Root the store at the prefix, a prefix of / is the store as it is */
+func NewPrefixStore(store KVStore, prefix string) KVStore {
+	prefix = filepath.Clean("/" + prefix)
+	if prefix == "/" {
+		return store
+	}
+	glog.Infof("Rooting the store at the prefix: %s", prefix)
+	return &PrefixStore{Store: store, Prefix: prefix}
+}
+
+/* The key under the prefix */
+func JoinPrefix(prefix, key string) string {
+	return filepath.Join(prefix, filepath.Clean("/"+key))
+}
+
+/* The key relative to the prefix */
+func TrimPrefix(prefix, path string) string {
+	path = filepath.Clean("/" + path)
+	if prefix == "/" {
+		return path
+	}
+	return filepath.Clean("/" + strings.TrimPrefix(path, prefix))
+}
+
+func (r *PrefixStore) Key(key string) string {
+	return JoinPrefix(r.Prefix, key)
+}
+
+func (r *PrefixStore) Node(node *Node) *Node {
+	node.Path = TrimPrefix(r.Prefix, node.Path)
+	return node
+}
+
+func (r *PrefixStore) Get(ctx context.Context, key string) (*Node, error) {
+	if filepath.Clean("/"+key) == "/" {
+		return &Node{Path: "/", Directory: true}, nil
+	}
+	node, err := r.Store.Get(ctx, r.Key(key))
+	if err != nil {
+		return nil, err
+	}
+	return r.Node(node), nil
+}
+
+/* The root is there even when nothing has been written under the prefix yet */
+func (r *PrefixStore) List(ctx context.Context, path string) ([]*Node, error) {
+	nodes, err := r.Store.List(ctx, r.Key(path))
+	if err == NodeNotFoundErr && filepath.Clean("/"+path) == "/" {
+		return []*Node{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		r.Node(node)
+	}
+	return nodes, nil
+}
+
+func (r *PrefixStore) Set(ctx context.Context, key string, value string) error {
+	return r.Store.Set(ctx, r.Key(key), value)
+}
+
+func (r *PrefixStore) SetWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error {
+	return r.Store.SetWithTTL(ctx, r.Key(key), value, ttl)
+}
+
+func (r *PrefixStore) Delete(ctx context.Context, key string) error {
+	return r.Store.Delete(ctx, r.Key(key))
+}
+
+func (r *PrefixStore) RemovePath(ctx context.Context, path string) error {
+	return r.Store.RemovePath(ctx, r.Key(path))
+}
+
+func (r *PrefixStore) Mkdir(ctx context.Context, path string) error {
+	return r.Store.Mkdir(ctx, r.Key(path))
+}
+
+func (r *PrefixStore) CompareAndSwap(ctx context.Context, key string, oldValue string, oldIndex uint64, value string) error {
+	return r.Store.CompareAndSwap(ctx, r.Key(key), oldValue, oldIndex, value)
+}
+
+func (r *PrefixStore) CompareAndSwapWithTTL(ctx context.Context, key string, oldValue string, oldIndex uint64, value string, ttl time.Duration) error {
+	return r.Store.CompareAndSwapWithTTL(ctx, r.Key(key), oldValue, oldIndex, value, ttl)
+}
+
+func (r *PrefixStore) CompareAndDelete(ctx context.Context, key string, oldValue string, oldIndex uint64) error {
+	return r.Store.CompareAndDelete(ctx, r.Key(key), oldValue, oldIndex)
+}
+
+/* The store under the prefix is shared, so it's closed by whoever created it */
+func (r *PrefixStore) Close() error {
+	return nil
+}
+
+func (r *PrefixStore) Txn(ctx context.Context, operations []*Operation) error {
+	translated := make([]*Operation, 0, len(operations))
+	for _, operation := range operations {
+		translated = append(translated, &Operation{
+			Key:    r.Key(operation.Key),
+			Value:  operation.Value,
+			Delete: operation.Delete})
+	}
+	return r.Store.Txn(ctx, translated)
+}
+
+func (r *PrefixStore) Watch(ctx context.Context, key string) (<-chan NodeChange, context.CancelFunc, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	changes, stop, err := r.Store.Watch(ctx, r.Key(key))
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	updates := make(chan NodeChange)
+	go func() {
+		defer close(updates)
+		for change := range changes {
+			/* step: the prefix itself is the root, which never changes */
+			if filepath.Clean("/"+change.Node.Path) == r.Prefix {
+				continue
+			}
+			r.Node(&change.Node)
+			select {
+			case updates <- change:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return updates, func() { cancel(); stop() }, nil
+}
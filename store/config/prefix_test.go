@@ -0,0 +1,118 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestJoinAndTrimPrefix(t *testing.T) {
+	tests := []struct {
+		Prefix  string
+		Key     string
+		Backend string
+	}{
+		{"/", "/db/host", "/db/host"},
+		{"/", "/", "/"},
+		{"/prod/payments", "/db/host", "/prod/payments/db/host"},
+		{"/prod/payments", "db/host", "/prod/payments/db/host"},
+		{"/prod/payments", "/", "/prod/payments"},
+		{"/prod/payments", "", "/prod/payments"},
+		{"/prod/payments", "/db/", "/prod/payments/db"},
+		{"/prod/payments", "/../../etc/passwd", "/prod/payments/etc/passwd"},
+	}
+	for _, test := range tests {
+		if joined := JoinPrefix(test.Prefix, test.Key); joined != test.Backend {
+			t.Errorf("prefix: %s, key: %q, expected: %s, got: %s", test.Prefix, test.Key, test.Backend, joined)
+		}
+	}
+	trims := []struct {
+		Prefix string
+		Path   string
+		Key    string
+	}{
+		{"/", "/db/host", "/db/host"},
+		{"/prod/payments", "/prod/payments/db/host", "/db/host"},
+		{"/prod/payments", "/prod/payments", "/"},
+		{"/prod/payments", "prod/payments/db", "/db"},
+		{"/prod/payments", "/prod/payments/db/", "/db"},
+	}
+	for _, test := range trims {
+		if trimmed := TrimPrefix(test.Prefix, test.Path); trimmed != test.Key {
+			t.Errorf("prefix: %s, path: %q, expected: %s, got: %s", test.Prefix, test.Path, test.Key, trimmed)
+		}
+	}
+}
+
+func TestPrefixStore(t *testing.T) {
+	backend := NewTestMemoryStore(t, "mem://")
+	ctx := context.Background()
+	backend.Set(ctx, "/prod/payments/db/host", "10.0.0.1")
+	backend.Set(ctx, "/prod/orders/db/host", "10.0.0.2")
+	store := NewPrefixStore(backend, "/prod/payments")
+	if NewPrefixStore(backend, "/") != KVStore(backend) {
+		t.Errorf("expected a prefix of / to be the store as it is")
+	}
+	node, err := store.Get(ctx, "/db/host")
+	if err != nil || node.Path != "/db/host" || node.Value != "10.0.0.1" {
+		t.Errorf("expected the key under the prefix, got: %v, error: %v", node, err)
+	}
+	if node, err := store.Get(ctx, "/"); err != nil || !node.IsDir() || node.Path != "/" {
+		t.Errorf("expected the root to be the prefix, got: %v, error: %v", node, err)
+	}
+	if names := ListNames(t, store, "/"); !EqualStrings(names, []string{"/db"}) {
+		t.Errorf("expected the listing of the prefix, got: %v", names)
+	}
+	if _, err := store.Get(ctx, "/../orders/db/host"); err != NodeNotFoundErr {
+		t.Errorf("expected nothing outside the prefix to be reachable, got: %v", err)
+	}
+	/* step: the writes land under the prefix */
+	if err := store.Txn(ctx, []*Operation{{Key: "/db/port", Value: "3306"}, {Key: "/db/host", Delete: true}}); err != nil {
+		t.Fatalf("failed to apply the transaction, error: %s", err)
+	}
+	if node, err := backend.Get(ctx, "/prod/payments/db/port"); err != nil || node.Value != "3306" {
+		t.Errorf("expected the key to be set under the prefix, got: %v, error: %v", node, err)
+	}
+	if _, err := backend.Get(ctx, "/prod/payments/db/host"); err != NodeNotFoundErr {
+		t.Errorf("expected the key under the prefix to be deleted, got: %v", err)
+	}
+	/* step: an empty prefix still lists as an empty root */
+	empty := NewPrefixStore(backend, "/staging")
+	if names := ListNames(t, empty, "/"); len(names) != 0 {
+		t.Errorf("expected an empty root, got: %v", names)
+	}
+}
+
+func TestPrefixStoreWatch(t *testing.T) {
+	backend := NewTestMemoryStore(t, "mem://")
+	ctx := context.Background()
+	store := NewPrefixStore(backend, "/prod/payments")
+	changes, stop, err := store.Watch(ctx, "/")
+	if err != nil {
+		t.Fatalf("failed to watch, error: %s", err)
+	}
+	defer stop()
+	backend.Set(ctx, "/prod/orders/db", "outside")
+	backend.Set(ctx, "/prod/payments/db", "inside")
+	select {
+	case change := <-changes:
+		if change.Node.Path != "/db" || change.Node.Value != "inside" {
+			t.Errorf("expected the change relative to the prefix, got: %v", change.Node)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected a change under the prefix")
+	}
+}
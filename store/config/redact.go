@@ -0,0 +1,124 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/golang/glog"
+)
+
+const (
+	/* how a redacted value is shown in the logs */
+	REDACT_LENGTH = "length"
+	REDACT_HASH   = "hash"
+)
+
+var redact_patterns, redact_display *string
+
+/*
+the patterns and display read from the flags on first use, and again after a reload; the salt of
+the hashes is made up the once, so a hash stays the same across reloads
+*/
+var redaction struct {
+	sync.RWMutex
+	Loaded   bool
+	Patterns []string
+	Display  string
+	Salt     []byte
+}
+
+func init() {
+	redact_patterns = flag.String("redact", "/**", "a comma separated list of the patterns of the keys whose values are masked in the logs, empty to log them all")
+	redact_display = flag.String("redact-display", REDACT_LENGTH, "how a masked value is shown, either its length or a hash of it")
+}
+
+/*
+Mask the value of the key if it's a secret, i.e. covered by one of the redact patterns. Every
+value which goes to the logs goes through here; by default that's all of them. The hash is
+keyed with a salt made up at start, so it can tell you a value changed but can't be used to
+guess the value, and isn't the same from one run to the next
+*/
+func Redact(key, value string) string {
+	patterns, display, salt := Redaction()
+	key = filepath.Clean("/" + key)
+	for _, pattern := range patterns {
+		if MatchPattern(pattern, key) {
+			return RedactValue(value, display, salt)
+		}
+	}
+	return value
+}
+
+func Redaction() ([]string, string, []byte) {
+	redaction.RLock()
+	if redaction.Loaded {
+		defer redaction.RUnlock()
+		return redaction.Patterns, redaction.Display, redaction.Salt
+	}
+	redaction.RUnlock()
+	redaction.Lock()
+	defer redaction.Unlock()
+	if !redaction.Loaded {
+		LoadRedaction()
+	}
+	return redaction.Patterns, redaction.Display, redaction.Salt
+}
+
+/*
+Read the patterns and display from the flags again, i.e. after a reload. They're read now rather
+than on the next use, so nothing reads the flags while a later reload is changing them
+*/
+func ResetRedaction() {
+	redaction.Lock()
+	defer redaction.Unlock()
+	LoadRedaction()
+}
+
+/* Read the patterns and display from the flags, the lock is held by the caller */
+func LoadRedaction() {
+	patterns := make([]string, 0)
+	for _, pattern := range strings.Split(*redact_patterns, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, filepath.Clean("/"+pattern))
+		}
+	}
+	if redaction.Salt == nil {
+		salt := make([]byte, 32)
+		if _, err := rand.Read(salt); err != nil {
+			glog.Errorf("Failed to make up the salt of the hashes, the redacted values are shown by their length, error: %s", err)
+		} else {
+			redaction.Salt = salt
+		}
+	}
+	redaction.Patterns, redaction.Display, redaction.Loaded = patterns, *redact_display, true
+}
+
+/* The value as it's shown; without a salt a hash could be used to guess a short value, so it's the length */
+func RedactValue(value, display string, salt []byte) string {
+	if display == REDACT_HASH && salt != nil {
+		digest := hmac.New(sha256.New, salt)
+		digest.Write([]byte(value))
+		return fmt.Sprintf("<redacted, hmac: %s>", hex.EncodeToString(digest.Sum(nil))[:12])
+	}
+	return fmt.Sprintf("<redacted, %d bytes>", len(value))
+}
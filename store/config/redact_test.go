@@ -0,0 +1,82 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactAfterReload(t *testing.T) {
+	patterns, display := *redact_patterns, *redact_display
+	defer func() {
+		*redact_patterns, *redact_display = patterns, display
+		ResetRedaction()
+	}()
+	*redact_patterns, *redact_display = "/secrets/**", REDACT_LENGTH
+	ResetRedaction()
+	tests := []struct {
+		Key      string
+		Redacted bool
+	}{
+		{"/secrets/db/password", true},
+		{"/secrets", true},
+		{"/config/motd", false},
+	}
+	for _, test := range tests {
+		if redacted := Redact(test.Key, "value") != "value"; redacted != test.Redacted {
+			t.Errorf("key: %s, expected redacted: %t", test.Key, test.Redacted)
+		}
+	}
+	hashed := func() string {
+		return Redact("/config/motd", "value")
+	}
+	*redact_patterns, *redact_display = "/config/**", REDACT_HASH
+	if hashed() != "value" {
+		t.Errorf("expected the patterns to be kept until the reload")
+	}
+	ResetRedaction()
+	first := hashed()
+	if !strings.HasPrefix(first, "<redacted, hmac: ") {
+		t.Errorf("expected the new patterns and display after the reload, got: %s", first)
+	}
+	if Redact("/secrets/db/password", "value") != "value" {
+		t.Errorf("expected the old patterns to be dropped after the reload")
+	}
+	/* step: the salt outlives the reload, so the hashes can still be compared */
+	ResetRedaction()
+	if second := hashed(); second != first {
+		t.Errorf("expected the same hash across reloads, got: %s and %s", first, second)
+	}
+}
+
+func TestRedactValueWithoutSalt(t *testing.T) {
+	tests := []struct {
+		Display  string
+		Salt     []byte
+		Redacted string
+	}{
+		{REDACT_LENGTH, []byte("salt"), "<redacted, 5 bytes>"},
+		{REDACT_LENGTH, nil, "<redacted, 5 bytes>"},
+		{REDACT_HASH, nil, "<redacted, 5 bytes>"},
+	}
+	for _, test := range tests {
+		if redacted := RedactValue("value", test.Display, test.Salt); redacted != test.Redacted {
+			t.Errorf("display: %s, salt: %v, expected: %s, got: %s", test.Display, test.Salt, test.Redacted, redacted)
+		}
+	}
+	if redacted := RedactValue("value", REDACT_HASH, []byte("salt")); !strings.HasPrefix(redacted, "<redacted, hmac: ") {
+		t.Errorf("expected a hash with a salt, got: %s", redacted)
+	}
+}
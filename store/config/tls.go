@@ -0,0 +1,157 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+var kv_tls_ca, kv_tls_cert, kv_tls_key, kv_tls_server_name *string
+var kv_tls_insecure *bool
+
+var InvalidCACertErr = errors.New("Unable to parse any certificates from the CA bundle")
+
+func init() {
+	kv_tls_ca = flag.String("kv-ca", "", "the CA bundle used to verify the backend k/v store")
+	kv_tls_cert = flag.String("kv-cert", "", "the client certificate used to authenticate to the backend k/v store")
+	kv_tls_key = flag.String("kv-key", "", "the private key for the client certificate")
+	kv_tls_server_name = flag.String("kv-server-name", "", "override the server name used to verify the backend certificate")
+	kv_tls_insecure = flag.Bool("kv-insecure", false, "skip the verification of the backend certificate")
+}
+
+/*
+The TLS options for a backend; they're taken from the flags and can be overridden per
+backend in the url, i.e. etcd://host:4001?ca=/etc/ssl/ca.pem&cert=/etc/ssl/client.pem&key=/etc/ssl/client-key.pem
+*/
+type TLSOptions struct {
+	/* the CA bundle to verify the server */
+	CAFile string
+	/* the client certificate and key */
+	CertFile string
+	KeyFile  string
+	/* an override for the server name in the certificate */
+	ServerName string
+	/* skip verification of the server certificate */
+	Insecure bool
+	/* use tls even without any of the above */
+	Enabled bool
+}
+
+func GetTLSOptions(uri *url.URL) (*TLSOptions, error) {
+	params := uri.Query()
+	options := &TLSOptions{
+		CAFile:     *kv_tls_ca,
+		CertFile:   *kv_tls_cert,
+		KeyFile:    *kv_tls_key,
+		ServerName: *kv_tls_server_name,
+		Insecure:   *kv_tls_insecure}
+	if value := params.Get("ca"); value != "" {
+		options.CAFile = value
+	}
+	if value := params.Get("cert"); value != "" {
+		options.CertFile = value
+	}
+	if value := params.Get("key"); value != "" {
+		options.KeyFile = value
+	}
+	if value := params.Get("server_name"); value != "" {
+		options.ServerName = value
+	}
+	if value := params.Get("insecure"); value != "" {
+		insecure, err := strconv.ParseBool(value)
+		if err != nil {
+			glog.Errorf("Invalid insecure option: %s in url", value)
+			return nil, InvalidUrlErr
+		}
+		options.Insecure = insecure
+	}
+	if value := params.Get("tls"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			glog.Errorf("Invalid tls option: %s in url", value)
+			return nil, InvalidUrlErr
+		}
+		options.Enabled = enabled
+	}
+	if options.CAFile != "" || options.CertFile != "" || options.ServerName != "" || options.Insecure {
+		options.Enabled = true
+	}
+	if (options.CertFile == "") != (options.KeyFile == "") {
+		glog.Errorf("Both a client certificate and key must be specified in the url, host: %s", uri.Host)
+		return nil, InvalidUrlErr
+	}
+	return options, nil
+}
+
+/* The scheme to use when talking to the backend */
+func (r *TLSOptions) Scheme() string {
+	if r.Enabled {
+		return "https"
+	}
+	return "http"
+}
+
+func (r *TLSOptions) TLSConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName:         r.ServerName,
+		InsecureSkipVerify: r.Insecure}
+	if r.CAFile != "" {
+		content, err := ioutil.ReadFile(r.CAFile)
+		if err != nil {
+			glog.Errorf("Failed to read the CA bundle: %s, error: %s", r.CAFile, err)
+			return nil, err
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(content) {
+			glog.Errorf("Failed to parse the CA bundle: %s", r.CAFile)
+			return nil, InvalidCACertErr
+		}
+	}
+	if r.CertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(r.CertFile, r.KeyFile)
+		if err != nil {
+			glog.Errorf("Failed to load the client certificate: %s, error: %s", r.CertFile, err)
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	return config, nil
+}
+
+func (r *TLSOptions) Transport() (*http.Transport, error) {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		Dial: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second}).Dial}
+	if r.Enabled {
+		config, err := r.TLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = config
+	}
+	return transport, nil
+}
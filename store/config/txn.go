@@ -0,0 +1,164 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+const TXN_ROLLBACK_TIMEOUT = 30 * time.Second
+
+var TxnTooLargeErr = errors.New("The transaction has too many operations for the backend")
+
+/* A set or delete of a key within a transaction */
+type Operation struct {
+	/* the key the operation applies to */
+	Key string
+	/* the value to set the key to */
+	Value string
+	/* the key is deleted rather than set */
+	Delete bool
+}
+
+func (r Operation) String() string {
+	if r.Delete {
+		return fmt.Sprintf("delete: %s", r.Key)
+	}
+	return fmt.Sprintf("set: %s, value: %s", r.Key, Redact(r.Key, r.Value))
+}
+
+/*
+Apply the operations one at a time, for the backends which have no transactions. The current
+values are read first, so if an operation fails the ones already applied are put back. It's
+best effort; a concurrent writer or the backend going away during the rollback can still
+leave the change half applied
+*/
+func EmulateTxn(ctx context.Context, store KVStore, operations []*Operation) error {
+	/* step: read the current state of the keys */
+	previous := make([]*Node, len(operations))
+	exists := make(map[string]bool, 0)
+	for i, operation := range operations {
+		node, err := store.Get(ctx, operation.Key)
+		if err != nil && err != NodeNotFoundErr {
+			glog.Errorf("EmulateTxn() failed to read the key: %s, error: %s", operation.Key, err)
+			return err
+		}
+		if node != nil && node.IsDir() {
+			glog.Errorf("EmulateTxn() key: %s is a directory", operation.Key)
+			return InvalidDirectoryErr
+		}
+		previous[i] = node
+		exists[operation.Key] = node != nil
+	}
+	/* step: apply the operations in order */
+	for i, operation := range operations {
+		var err error
+		switch {
+		case operation.Delete && !exists[operation.Key]:
+			continue
+		case operation.Delete:
+			err = store.Delete(ctx, operation.Key)
+		default:
+			err = store.Set(ctx, operation.Key, operation.Value)
+		}
+		if err != nil {
+			glog.Errorf("EmulateTxn() failed to apply: %s, error: %s, rolling back", operation, err)
+			RollbackTxn(store, operations[:i], previous[:i])
+			return err
+		}
+		exists[operation.Key] = !operation.Delete
+	}
+	return nil
+}
+
+/* Put back the keys, in reverse order so each ends up as it was before the transaction */
+func RollbackTxn(store KVStore, operations []*Operation, previous []*Node) {
+	/* step: the rollback gets its own deadline, the one for the transaction has probably passed */
+	ctx, cancel := context.WithTimeout(context.Background(), TXN_ROLLBACK_TIMEOUT)
+	defer cancel()
+	for i := len(operations) - 1; i >= 0; i-- {
+		var err error
+		if previous[i] == nil {
+			err = store.Delete(ctx, operations[i].Key)
+		} else {
+			err = store.Set(ctx, operations[i].Key, previous[i].Value)
+		}
+		if err != nil && err != NodeNotFoundErr {
+			glog.Errorf("RollbackTxn() failed to restore the key: %s, error: %s", operations[i].Key, err)
+		}
+	}
+}
+
+/*
+Convert a JSON tree into the operations to set it under the path; objects are directories
+and anything else is a key, i.e. {"db": {"host": "10.0.0.1", "port": 3306}}. The operations
+are sorted by key so the result is the same every time
+*/
+func TreeOperations(path string, tree map[string]interface{}) []*Operation {
+	operations := make([]*Operation, 0)
+	for name, value := range tree {
+		key := filepath.Join("/", path, name)
+		switch value := value.(type) {
+		case map[string]interface{}:
+			operations = append(operations, TreeOperations(key, value)...)
+		case string:
+			operations = append(operations, &Operation{Key: key, Value: value})
+		default:
+			encoded, _ := json.Marshal(value)
+			operations = append(operations, &Operation{Key: key, Value: string(encoded)})
+		}
+	}
+	sort.Sort(operationsByKey(operations))
+	return operations
+}
+
+type operationsByKey []*Operation
+
+func (r operationsByKey) Len() int           { return len(r) }
+func (r operationsByKey) Less(i, j int) bool { return r[i].Key < r[j].Key }
+func (r operationsByKey) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+
+/* Walk the tree under the path, returning the keys along with their values */
+func Walk(ctx context.Context, store KVStore, path string) ([]*Node, error) {
+	nodes, err := store.List(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*Node, 0)
+	for _, node := range nodes {
+		if node.IsDir() {
+			children, err := Walk(ctx, store, node.Path)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, children...)
+			continue
+		}
+		/* step: not every backend hands back the values in a listing */
+		key, err := store.Get(ctx, node.Path)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"testing"
+)
+
+/* Fails the writes to the keys, the reads go through, so the transaction gets as far as applying them */
+type FailingWrites struct {
+	KVStore
+	/* the keys whose writes fail */
+	Keys map[string]bool
+}
+
+func (r *FailingWrites) Set(ctx context.Context, key string, value string) error {
+	if r.Keys[key] {
+		return InjectedFaultErr
+	}
+	return r.KVStore.Set(ctx, key, value)
+}
+
+func (r *FailingWrites) Delete(ctx context.Context, key string) error {
+	if r.Keys[key] {
+		return InjectedFaultErr
+	}
+	return r.KVStore.Delete(ctx, key)
+}
+
+func TestEmulateTxnRollback(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Operations []*Operation
+		Fail       string
+		Error      error
+		Values     map[string]string
+		Absent     []string
+	}{
+		{
+			Name:       "applied",
+			Operations: []*Operation{{Key: "/a", Value: "1"}, {Key: "/b", Delete: true}, {Key: "/c", Value: "3"}},
+			Values:     map[string]string{"/a": "1", "/c": "3"},
+			Absent:     []string{"/b"},
+		},
+		{
+			Name:       "the first write fails",
+			Operations: []*Operation{{Key: "/a", Value: "1"}, {Key: "/c", Value: "3"}},
+			Fail:       "/a",
+			Error:      InjectedFaultErr,
+			Values:     map[string]string{"/a": "old", "/b": "old"},
+			Absent:     []string{"/c"},
+		},
+		{
+			Name:       "a set is put back and a new key removed",
+			Operations: []*Operation{{Key: "/a", Value: "1"}, {Key: "/c", Value: "3"}, {Key: "/d", Value: "4"}},
+			Fail:       "/d",
+			Error:      InjectedFaultErr,
+			Values:     map[string]string{"/a": "old", "/b": "old"},
+			Absent:     []string{"/c", "/d"},
+		},
+		{
+			Name:       "a delete is put back",
+			Operations: []*Operation{{Key: "/b", Delete: true}, {Key: "/d", Value: "4"}},
+			Fail:       "/d",
+			Error:      InjectedFaultErr,
+			Values:     map[string]string{"/a": "old", "/b": "old"},
+			Absent:     []string{"/d"},
+		},
+		{
+			Name:       "a key written twice ends as it was",
+			Operations: []*Operation{{Key: "/a", Value: "1"}, {Key: "/a", Value: "2"}, {Key: "/c", Value: "3"}, {Key: "/c", Delete: true}, {Key: "/d", Value: "4"}},
+			Fail:       "/d",
+			Error:      InjectedFaultErr,
+			Values:     map[string]string{"/a": "old", "/b": "old"},
+			Absent:     []string{"/c", "/d"},
+		},
+		{
+			Name:       "a delete of a missing key isn't a write",
+			Operations: []*Operation{{Key: "/d", Delete: true}, {Key: "/a", Value: "1"}},
+			Fail:       "/d",
+			Values:     map[string]string{"/a": "1", "/b": "old"},
+			Absent:     []string{"/d"},
+		},
+		{
+			Name:       "a directory is refused before anything is written",
+			Operations: []*Operation{{Key: "/a", Value: "1"}, {Key: "/dir", Value: "2"}},
+			Error:      InvalidDirectoryErr,
+			Values:     map[string]string{"/a": "old", "/b": "old"},
+		},
+	}
+	for _, test := range tests {
+		memory := NewTestMemoryStore(t, "mem://")
+		ctx := context.Background()
+		for _, key := range []string{"/a", "/b", "/dir/key"} {
+			if err := memory.Set(ctx, key, "old"); err != nil {
+				t.Fatalf("failed to set the key: %s, error: %s", key, err)
+			}
+		}
+		store := &FailingWrites{KVStore: memory, Keys: map[string]bool{test.Fail: true}}
+		if err := EmulateTxn(ctx, store, test.Operations); err != test.Error {
+			t.Errorf("%s: expected the error: %v, got: %v", test.Name, test.Error, err)
+		}
+		for key, value := range test.Values {
+			if node, err := memory.Get(ctx, key); err != nil || node.Value != value {
+				t.Errorf("%s: expected the key: %s to be %s, got: %v, error: %v", test.Name, key, value, node, err)
+			}
+		}
+		for _, key := range test.Absent {
+			if _, err := memory.Get(ctx, key); err != NodeNotFoundErr {
+				t.Errorf("%s: expected the key: %s to be absent, got: %v", test.Name, key, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,449 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+/* a key with the prefix in a layer hides the key of the same name in the layers below */
+const WHITEOUT_PREFIX = ".wh."
+
+var NoLayersErr = errors.New("The union has no layers")
+
+/* A layer of the union, a prefix in a backend */
+type Layer struct {
+	/* the name of the layer, shown in the metadata of the keys */
+	Name string
+	/* the backend holding the layer */
+	Store KVStore
+	/* the prefix of the layer in the backend */
+	Prefix string
+}
+
+/*
+The union store overlays the layers, i.e. /defaults/app, then /env/prod/app, then
+/host/<hostname>/app; a key is read from the top-most layer which has it and a directory
+lists the keys of all the layers. The changes are made to the top layer, so a key from
+a lower layer which is deleted is hidden by a whiteout, a .wh.<name> key next to it;
+a whiteout of a directory hides everything under it in the layers below
+*/
+type UnionStore struct {
+	/* the layers, the bottom one first */
+	Layers []*Layer
+}
+
+func NewUnionStore(layers []*Layer) (KVStore, error) {
+	if len(layers) == 0 {
+		return nil, NoLayersErr
+	}
+	for _, layer := range layers {
+		layer.Prefix = filepath.Clean("/" + layer.Prefix)
+		glog.Infof("Adding the layer: %s to the union", layer.Name)
+	}
+	return &UnionStore{Layers: layers}, nil
+}
+
+/* The key within the layer */
+func (r *Layer) Key(key string) string {
+	return JoinPrefix(r.Prefix, key)
+}
+
+/* The key in the union of the path within the layer */
+func (r *Layer) UnionKey(path string) string {
+	return TrimPrefix(r.Prefix, path)
+}
+
+/* The node as it is seen in the union */
+func (r *Layer) UnionNode(node *Node) *Node {
+	node.Path = r.UnionKey(node.Path)
+	if node.Metadata == nil {
+		node.Metadata = make(map[string]string, 0)
+	}
+	node.Metadata["union.layer"] = r.Name
+	return node
+}
+
+/* The whiteout hiding the key */
+func Whiteout(key string) string {
+	key = filepath.Clean("/" + key)
+	return filepath.Join(filepath.Dir(key), WHITEOUT_PREFIX+filepath.Base(key))
+}
+
+func (r *UnionStore) Top() *Layer {
+	return r.Layers[len(r.Layers)-1]
+}
+
+/* Check if the key or a directory above it has been whited out in the layer */
+func (r *UnionStore) WhitedOut(ctx context.Context, layer *Layer, key string) (bool, error) {
+	for path := filepath.Clean("/" + key); path != "/"; path = filepath.Dir(path) {
+		_, err := layer.Store.Get(ctx, layer.Key(Whiteout(path)))
+		if err == nil {
+			return true, nil
+		}
+		if err != NodeNotFoundErr {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+/* Find the key in the layers from the one at the index down, returning the index of the layer it's in */
+func (r *UnionStore) Resolve(ctx context.Context, key string, from int) (*Node, int, error) {
+	for index := from; index >= 0; index-- {
+		layer := r.Layers[index]
+		node, err := layer.Store.Get(ctx, layer.Key(key))
+		if err == nil {
+			return layer.UnionNode(node), index, nil
+		}
+		if err != NodeNotFoundErr {
+			return nil, 0, err
+		}
+		if hidden, err := r.WhitedOut(ctx, layer, key); err != nil || hidden {
+			if err == nil {
+				err = NodeNotFoundErr
+			}
+			return nil, 0, err
+		}
+	}
+	return nil, 0, NodeNotFoundErr
+}
+
+/* Check if the key shows through from the layers below the top one */
+func (r *UnionStore) VisibleBelow(ctx context.Context, key string) (bool, error) {
+	if len(r.Layers) < 2 {
+		return false, nil
+	}
+	_, _, err := r.Resolve(ctx, key, len(r.Layers)-2)
+	if err == NodeNotFoundErr {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *UnionStore) Get(ctx context.Context, key string) (*Node, error) {
+	key = filepath.Clean("/" + key)
+	Verbose("Get() key: %s", key)
+	if key == "/" {
+		return &Node{Path: key, Directory: true}, nil
+	}
+	if strings.HasPrefix(filepath.Base(key), WHITEOUT_PREFIX) {
+		return nil, NodeNotFoundErr
+	}
+	node, _, err := r.Resolve(ctx, key, len(r.Layers)-1)
+	return node, err
+}
+
+/* The keys of every layer, the upper ones hiding the lower; whiteouts hide what's below them */
+func (r *UnionStore) List(ctx context.Context, path string) ([]*Node, error) {
+	path = filepath.Clean("/" + path)
+	Verbose("List() path: %s", path)
+	if node, err := r.Get(ctx, path); err != nil {
+		return nil, err
+	} else if !node.IsDir() {
+		return nil, InvalidDirectoryErr
+	}
+	list := make([]*Node, 0)
+	seen := make(map[string]bool, 0)
+	for index := len(r.Layers) - 1; index >= 0; index-- {
+		layer := r.Layers[index]
+		nodes, err := layer.Store.List(ctx, layer.Key(path))
+		if err != nil && err != NodeNotFoundErr && err != InvalidDirectoryErr {
+			glog.Errorf("List() failed to list path: %s in layer: %s, error: %s", path, layer.Name, err)
+			return nil, err
+		}
+		whiteouts := make([]string, 0)
+		for _, node := range nodes {
+			name := filepath.Base(node.Path)
+			if strings.HasPrefix(name, WHITEOUT_PREFIX) {
+				whiteouts = append(whiteouts, strings.TrimPrefix(name, WHITEOUT_PREFIX))
+				continue
+			}
+			if !seen[name] {
+				seen[name] = true
+				list = append(list, layer.UnionNode(node))
+			}
+		}
+		/* step: the whiteouts only hide the keys of the layers below */
+		for _, name := range whiteouts {
+			seen[name] = true
+		}
+		/* step: a whiteout of the directory, or one above it, hides it in the layers below */
+		if index > 0 {
+			if hidden, err := r.WhitedOut(ctx, layer, path); err != nil {
+				return nil, err
+			} else if hidden {
+				break
+			}
+		}
+	}
+	return list, nil
+}
+
+func (r *UnionStore) Set(ctx context.Context, key string, value string) error {
+	return r.SetWithTTL(ctx, key, value, 0)
+}
+
+/* The key is set in the top layer, removing any whiteout of it */
+func (r *UnionStore) SetWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error {
+	key = filepath.Clean("/" + key)
+	Verbose("SetWithTTL() key: %s, value: %s, ttl: %s", key, Redact(key, value), ttl)
+	top := r.Top()
+	whiteout, err := r.HasWhiteout(ctx, key)
+	if err != nil {
+		return err
+	}
+	if ttl <= 0 && whiteout {
+		return top.Store.Txn(ctx, []*Operation{
+			{Key: top.Key(key), Value: value},
+			{Key: top.Key(Whiteout(key)), Delete: true}})
+	}
+	if err := top.Store.SetWithTTL(ctx, top.Key(key), value, ttl); err != nil {
+		return err
+	}
+	if whiteout {
+		return top.Store.Delete(ctx, top.Key(Whiteout(key)))
+	}
+	return nil
+}
+
+/* Check for a whiteout of the key itself in the top layer */
+func (r *UnionStore) HasWhiteout(ctx context.Context, key string) (bool, error) {
+	top := r.Top()
+	_, err := top.Store.Get(ctx, top.Key(Whiteout(key)))
+	if err == NodeNotFoundErr {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+/* The key is removed from the top layer, and whited out if it shows through from below */
+func (r *UnionStore) Delete(ctx context.Context, key string) error {
+	key = filepath.Clean("/" + key)
+	Verbose("Delete() key: %s", key)
+	operations, err := r.DeleteOperations(ctx, key)
+	if err != nil {
+		return err
+	}
+	return r.Top().Store.Txn(ctx, operations)
+}
+
+/* The operations on the top layer to delete the key from the union */
+func (r *UnionStore) DeleteOperations(ctx context.Context, key string) ([]*Operation, error) {
+	top := r.Top()
+	node, index, err := r.Resolve(ctx, key, len(r.Layers)-1)
+	if err != nil {
+		return nil, err
+	}
+	if node.IsDir() {
+		return nil, InvalidDirectoryErr
+	}
+	operations := make([]*Operation, 0)
+	if index == len(r.Layers)-1 {
+		operations = append(operations, &Operation{Key: top.Key(key), Delete: true})
+	}
+	below, err := r.VisibleBelow(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if below {
+		operations = append(operations, &Operation{Key: top.Key(Whiteout(key))})
+	}
+	return operations, nil
+}
+
+func (r *UnionStore) RemovePath(ctx context.Context, path string) error {
+	path = filepath.Clean("/" + path)
+	Verbose("RemovePath() path: %s", path)
+	top := r.Top()
+	if err := top.Store.RemovePath(ctx, top.Key(path)); err != nil && err != NodeNotFoundErr {
+		return err
+	}
+	if path == "/" {
+		return nil
+	}
+	below, err := r.VisibleBelow(ctx, path)
+	if err != nil || !below {
+		return err
+	}
+	return top.Store.Set(ctx, top.Key(Whiteout(path)), "")
+}
+
+func (r *UnionStore) Mkdir(ctx context.Context, path string) error {
+	path = filepath.Clean("/" + path)
+	top := r.Top()
+	if whiteout, err := r.HasWhiteout(ctx, path); err != nil {
+		return err
+	} else if whiteout {
+		if err := top.Store.Delete(ctx, top.Key(Whiteout(path))); err != nil {
+			return err
+		}
+	}
+	return top.Store.Mkdir(ctx, top.Key(path))
+}
+
+/*
+A key in the top layer is swapped there; one from a layer below is compared and then copied
+up with the new value, which isn't atomic with regards to a writer in the layer below
+*/
+func (r *UnionStore) CompareAndSwap(ctx context.Context, key string, oldValue string, oldIndex uint64, value string) error {
+	return r.CompareAndSwapWithTTL(ctx, key, oldValue, oldIndex, value, 0)
+}
+
+func (r *UnionStore) CompareAndSwapWithTTL(ctx context.Context, key string, oldValue string, oldIndex uint64, value string, ttl time.Duration) error {
+	key = filepath.Clean("/" + key)
+	Verbose("CompareAndSwap() key: %s, index: %d, value: %s, ttl: %s", key, oldIndex, Redact(key, value), ttl)
+	top := r.Top()
+	node, index, err := r.Resolve(ctx, key, len(r.Layers)-1)
+	if err != nil {
+		return err
+	}
+	if index == len(r.Layers)-1 {
+		return top.Store.CompareAndSwapWithTTL(ctx, top.Key(key), oldValue, oldIndex, value, ttl)
+	}
+	if !node.Unchanged(oldValue, oldIndex) {
+		return CompareFailedErr
+	}
+	return r.SetWithTTL(ctx, key, value, ttl)
+}
+
+func (r *UnionStore) CompareAndDelete(ctx context.Context, key string, oldValue string, oldIndex uint64) error {
+	key = filepath.Clean("/" + key)
+	Verbose("CompareAndDelete() key: %s, index: %d", key, oldIndex)
+	top := r.Top()
+	node, index, err := r.Resolve(ctx, key, len(r.Layers)-1)
+	if err != nil {
+		return err
+	}
+	if !node.Unchanged(oldValue, oldIndex) {
+		return CompareFailedErr
+	}
+	if index == len(r.Layers)-1 {
+		if err := top.Store.CompareAndDelete(ctx, top.Key(key), oldValue, oldIndex); err != nil {
+			return err
+		}
+	}
+	below, err := r.VisibleBelow(ctx, key)
+	if err != nil || !below {
+		return err
+	}
+	return top.Store.Set(ctx, top.Key(Whiteout(key)), "")
+}
+
+/* The backends of the layers are shared, so they're closed by whoever created them */
+func (r *UnionStore) Close() error {
+	return nil
+}
+
+/* The operations are translated into ones on the top layer and applied there in one go */
+func (r *UnionStore) Txn(ctx context.Context, operations []*Operation) error {
+	top := r.Top()
+	translated := make([]*Operation, 0)
+	for _, operation := range operations {
+		key := filepath.Clean("/" + operation.Key)
+		if operation.Delete {
+			deletes, err := r.DeleteOperations(ctx, key)
+			if err == NodeNotFoundErr {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			translated = append(translated, deletes...)
+			continue
+		}
+		translated = append(translated, &Operation{Key: top.Key(key), Value: operation.Value})
+		if whiteout, err := r.HasWhiteout(ctx, key); err != nil {
+			return err
+		} else if whiteout {
+			translated = append(translated, &Operation{Key: top.Key(Whiteout(key)), Delete: true})
+		}
+	}
+	return top.Store.Txn(ctx, translated)
+}
+
+/*
+Watch the key in every layer; a change in any of them, a whiteout included, is passed on as
+whatever the key now resolves to in the union
+*/
+func (r *UnionStore) Watch(ctx context.Context, key string) (<-chan NodeChange, context.CancelFunc, error) {
+	key = filepath.Clean("/" + key)
+	Verbose("Watch() key: %s", key)
+	ctx, cancel := context.WithCancel(ctx)
+	changes := make(chan NodeChange, 10)
+	updateChannel := make(chan NodeChange)
+	/* step: the layers are cancelled along with the union, should any of them end the union ends */
+	cancels := make([]context.CancelFunc, 0, len(r.Layers))
+	stop := func() {
+		cancel()
+		for _, layerCancel := range cancels {
+			layerCancel()
+		}
+	}
+	for _, layer := range r.Layers {
+		layerChannel, layerCancel, err := layer.Store.Watch(ctx, layer.Key(key))
+		if err != nil {
+			glog.Errorf("Watch() failed to watch the key: %s in layer: %s, error: %s", key, layer.Name, err)
+			stop()
+			return nil, nil, err
+		}
+		cancels = append(cancels, layerCancel)
+		go func(layer *Layer, layerChannel <-chan NodeChange) {
+			defer cancel()
+			for change := range layerChannel {
+				select {
+				case changes <- NodeChange{Node: Node{Path: layer.UnionKey(change.Node.Path)}}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			glog.Warningf("Watch() the watch on layer: %s has closed, closing the watch on the union", layer.Name)
+		}(layer, layerChannel)
+	}
+	go func() {
+		defer close(updateChannel)
+		defer stop()
+		for {
+			var change NodeChange
+			select {
+			case change = <-changes:
+			case <-ctx.Done():
+				return
+			}
+			path := change.Node.Path
+			if name := filepath.Base(path); strings.HasPrefix(name, WHITEOUT_PREFIX) {
+				path = filepath.Join(filepath.Dir(path), strings.TrimPrefix(name, WHITEOUT_PREFIX))
+			}
+			event := NodeChange{Node: Node{Path: path}, Operation: DELETED}
+			if node, err := r.Get(ctx, path); err == nil {
+				event = NodeChange{Node: *node, Operation: CHANGED}
+			} else if err != NodeNotFoundErr {
+				glog.Errorf("Watch() failed to resolve the change on key: %s, error: %s", path, err)
+				continue
+			}
+			select {
+			case updateChannel <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return updateChannel, stop, nil
+}
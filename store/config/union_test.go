@@ -0,0 +1,182 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+)
+
+/* A union of /defaults and /prod in the one memory store */
+func NewTestUnionStore(t *testing.T) (KVStore, *MemoryStoreClient) {
+	backend := NewTestMemoryStore(t, "mem://")
+	ctx := context.Background()
+	for key, value := range map[string]string{
+		"/defaults/app/a":      "default-a",
+		"/defaults/app/b":      "default-b",
+		"/defaults/app/db/url": "default-url",
+		"/prod/app/b":          "prod-b",
+		"/prod/app/c":          "prod-c"} {
+		if err := backend.Set(ctx, key, value); err != nil {
+			t.Fatalf("failed to set the key: %s, error: %s", key, err)
+		}
+	}
+	union, err := NewUnionStore([]*Layer{
+		{Name: "defaults", Store: backend, Prefix: "/defaults"},
+		{Name: "prod", Store: backend, Prefix: "/prod"}})
+	if err != nil {
+		t.Fatalf("failed to create the union, error: %s", err)
+	}
+	return union, backend
+}
+
+func ListNames(t *testing.T, store KVStore, path string) []string {
+	nodes, err := store.List(context.Background(), path)
+	if err != nil {
+		t.Fatalf("failed to list the path: %s, error: %s", path, err)
+	}
+	names := make([]string, 0)
+	for _, node := range nodes {
+		names = append(names, node.Path)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestUnionGet(t *testing.T) {
+	union, _ := NewTestUnionStore(t)
+	tests := []struct {
+		Key   string
+		Value string
+		Layer string
+	}{
+		{"/app/a", "default-a", "defaults"},
+		{"/app/b", "prod-b", "prod"},
+		{"/app/c", "prod-c", "prod"},
+		{"/app/db/url", "default-url", "defaults"},
+	}
+	for _, test := range tests {
+		node, err := union.Get(context.Background(), test.Key)
+		if err != nil {
+			t.Errorf("failed to get the key: %s, error: %s", test.Key, err)
+			continue
+		}
+		if node.Value != test.Value || node.Metadata["union.layer"] != test.Layer {
+			t.Errorf("key: %s, expected: %s from: %s, got: %s from: %s",
+				test.Key, test.Value, test.Layer, node.Value, node.Metadata["union.layer"])
+		}
+	}
+}
+
+func TestUnionWhiteouts(t *testing.T) {
+	union, backend := NewTestUnionStore(t)
+	ctx := context.Background()
+	/* step: deleting a key of the layer below leaves a whiteout in the top layer */
+	if err := union.Delete(ctx, "/app/a"); err != nil {
+		t.Fatalf("failed to delete the key, error: %s", err)
+	}
+	if _, err := union.Get(ctx, "/app/a"); err != NodeNotFoundErr {
+		t.Errorf("expected the key to be hidden, got: %v", err)
+	}
+	if _, err := backend.Get(ctx, "/defaults/app/a"); err != nil {
+		t.Errorf("expected the key to remain in the layer below, got: %v", err)
+	}
+	if _, err := backend.Get(ctx, "/prod/app/"+WHITEOUT_PREFIX+"a"); err != nil {
+		t.Errorf("expected a whiteout in the top layer, got: %v", err)
+	}
+	expected := []string{"/app/b", "/app/c", "/app/db"}
+	if names := ListNames(t, union, "/app"); !EqualStrings(names, expected) {
+		t.Errorf("expected the listing: %v, got: %v", expected, names)
+	}
+	/* step: setting the key again removes the whiteout */
+	if err := union.Set(ctx, "/app/a", "prod-a"); err != nil {
+		t.Fatalf("failed to set the key, error: %s", err)
+	}
+	if node, err := union.Get(ctx, "/app/a"); err != nil || node.Value != "prod-a" {
+		t.Errorf("expected the key to be visible again, got: %v, error: %v", node, err)
+	}
+	if _, err := backend.Get(ctx, "/prod/app/"+WHITEOUT_PREFIX+"a"); err != NodeNotFoundErr {
+		t.Errorf("expected the whiteout to be removed, got: %v", err)
+	}
+	/* step: a whiteout of a directory hides everything under it */
+	if err := union.RemovePath(ctx, "/app/db"); err != nil {
+		t.Fatalf("failed to remove the directory, error: %s", err)
+	}
+	if _, err := union.Get(ctx, "/app/db/url"); err != NodeNotFoundErr {
+		t.Errorf("expected the key under the directory to be hidden, got: %v", err)
+	}
+}
+
+/* A store whose watch is closed by the test */
+type ClosingStore struct {
+	*MemoryStoreClient
+	Changes chan NodeChange
+	Stopped chan bool
+}
+
+func (r *ClosingStore) Watch(ctx context.Context, key string) (<-chan NodeChange, context.CancelFunc, error) {
+	return r.Changes, func() { close(r.Stopped) }, nil
+}
+
+func TestUnionWatchClosesWithALayer(t *testing.T) {
+	backend := NewTestMemoryStore(t, "mem://")
+	closing := &ClosingStore{MemoryStoreClient: backend, Changes: make(chan NodeChange), Stopped: make(chan bool)}
+	union, _ := NewUnionStore([]*Layer{
+		{Name: "defaults", Store: backend, Prefix: "/defaults"},
+		{Name: "prod", Store: closing, Prefix: "/prod"}})
+	changes, _, err := union.Watch(context.Background(), "/")
+	if err != nil {
+		t.Fatalf("failed to watch the union, error: %s", err)
+	}
+	close(closing.Changes)
+	select {
+	case _, open := <-changes:
+		if open {
+			t.Errorf("expected the watch on the union to be closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("the watch on the union wasn't closed")
+	}
+	select {
+	case <-closing.Stopped:
+	case <-time.After(time.Second):
+		t.Errorf("the watch on the layer wasn't cancelled")
+	}
+	/* step: the memory store removes the watch once it sees the cancel */
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(10 * time.Millisecond) {
+		backend.RLock()
+		watches := len(backend.Watches)
+		backend.RUnlock()
+		if watches == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the watch on the other layer to be cancelled, watches: %d", watches)
+		}
+	}
+}
+
+func EqualStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,671 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+const (
+	/* the field of the secret holding the content of the file */
+	VAULT_DEFAULT_FIELD = "value"
+	/* the interval we poll for changes, vault has no watches */
+	VAULT_DEFAULT_POLL = 30 * time.Second
+	/* the backoff used when a renewal of the token fails */
+	VAULT_RENEW_MIN_BACKOFF = 1 * time.Second
+	VAULT_RENEW_MAX_BACKOFF = 60 * time.Second
+)
+
+var vault_token *string
+
+var VaultPermissionDeniedErr = errors.New("Vault denied the request, check the policies of the token")
+
+func init() {
+	vault_token = flag.String("vault-token", "", "the token used to authenticate to vault, defaults to $VAULT_TOKEN")
+}
+
+/*
+The vault store maps a KV secrets engine onto the tree, i.e. vault://127.0.0.1:8200/secret?kv=2;
+the path of the url is the mount of the engine and kv is the version, 1 or 2 (the default).
+Each secret is a key and the file is the content of the one field (value by default, see the
+field option), stored verbatim; a write replaces the secret with the field. With split=true
+the fields are split instead; a secret with only the field is its content, otherwise it's the
+JSON of the fields, and writing a JSON object sets the fields. On version 2 the revision of a
+key is the version of the secret and an older version is read when the context asks for it,
+see WithVersion
+*/
+type VaultStoreClient struct {
+	/* the address of vault, i.e. https://127.0.0.1:8200 */
+	Address string
+	/* the mount of the kv engine */
+	Mount string
+	/* the version of the kv engine */
+	Version int
+	/* the field holding the content */
+	Field string
+	/* whether the JSON of a file is split into the fields of the secret */
+	Split bool
+	/* the interval we poll for changes */
+	Poll time.Duration
+	/* the http client */
+	Client *http.Client
+	/* the token and the lock protecting it */
+	Token     string
+	TokenLock sync.RWMutex
+	/* stops the renewal of the token */
+	Stop context.CancelFunc
+}
+
+type vaultResponse struct {
+	Data          json.RawMessage `json:"data"`
+	LeaseDuration int64           `json:"lease_duration"`
+	Auth          *struct {
+		LeaseDuration int64 `json:"lease_duration"`
+		Renewable     bool  `json:"renewable"`
+	} `json:"auth"`
+	Errors []string `json:"errors"`
+}
+
+type vaultSecretV2 struct {
+	Data     map[string]interface{} `json:"data"`
+	Metadata struct {
+		CreatedTime  string `json:"created_time"`
+		DeletionTime string `json:"deletion_time"`
+		Destroyed    bool   `json:"destroyed"`
+		Version      uint64 `json:"version"`
+	} `json:"metadata"`
+}
+
+type vaultTokenLookup struct {
+	TTL       int64 `json:"ttl"`
+	Renewable bool  `json:"renewable"`
+}
+
+func NewVaultStoreClient(uri *url.URL) (KVStore, error) {
+	glog.Infof("Creating a Vault Agent for K/V Store, host: %s, mount: %s", uri.Host, uri.Path)
+	if uri.Scheme != "vault" {
+		glog.Errorf("Invalid url scheme: %s, must start with vault", uri.Scheme)
+		return nil, InvalidUrlErr
+	}
+	params := uri.Query()
+	store := &VaultStoreClient{
+		Mount:   strings.Trim(uri.Path, "/"),
+		Version: 2,
+		Field:   VAULT_DEFAULT_FIELD,
+		Poll:    VAULT_DEFAULT_POLL,
+		Token:   *vault_token}
+	if store.Mount == "" {
+		store.Mount = "secret"
+	}
+	if store.Token == "" {
+		store.Token = os.Getenv("VAULT_TOKEN")
+	}
+	if value := params.Get("token"); value != "" {
+		store.Token = value
+	}
+	if value := params.Get("kv"); value != "" {
+		version, err := strconv.Atoi(value)
+		if err != nil || version < 1 || version > 2 {
+			glog.Errorf("Invalid kv version: %s in url, must be 1 or 2", value)
+			return nil, InvalidUrlErr
+		}
+		store.Version = version
+	}
+	if value := params.Get("field"); value != "" {
+		store.Field = value
+	}
+	if value := params.Get("split"); value != "" {
+		split, err := strconv.ParseBool(value)
+		if err != nil {
+			glog.Errorf("Invalid split option: %s in url", value)
+			return nil, InvalidUrlErr
+		}
+		store.Split = split
+	}
+	if value := params.Get("poll"); value != "" {
+		poll, err := time.ParseDuration(value)
+		if err != nil || poll <= 0 {
+			glog.Errorf("Invalid poll interval: %s in url", value)
+			return nil, InvalidUrlErr
+		}
+		store.Poll = poll
+	}
+	options, err := GetTLSOptions(uri)
+	if err != nil {
+		return nil, err
+	}
+	transport, err := options.Transport()
+	if err != nil {
+		glog.Errorf("Failed to create the transport for vault, error: %s", err)
+		return nil, err
+	}
+	store.Address = options.Scheme() + "://" + uri.Host
+	store.Client = &http.Client{Transport: transport}
+	/* step: keep the token alive until the store is closed */
+	ctx, cancel := context.WithCancel(context.Background())
+	store.Stop = cancel
+	go store.RenewToken(ctx)
+	return store, nil
+}
+
+func (r *VaultStoreClient) Get(ctx context.Context, key string) (*Node, error) {
+	key = r.KeyPath(key)
+	Verbose("Get() key: %s", key)
+	if key == "/" {
+		return &Node{Path: key, Directory: true}, nil
+	}
+	version := ""
+	if index, found := VersionFrom(ctx); found && r.Version == 2 {
+		version = strconv.FormatUint(index, 10)
+	}
+	node, err := r.Read(ctx, key, version)
+	if err == NodeNotFoundErr && version == "" {
+		/* step: not a secret, but it could be a directory of them */
+		if _, err := r.ListKeys(ctx, key); err == nil {
+			return &Node{Path: key, Directory: true}, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	node.Path = key
+	return node, nil
+}
+
+/* Read the secret, at the version if one is given */
+func (r *VaultStoreClient) Read(ctx context.Context, key, version string) (*Node, error) {
+	response := new(vaultResponse)
+	if r.Version == 1 {
+		if err := r.Request(ctx, "GET", r.Mount+key, nil, response); err != nil {
+			return nil, err
+		}
+		fields := make(map[string]interface{}, 0)
+		if err := json.Unmarshal(response.Data, &fields); err != nil {
+			return nil, err
+		}
+		return &Node{Path: key, Value: r.EncodeFields(fields)}, nil
+	}
+	path := r.Mount + "/data" + key
+	if version != "" {
+		path += "?version=" + version
+	}
+	if err := r.Request(ctx, "GET", path, nil, response); err != nil {
+		return nil, err
+	}
+	secret := new(vaultSecretV2)
+	if err := json.Unmarshal(response.Data, secret); err != nil {
+		return nil, err
+	}
+	/* step: a deleted or destroyed version has no data */
+	if secret.Data == nil {
+		return nil, NodeNotFoundErr
+	}
+	return &Node{
+		Path:  key,
+		Value: r.EncodeFields(secret.Data),
+		Index: secret.Metadata.Version,
+		Metadata: map[string]string{
+			"vault.version":      strconv.FormatUint(secret.Metadata.Version, 10),
+			"vault.created_time": secret.Metadata.CreatedTime}}, nil
+}
+
+func (r *VaultStoreClient) Set(ctx context.Context, key string, value string) error {
+	Verbose("Set() key: %s", key)
+	return r.Write(ctx, r.KeyPath(key), value, -1)
+}
+
+/* Secrets in the kv engine don't expire */
+func (r *VaultStoreClient) SetWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error {
+	if ttl > 0 {
+		return TTLNotSupportedErr
+	}
+	return r.Set(ctx, key, value)
+}
+
+/* Write the secret; on version 2 a cas of zero or more only writes if the secret is at that version */
+func (r *VaultStoreClient) Write(ctx context.Context, key, value string, cas int64) error {
+	fields := r.DecodeFields(value)
+	var request interface{} = fields
+	path := r.Mount + key
+	if r.Version == 2 {
+		path = r.Mount + "/data" + key
+		secret := map[string]interface{}{"data": fields}
+		if cas >= 0 {
+			secret["options"] = map[string]interface{}{"cas": cas}
+		}
+		request = secret
+	}
+	if err := r.Request(ctx, "POST", path, request, nil); err != nil {
+		glog.Errorf("Failed to set the key: %s, error: %s", key, err)
+		return err
+	}
+	return nil
+}
+
+/* Deleting a key removes the secret along with all its versions */
+func (r *VaultStoreClient) Delete(ctx context.Context, key string) error {
+	key = r.KeyPath(key)
+	Verbose("Delete() deleting the key: %s", key)
+	path := r.Mount + key
+	if r.Version == 2 {
+		path = r.Mount + "/metadata" + key
+	}
+	if err := r.Request(ctx, "DELETE", path, nil, nil); err != nil {
+		glog.Errorf("Delete() failed to delete key: %s, error: %s", key, err)
+		return err
+	}
+	return nil
+}
+
+/*
+On version 2 the swap is made with check-and-set on the version, comparing on the value we
+read the version it's at first; version 1 has no check-and-set so it's compare then set and a
+writer in between the two can be lost
+*/
+func (r *VaultStoreClient) CompareAndSwap(ctx context.Context, key string, oldValue string, oldIndex uint64, value string) error {
+	key = r.KeyPath(key)
+	Verbose("CompareAndSwap() key: %s, index: %d", key, oldIndex)
+	node, err := r.Read(ctx, key, "")
+	if err != nil {
+		return err
+	}
+	if !node.Unchanged(oldValue, oldIndex) {
+		return CompareFailedErr
+	}
+	if r.Version == 1 {
+		return r.Write(ctx, key, value, -1)
+	}
+	if err := r.Write(ctx, key, value, int64(node.Index)); err != nil {
+		if strings.Contains(err.Error(), "check-and-set") {
+			return CompareFailedErr
+		}
+		return err
+	}
+	return nil
+}
+
+/* Secrets in the kv engine don't expire */
+func (r *VaultStoreClient) CompareAndSwapWithTTL(ctx context.Context, key string, oldValue string, oldIndex uint64, value string, ttl time.Duration) error {
+	if ttl > 0 {
+		return TTLNotSupportedErr
+	}
+	return r.CompareAndSwap(ctx, key, oldValue, oldIndex, value)
+}
+
+/* There's no check-and-set on deletes, so this is compare then delete on either version */
+func (r *VaultStoreClient) CompareAndDelete(ctx context.Context, key string, oldValue string, oldIndex uint64) error {
+	key = r.KeyPath(key)
+	Verbose("CompareAndDelete() key: %s, index: %d", key, oldIndex)
+	node, err := r.Read(ctx, key, "")
+	if err != nil {
+		return err
+	}
+	if !node.Unchanged(oldValue, oldIndex) {
+		return CompareFailedErr
+	}
+	return r.Delete(ctx, key)
+}
+
+func (r *VaultStoreClient) Txn(ctx context.Context, operations []*Operation) error {
+	return EmulateTxn(ctx, r, operations)
+}
+
+func (r *VaultStoreClient) RemovePath(ctx context.Context, path string) error {
+	path = r.KeyPath(path)
+	Verbose("RemovePath() deleting the path: %s", path)
+	nodes, err := r.List(ctx, path)
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		if node.IsDir() {
+			err = r.RemovePath(ctx, node.Path)
+		} else {
+			err = r.Delete(ctx, node.Path)
+		}
+		if err != nil && err != NodeNotFoundErr {
+			return err
+		}
+	}
+	return nil
+}
+
+/* There are no directories in vault, they only exist while there are secrets under them */
+func (r *VaultStoreClient) Mkdir(ctx context.Context, path string) error {
+	Verbose("Mkdir() path: %s", path)
+	return nil
+}
+
+/* The listing only has the names, the values are read as the keys are */
+func (r *VaultStoreClient) List(ctx context.Context, path string) ([]*Node, error) {
+	path = r.KeyPath(path)
+	Verbose("List() path: %s", path)
+	keys, err := r.ListKeys(ctx, path)
+	if err == NodeNotFoundErr {
+		if path == "/" {
+			return []*Node{}, nil
+		}
+		if _, err := r.Read(ctx, path, ""); err == nil {
+			glog.Errorf("List() path: %s is not a directory node", path)
+			return nil, InvalidDirectoryErr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*Node, 0)
+	prefix := strings.TrimSuffix(path, "/") + "/"
+	for _, key := range keys {
+		if strings.HasSuffix(key, "/") {
+			list = append(list, &Node{Path: prefix + strings.TrimSuffix(key, "/"), Directory: true})
+		} else {
+			list = append(list, &Node{Path: prefix + key})
+		}
+	}
+	return list, nil
+}
+
+/* The names under the path, the directories end with a slash */
+func (r *VaultStoreClient) ListKeys(ctx context.Context, path string) ([]string, error) {
+	location := r.Mount + strings.TrimSuffix(path, "/")
+	if r.Version == 2 {
+		location = r.Mount + "/metadata" + strings.TrimSuffix(path, "/")
+	}
+	response := new(vaultResponse)
+	if err := r.Request(ctx, "LIST", location, nil, response); err != nil {
+		return nil, err
+	}
+	var listing struct {
+		Keys []string `json:"keys"`
+	}
+	if err := json.Unmarshal(response.Data, &listing); err != nil {
+		return nil, err
+	}
+	return listing.Keys, nil
+}
+
+/*
+Vault has no watches, so we poll the tree under the key and diff it against what we saw last;
+on version 2 it's the versions we compare, on version 1 the values
+*/
+func (r *VaultStoreClient) Watch(ctx context.Context, key string) (<-chan NodeChange, context.CancelFunc, error) {
+	key = r.KeyPath(key)
+	Verbose("Watch() key: %s, poll: %s", key, r.Poll)
+	/* step: the changes are from the moment of the call, so we take the first snapshot here */
+	snapshot, err := r.Snapshot(ctx, key)
+	if err != nil {
+		glog.Errorf("Watch() failed to read the tree under key: %s, error: %s", key, err)
+		return nil, nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	updateChannel := make(chan NodeChange)
+	go func() {
+		defer close(updateChannel)
+		for {
+			select {
+			case <-ctx.Done():
+				glog.V(3).Infof("Watch() exitting the watch on key: %s", key)
+				return
+			case <-time.After(r.Poll):
+			}
+			current, err := r.Snapshot(ctx, key)
+			if err != nil {
+				glog.Errorf("Watch() failed to read the tree under key: %s, error: %s", key, err)
+				WatchReconnected("vault")
+				continue
+			}
+			for _, event := range r.GetSnapshotEvents(snapshot, current) {
+				Verbose("Watch() sending the change for key: %s upstream", event.Node.Path)
+				select {
+				case updateChannel <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+			snapshot = current
+		}
+	}()
+	return updateChannel, cancel, nil
+}
+
+/* The secrets under the key, by path */
+func (r *VaultStoreClient) Snapshot(ctx context.Context, key string) (map[string]*Node, error) {
+	snapshot := make(map[string]*Node, 0)
+	nodes, err := Walk(ctx, r, key)
+	if err == NodeNotFoundErr {
+		return snapshot, nil
+	}
+	if err == InvalidDirectoryErr {
+		node, err := r.Read(ctx, key, "")
+		if err != nil {
+			return nil, err
+		}
+		nodes = []*Node{node}
+	} else if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		snapshot[node.Path] = node
+	}
+	return snapshot, nil
+}
+
+func (r *VaultStoreClient) GetSnapshotEvents(previous, current map[string]*Node) []NodeChange {
+	events := make([]NodeChange, 0)
+	for path, node := range current {
+		if last, found := previous[path]; !found || last.Index != node.Index || last.Value != node.Value {
+			events = append(events, NodeChange{*node, CHANGED})
+		}
+	}
+	for path, node := range previous {
+		if _, found := current[path]; !found {
+			events = append(events, NodeChange{*node, DELETED})
+		}
+	}
+	sort.Sort(changesByPath(events))
+	return events
+}
+
+type changesByPath []NodeChange
+
+func (r changesByPath) Len() int           { return len(r) }
+func (r changesByPath) Less(i, j int) bool { return r[i].Node.Path < r[j].Node.Path }
+func (r changesByPath) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+
+/*
+Renew the token at half its ttl for as long as it's renewable; a failed renewal is retried with
+a backoff until the token expires. A token which isn't renewable, or a root token without a ttl,
+is left alone
+*/
+func (r *VaultStoreClient) RenewToken(ctx context.Context) {
+	lookup := new(vaultTokenLookup)
+	err := r.Retry(ctx, time.Time{}, func() error {
+		response := new(vaultResponse)
+		if err := r.Request(ctx, "GET", "auth/token/lookup-self", nil, response); err != nil {
+			return err
+		}
+		return json.Unmarshal(response.Data, lookup)
+	})
+	if err != nil {
+		if ctx.Err() == nil {
+			glog.Warningf("Failed to lookup the vault token, error: %s", err)
+		}
+		return
+	}
+	if !lookup.Renewable || lookup.TTL <= 0 {
+		Verbose("RenewToken() the token isn't renewable, ttl: %d", lookup.TTL)
+		return
+	}
+	ttl := time.Duration(lookup.TTL) * time.Second
+	for {
+		expires := time.Now().Add(ttl)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(ttl / 2):
+		}
+		response := new(vaultResponse)
+		err := r.Retry(ctx, expires, func() error {
+			return r.Request(ctx, "POST", "auth/token/renew-self", map[string]interface{}{}, response)
+		})
+		if err != nil {
+			if ctx.Err() == nil {
+				glog.Errorf("Failed to renew the vault token, error: %s", err)
+			}
+			return
+		}
+		if response.Auth == nil || time.Duration(response.Auth.LeaseDuration)*time.Second <= time.Until(expires) {
+			glog.Warningf("The vault token has reached its max ttl and will expire at: %s", expires)
+			return
+		}
+		ttl = time.Duration(response.Auth.LeaseDuration) * time.Second
+		glog.V(3).Infof("Renewed the vault token, ttl: %s", ttl)
+	}
+}
+
+/*
+Call the method until it succeeds, backing off between the attempts; we give up when the
+deadline passes (a zero deadline has none), the store is closed or vault denies the token
+*/
+func (r *VaultStoreClient) Retry(ctx context.Context, deadline time.Time, method func() error) error {
+	backoff := VAULT_RENEW_MIN_BACKOFF
+	for {
+		err := method()
+		if err == nil || err == VaultPermissionDeniedErr || ctx.Err() != nil {
+			return err
+		}
+		wait := backoff
+		if !deadline.IsZero() {
+			if remaining := time.Until(deadline); remaining < wait {
+				wait = remaining
+			}
+		}
+		if wait <= 0 {
+			return err
+		}
+		glog.Errorf("The request to vault failed, error: %s, retrying in %s", err, wait)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		if backoff *= 2; backoff > VAULT_RENEW_MAX_BACKOFF {
+			backoff = VAULT_RENEW_MAX_BACKOFF
+		}
+	}
+}
+
+/* Stop renewing the token and drop the connections to vault */
+func (r *VaultStoreClient) Close() error {
+	r.Stop()
+	r.Client.CloseIdleConnections()
+	return nil
+}
+
+/*
+The content of the file; the field verbatim, or when splitting the field on its own and
+otherwise the JSON of all of them
+*/
+func (r *VaultStoreClient) EncodeFields(fields map[string]interface{}) string {
+	value, found := fields[r.Field]
+	if content, ok := value.(string); ok && (!r.Split || len(fields) == 1) {
+		return content
+	}
+	if !r.Split {
+		if !found {
+			return ""
+		}
+		encoded, _ := json.Marshal(value)
+		return string(encoded)
+	}
+	encoded, _ := json.Marshal(fields)
+	return string(encoded)
+}
+
+func (r *VaultStoreClient) DecodeFields(value string) map[string]interface{} {
+	fields := make(map[string]interface{}, 0)
+	if r.Split && strings.HasPrefix(strings.TrimSpace(value), "{") && json.Unmarshal([]byte(value), &fields) == nil && len(fields) > 0 {
+		return fields
+	}
+	return map[string]interface{}{r.Field: value}
+}
+
+/* Make a request against the api; a not found comes back as NodeNotFoundErr */
+func (r *VaultStoreClient) Request(ctx context.Context, method, path string, request interface{}, result interface{}) error {
+	var body io.Reader
+	if request != nil {
+		content, err := json.Marshal(request)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(content)
+	}
+	req, err := http.NewRequest(method, r.Address+"/v1/"+strings.TrimPrefix(path, "/"), body)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	r.TokenLock.RLock()
+	if r.Token != "" {
+		req.Header.Set("X-Vault-Token", r.Token)
+	}
+	r.TokenLock.RUnlock()
+	response, err := r.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	content, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+	case http.StatusNotFound:
+		return NodeNotFoundErr
+	case http.StatusForbidden:
+		return VaultPermissionDeniedErr
+	default:
+		decoded := new(vaultResponse)
+		json.Unmarshal(content, decoded)
+		return fmt.Errorf("vault returned status: %d, errors: %s", response.StatusCode, strings.Join(decoded.Errors, ", "))
+	}
+	if result == nil || len(content) == 0 {
+		return nil
+	}
+	return json.Unmarshal(content, result)
+}
+
+func (r *VaultStoreClient) KeyPath(key string) string {
+	return filepath.Clean("/" + key)
+}
@@ -0,0 +1,323 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+/* Enough of the kv version 2 engine and the token api of vault to test against */
+type FakeVault struct {
+	sync.Mutex
+	/* the versions of the secrets, by path */
+	Secrets map[string][]map[string]interface{}
+	/* the ttl of the token in seconds */
+	TTL int64
+	/* the renewals which fail before one succeeds */
+	FailRenewals int
+	Renewals     int
+}
+
+func NewTestVault(t *testing.T, options string) (*VaultStoreClient, *FakeVault) {
+	vault := &FakeVault{Secrets: make(map[string][]map[string]interface{}, 0)}
+	server := httptest.NewServer(vault)
+	t.Cleanup(server.Close)
+	location, _ := url.Parse(server.URL)
+	uri, _ := url.Parse("vault://" + location.Host + "/secret?token=test" + options)
+	store, err := NewVaultStoreClient(uri)
+	if err != nil {
+		t.Fatalf("failed to create the vault store, error: %s", err)
+	}
+	t.Cleanup(func() { store.(*VaultStoreClient).Close() })
+	return store.(*VaultStoreClient), vault
+}
+
+func (r *FakeVault) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	r.Lock()
+	defer r.Unlock()
+	if request.Header.Get("X-Vault-Token") != "test" {
+		writer.WriteHeader(http.StatusForbidden)
+		return
+	}
+	path := strings.TrimPrefix(request.URL.Path, "/v1")
+	switch {
+	case path == "/auth/token/lookup-self":
+		r.Reply(writer, map[string]interface{}{"data": map[string]interface{}{"ttl": r.TTL, "renewable": r.TTL > 0}})
+	case path == "/auth/token/renew-self":
+		if r.FailRenewals > 0 {
+			r.FailRenewals--
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		r.Renewals++
+		r.Reply(writer, map[string]interface{}{"auth": map[string]interface{}{"lease_duration": r.TTL, "renewable": true}})
+	case strings.HasPrefix(path, "/secret/data/"):
+		r.Data(writer, request, strings.TrimPrefix(path, "/secret/data"))
+	case strings.HasPrefix(path, "/secret/metadata"):
+		r.Metadata(writer, request, strings.TrimPrefix(path, "/secret/metadata"))
+	default:
+		writer.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (r *FakeVault) Data(writer http.ResponseWriter, request *http.Request, key string) {
+	versions := r.Secrets[key]
+	switch request.Method {
+	case "GET":
+		version := len(versions)
+		if requested := request.URL.Query().Get("version"); requested != "" {
+			version, _ = strconv.Atoi(requested)
+		}
+		if version <= 0 || version > len(versions) {
+			writer.WriteHeader(http.StatusNotFound)
+			return
+		}
+		r.Reply(writer, map[string]interface{}{"data": map[string]interface{}{
+			"data":     versions[version-1],
+			"metadata": map[string]interface{}{"version": version}}})
+	case "POST":
+		var secret struct {
+			Data    map[string]interface{} `json:"data"`
+			Options struct {
+				CAS *int `json:"cas"`
+			} `json:"options"`
+		}
+		json.NewDecoder(request.Body).Decode(&secret)
+		if secret.Options.CAS != nil && *secret.Options.CAS != len(versions) {
+			writer.WriteHeader(http.StatusBadRequest)
+			r.Reply(writer, map[string]interface{}{"errors": []string{"check-and-set parameter did not match the current version"}})
+			return
+		}
+		r.Secrets[key] = append(versions, secret.Data)
+		writer.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (r *FakeVault) Metadata(writer http.ResponseWriter, request *http.Request, path string) {
+	switch request.Method {
+	case "DELETE":
+		delete(r.Secrets, path)
+		writer.WriteHeader(http.StatusNoContent)
+	case "LIST":
+		found := make(map[string]bool, 0)
+		prefix := strings.TrimSuffix(path, "/") + "/"
+		for key := range r.Secrets {
+			if strings.HasPrefix(key, prefix) {
+				name := strings.TrimPrefix(key, prefix)
+				if index := strings.Index(name, "/"); index >= 0 {
+					name = name[:index+1]
+				}
+				found[name] = true
+			}
+		}
+		if len(found) == 0 {
+			writer.WriteHeader(http.StatusNotFound)
+			return
+		}
+		keys := make([]string, 0)
+		for name := range found {
+			keys = append(keys, name)
+		}
+		sort.Strings(keys)
+		r.Reply(writer, map[string]interface{}{"data": map[string]interface{}{"keys": keys}})
+	}
+}
+
+func (r *FakeVault) Reply(writer http.ResponseWriter, response interface{}) {
+	json.NewEncoder(writer).Encode(response)
+}
+
+func TestVaultFields(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Options string
+		Value   string
+		Fields  map[string]interface{}
+		Content string
+	}{
+		{"verbatim", "", "hello", map[string]interface{}{"value": "hello"}, "hello"},
+		{"verbatim json", "", `{"user": "admin"}`, map[string]interface{}{"value": `{"user": "admin"}`}, `{"user": "admin"}`},
+		{"verbatim field", "&field=content", "line one\nline two\n", map[string]interface{}{"content": "line one\nline two\n"}, "line one\nline two\n"},
+		{"split", "&split=true", "hello", map[string]interface{}{"value": "hello"}, "hello"},
+		{"split json", "&split=true", `{"user": "admin"}`, map[string]interface{}{"user": "admin"}, `{"user":"admin"}`},
+	}
+	for _, test := range tests {
+		store, vault := NewTestVault(t, test.Options)
+		ctx := context.Background()
+		if err := store.Set(ctx, "/app/config", test.Value); err != nil {
+			t.Errorf("%s: failed to set the key, error: %s", test.Name, err)
+			continue
+		}
+		vault.Lock()
+		stored := vault.Secrets["/app/config"][0]
+		vault.Unlock()
+		if encoded, expected := ToJSON(stored), ToJSON(test.Fields); encoded != expected {
+			t.Errorf("%s: expected the fields: %s, got: %s", test.Name, expected, encoded)
+		}
+		node, err := store.Get(ctx, "/app/config")
+		if err != nil {
+			t.Errorf("%s: failed to get the key, error: %s", test.Name, err)
+			continue
+		}
+		if node.Value != test.Content {
+			t.Errorf("%s: expected: %q, got: %q", test.Name, test.Content, node.Value)
+		}
+	}
+}
+
+func TestVaultSecretWithOtherFields(t *testing.T) {
+	store, vault := NewTestVault(t, "")
+	vault.Lock()
+	vault.Secrets["/db"] = []map[string]interface{}{{"value": "password", "user": "admin"}}
+	vault.Secrets["/api"] = []map[string]interface{}{{"key": "abc"}}
+	vault.Unlock()
+	ctx := context.Background()
+	tests := []struct {
+		Key   string
+		Value string
+	}{
+		{"/db", "password"},
+		{"/api", ""},
+	}
+	for _, test := range tests {
+		node, err := store.Get(ctx, test.Key)
+		if err != nil || node.Value != test.Value {
+			t.Errorf("key: %s, expected the field: %q, got: %v, error: %v", test.Key, test.Value, node, err)
+		}
+	}
+}
+
+func TestVaultCompareAndSwap(t *testing.T) {
+	store, _ := NewTestVault(t, "")
+	ctx := context.Background()
+	store.Set(ctx, "/key", "first")
+	node, err := store.Get(ctx, "/key")
+	if err != nil {
+		t.Fatalf("failed to get the key, error: %s", err)
+	}
+	store.Set(ctx, "/key", "second")
+	if err := store.CompareAndSwap(ctx, "/key", node.Value, node.Index, "third"); err != CompareFailedErr {
+		t.Errorf("expected the compare to fail on a stale version, got: %v", err)
+	}
+	node, _ = store.Get(ctx, "/key")
+	if err := store.CompareAndSwap(ctx, "/key", node.Value, node.Index, "third"); err != nil {
+		t.Errorf("failed to swap the key, error: %s", err)
+	}
+	if node, _ := store.Get(ctx, "/key"); node.Value != "third" || node.Index != 3 {
+		t.Errorf("expected the third version, got: %v", node)
+	}
+}
+
+func TestVaultVersions(t *testing.T) {
+	store, _ := NewTestVault(t, "")
+	ctx := context.Background()
+	for _, value := range []string{"first", "second", "third"} {
+		store.Set(ctx, "/key", value)
+	}
+	store.Set(ctx, "/key@2", "not a version")
+	tests := []struct {
+		Key     string
+		Version uint64
+		Value   string
+		Index   uint64
+	}{
+		{"/key", 0, "third", 3},
+		{"/key", 1, "first", 1},
+		{"/key", 2, "second", 2},
+		{"/key@2", 0, "not a version", 1},
+	}
+	for _, test := range tests {
+		read := ctx
+		if test.Version > 0 {
+			read = WithVersion(ctx, test.Version)
+		}
+		node, err := store.Get(read, test.Key)
+		if err != nil {
+			t.Errorf("key: %s, version: %d, failed to get the key, error: %s", test.Key, test.Version, err)
+			continue
+		}
+		if node.Value != test.Value || node.Index != test.Index || node.Path != test.Key {
+			t.Errorf("key: %s, version: %d, expected: %q at %d, got: %v", test.Key, test.Version, test.Value, test.Index, node)
+		}
+	}
+	if _, err := store.Get(WithVersion(ctx, 4), "/key"); err != NodeNotFoundErr {
+		t.Errorf("expected a missing version not to be found, got: %v", err)
+	}
+}
+
+func TestVaultList(t *testing.T) {
+	store, _ := NewTestVault(t, "")
+	ctx := context.Background()
+	for _, key := range []string{"/app/a", "/app/db/url", "/other"} {
+		store.Set(ctx, key, "value")
+	}
+	expected := []string{"/app/a", "/app/db"}
+	if names := ListNames(t, store, "/app"); !EqualStrings(names, expected) {
+		t.Errorf("expected the listing: %v, got: %v", expected, names)
+	}
+	if node, err := store.Get(ctx, "/app/db"); err != nil || !node.IsDir() {
+		t.Errorf("expected a directory, got: %v, error: %v", node, err)
+	}
+	if _, err := store.List(ctx, "/other"); err != InvalidDirectoryErr {
+		t.Errorf("expected the secret not to be a directory, got: %v", err)
+	}
+}
+
+func TestVaultRenewToken(t *testing.T) {
+	store, vault := NewTestVault(t, "")
+	vault.Lock()
+	vault.TTL = 1
+	vault.FailRenewals = 1
+	vault.Unlock()
+	/* step: the renewal started by the constructor saw a token without a ttl and exited */
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan bool)
+	go func() {
+		store.RenewToken(ctx)
+		close(done)
+	}()
+	/* step: the first renewal fails and is retried before the token expires */
+	for deadline := time.Now().Add(3 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		vault.Lock()
+		renewals := vault.Renewals
+		vault.Unlock()
+		if renewals >= 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the token to be renewed, renewals: %d", renewals)
+		}
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("the renewal wasn't stopped")
+	}
+}
+
+func ToJSON(value interface{}) string {
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/gambol99/config-store/store/cache"
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var control_socket *string
+
+var NoSuchMountErr = errors.New("There is no such mount")
+var TemplatesNotSupportedErr = errors.New("The templates are not supported by this build")
+
+func init() {
+	control_socket = flag.String("control", "", "the unix socket the control api listens on, i.e. /var/run/config-store.sock, none if empty")
+}
+
+/*
+The control api, http over a unix socket, i.e. curl --unix-socket /var/run/config-store.sock
+http://localhost/status. It lets an operator look at a running daemon and nudge it:
+
+	GET  /status               the mounts and their options
+	GET  /cache                the size, hits, misses and evictions of the caches
+	POST /cache/flush[?mount=] empty the cache of the mount, or of them all
+	POST /resync[?mount=]      read everything from the backend again, with a new watch
+	GET  /watches              the watches on the backends
+	GET  /templates            the render status of the templates
+	POST /reload               reload the settings and the mounts, as SIGHUP does
+	GET  /metrics              the metrics in the prometheus text format
+
+the socket is only accessible to the user running the daemon
+*/
+type ControlServer struct {
+	/* the mounts being served */
+	Mounts func() []*ReloadableFileSystem
+	/* reload the settings and the mounts */
+	Reload func() error
+	/* when the daemon was started */
+	Started time.Time
+	/* the listener on the socket */
+	Listener net.Listener
+}
+
+/* The mount as reported by the control api */
+type MountStatus struct {
+	Mount   string           `json:"mount"`
+	Backend string           `json:"backend"`
+	Prefix  string           `json:"prefix"`
+	Layers  []string         `json:"layers,omitempty"`
+	Mode    string           `json:"mode"`
+	Cache   string           `json:"cache"`
+	Created time.Time        `json:"created"`
+	Stats   cache.CacheStats `json:"stats"`
+}
+
+/* A watch as reported by the control api */
+type WatchReport struct {
+	Mount     string     `json:"mount"`
+	Backend   string     `json:"backend"`
+	Path      string     `json:"path"`
+	Started   time.Time  `json:"started"`
+	Events    int64      `json:"events"`
+	LastEvent *time.Time `json:"last_event,omitempty"`
+	Active    bool       `json:"active"`
+}
+
+/* Start the control api if there's a socket for it, nil if not */
+func NewControlServer(mounts func() []*ReloadableFileSystem, reload func() error) (*ControlServer, error) {
+	if *control_socket == "" {
+		return nil, nil
+	}
+	/* step: remove the socket left behind by a previous run */
+	if stat, err := os.Stat(*control_socket); err == nil && stat.Mode()&os.ModeSocket != 0 {
+		os.Remove(*control_socket)
+	}
+	listener, err := net.Listen("unix", *control_socket)
+	if err != nil {
+		glog.Errorf("Failed to listen on the control socket: %s, error: %s", *control_socket, err)
+		return nil, err
+	}
+	if err := os.Chmod(*control_socket, 0600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	server := &ControlServer{Mounts: mounts, Reload: reload, Started: time.Now(), Listener: listener}
+	router := http.NewServeMux()
+	router.HandleFunc("/status", server.Handler("GET", server.Status))
+	router.HandleFunc("/cache", server.Handler("GET", server.CacheStats))
+	router.HandleFunc("/cache/flush", server.Handler("POST", server.CacheFlush))
+	router.HandleFunc("/resync", server.Handler("POST", server.Resync))
+	router.HandleFunc("/watches", server.Handler("GET", server.Watches))
+	router.HandleFunc("/templates", server.Handler("GET", server.Templates))
+	router.HandleFunc("/reload", server.Handler("POST", server.ReloadSettings))
+	router.Handle("/metrics", promhttp.Handler())
+	go func() {
+		if err := http.Serve(listener, router); err != nil {
+			glog.V(3).Infof("The control api has stopped, error: %s", err)
+		}
+	}()
+	glog.Infof("The control api is listening on: %s", *control_socket)
+	return server, nil
+}
+
+/* Stop listening and remove the socket */
+func (r *ControlServer) Close() {
+	if r != nil {
+		r.Listener.Close()
+	}
+}
+
+/* Wrap the handler to check the method and write what it returns, or the error, as json */
+func (r *ControlServer) Handler(method string, handler func(*http.Request) (interface{}, int, error)) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		var result interface{}
+		code := http.StatusMethodNotAllowed
+		err := errors.New("The method must be " + method)
+		if request.Method == method {
+			result, code, err = handler(request)
+		}
+		if err != nil {
+			result = map[string]string{"error": err.Error()}
+		}
+		glog.V(3).Infof("Control api, %s %s, code: %d", request.Method, request.URL, code)
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(code)
+		json.NewEncoder(writer).Encode(result)
+	}
+}
+
+/* The mounts the request is for, the one named by ?mount= or all of them */
+func (r *ControlServer) Selected(request *http.Request) ([]*ReloadableFileSystem, error) {
+	mounts := r.Mounts()
+	name := request.URL.Query().Get("mount")
+	if name == "" {
+		return mounts, nil
+	}
+	for _, mount := range mounts {
+		if mount.FS().Options.Mount == name {
+			return []*ReloadableFileSystem{mount}, nil
+		}
+	}
+	return nil, NoSuchMountErr
+}
+
+func (r *ControlServer) Status(request *http.Request) (interface{}, int, error) {
+	mounts := make([]*MountStatus, 0)
+	for _, mount := range r.Mounts() {
+		filesystem := mount.FS()
+		options := filesystem.Options
+		mounts = append(mounts, &MountStatus{
+			Mount:   options.Mount,
+			Backend: filesystem.Backend,
+			Prefix:  options.Prefix,
+			Layers:  options.Layers,
+			Mode:    options.Mode,
+			Cache:   options.Cache,
+			Created: filesystem.BigBang,
+			Stats:   filesystem.Cache.Stats()})
+	}
+	sort.Sort(MountsByPoint(mounts))
+	return map[string]interface{}{
+		"pid":     os.Getpid(),
+		"started": r.Started,
+		"uptime":  time.Since(r.Started).String(),
+		"mounts":  mounts}, http.StatusOK, nil
+}
+
+func (r *ControlServer) CacheStats(request *http.Request) (interface{}, int, error) {
+	stats := make(map[string]cache.CacheStats, 0)
+	for _, mount := range r.Mounts() {
+		filesystem := mount.FS()
+		stats[filesystem.Options.Mount] = filesystem.Cache.Stats()
+	}
+	return stats, http.StatusOK, nil
+}
+
+func (r *ControlServer) CacheFlush(request *http.Request) (interface{}, int, error) {
+	mounts, err := r.Selected(request)
+	if err != nil {
+		return nil, http.StatusNotFound, err
+	}
+	flushed := make([]string, 0)
+	for _, mount := range mounts {
+		filesystem := mount.FS()
+		glog.Infof("Flushing the cache of the mount: %s, as asked by the control api", filesystem.Options.Mount)
+		if err := filesystem.Cache.Flush(); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		flushed = append(flushed, filesystem.Options.Mount)
+	}
+	return map[string][]string{"flushed": flushed}, http.StatusOK, nil
+}
+
+func (r *ControlServer) Resync(request *http.Request) (interface{}, int, error) {
+	mounts, err := r.Selected(request)
+	if err != nil {
+		return nil, http.StatusNotFound, err
+	}
+	resynced := make([]string, 0)
+	for _, mount := range mounts {
+		glog.Infof("Resyncing the mount: %s, as asked by the control api", mount.FS().Options.Mount)
+		if err := mount.Resync(); err != nil {
+			return nil, http.StatusBadGateway, err
+		}
+		resynced = append(resynced, mount.FS().Options.Mount)
+	}
+	return map[string][]string{"resynced": resynced}, http.StatusOK, nil
+}
+
+func (r *ControlServer) Watches(request *http.Request) (interface{}, int, error) {
+	watches := make([]*WatchReport, 0)
+	for _, mount := range r.Mounts() {
+		filesystem := mount.FS()
+		report := &WatchReport{Mount: filesystem.Options.Mount, Backend: filesystem.Backend}
+		/* step: the filesystem has no watch if it couldn't create one */
+		if watch := filesystem.Watch; watch != nil {
+			report.Path, report.Started = watch.Path, watch.Started
+			report.Events = atomic.LoadInt64(&watch.Events)
+			report.Active = atomic.LoadInt32(&watch.Closed) == 0
+			if last := atomic.LoadInt64(&watch.LastEvent); last > 0 {
+				stamp := time.Unix(last, 0)
+				report.LastEvent = &stamp
+			}
+		}
+		watches = append(watches, report)
+	}
+	return watches, http.StatusOK, nil
+}
+
+/* The templates aren't rendered by the daemon yet, so there's nothing to report */
+func (r *ControlServer) Templates(request *http.Request) (interface{}, int, error) {
+	return nil, http.StatusNotImplemented, TemplatesNotSupportedErr
+}
+
+func (r *ControlServer) ReloadSettings(request *http.Request) (interface{}, int, error) {
+	glog.Infof("Reloading the settings and the mounts, as asked by the control api")
+	if err := r.Reload(); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return map[string]string{"status": "reloaded"}, http.StatusOK, nil
+}
+
+type MountsByPoint []*MountStatus
+
+func (r MountsByPoint) Len() int           { return len(r) }
+func (r MountsByPoint) Less(i, j int) bool { return r[i].Mount < r[j].Mount }
+func (r MountsByPoint) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
@@ -22,7 +22,7 @@ const (
 )
 
 func Verbose(message string, args ...interface {}) {
-	glog.V(AGENT_VERBOSE_LEVEL).Infof(message, args)
+	glog.V(AGENT_VERBOSE_LEVEL).Infof(message, args...)
 }
 
 type DiscoveryAgent interface {
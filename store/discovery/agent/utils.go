@@ -21,7 +21,7 @@ import (
 )
 
 func NewConsulServiceAgent(uri *url.URL) (DiscoveryAgent, error) {
-	glog.V(3).Infof("Creating a Consul Discovery Agent, url: %s", uri)
+	glog.V(3).Infof("Creating a Consul Discovery Agent, host: %s", uri.Host)
 	config := consulapi.DefaultConfig()
 	config.Address = uri.Host
 	client, err := consulapi.NewClient(config)
@@ -14,72 +14,201 @@ limitations under the License.
 package store
 
 import (
+	"context"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/gambol99/config-store/store/config"
-	"github.com/hanwen/go-fuse/fuse"
-	"github.com/hanwen/go-fuse/fuse/nodefs"
+	"github.com/hanwen/go-fuse/v2/fuse"
+	"github.com/hanwen/go-fuse/v2/fuse/nodefs"
 	"github.com/golang/glog"
 )
 
 type KVFile struct {
+	sync.Mutex
 	/* The path / key of the file */
 	Path string
 	/* The value of the key */
 	Value string
 	/* The KVStore provider */
 	StoreKV config.KVStore
+	/* the node as it was when the file was opened for writing, nil if read only */
+	Node *config.Node
+	/* the ownership and modes of the file */
+	Permissions *Permissions
+	/* the content being written */
+	Buffer []byte
+	/* the buffer has changes which haven't been flushed to the store */
+	Dirty bool
+	/* the audit log and the caller who opened the file */
+	Audit  *Auditor
+	Caller fuse.Context
+	/* called once the file is released, so the filesystem knows we're done with it */
+	Done func()
 }
 
-func NewKVFile(path string, store config.KVStore) nodefs.File {
+func NewKVFile(path string, store config.KVStore, permissions *Permissions) *KVFile {
 	Verbose("Creating K/V File, path: %s", path)
 	file := new(KVFile)
 	file.Path = path
 	file.StoreKV = store
+	file.Permissions = permissions
 	return file
 }
 
+/*
+Open the file for writing; we keep the revision of the key as it was at open, and the flush
+only goes through if nobody else has changed the key in the meantime
+*/
+func (f *KVFile) OpenWriter(truncate bool) fuse.Status {
+	ctx, cancel := ReadContext(nil)
+	defer cancel()
+	node, err := f.StoreKV.Get(ctx, f.Path)
+	if err != nil {
+		glog.Errorf("OpenWriter() file: %s failed to read, error: %s", f.Path, err)
+		return StoreStatus(err, fuse.EIO)
+	}
+	if node.IsDir() {
+		return fuse.Status(syscall.EISDIR)
+	}
+	f.Node = node
+	f.Buffer = []byte(node.Value)
+	if truncate {
+		f.Buffer = []byte{}
+		f.Dirty = true
+	}
+	return fuse.OK
+}
+
 func (f *KVFile) String() string {
 	return f.Path
 }
 
-func (f *KVFile) Read(buf []byte, off int64) (fuse.ReadResult, fuse.Status) {
-	if node, err := f.StoreKV.Get(f.Path); err != nil {
+func (f *KVFile) Read(buf []byte, off int64) (result fuse.ReadResult, code fuse.Status) {
+	defer Measure("read", time.Now(), &code)
+	f.Lock()
+	defer f.Unlock()
+	/* step: if we're writing, the reader sees what has been written so far */
+	if f.Node != nil {
+		return fuse.ReadResultData(Slice(f.Buffer, buf, off)), fuse.OK
+	}
+	ctx, cancel := ReadContext(nil)
+	defer cancel()
+	if node, err := f.StoreKV.Get(ctx, f.Path); err != nil {
 		glog.Errorf("Read() file: %s failed to read, error: %s", f.Path, err)
-		return nil, fuse.EIO
+		status := StoreStatus(err, fuse.EIO)
+		f.Audit.Record(&f.Caller, AUDIT_READ, f.Path, "", status, 0)
+		return nil, status
 	} else {
-		end := int(off) + int(len(buf))
-		if end > len(node.Value) {
-			end = len(node.Value)
+		/* step: a read of the file is recorded once, at the start of it */
+		if off == 0 {
+			f.Audit.Record(&f.Caller, AUDIT_READ, f.Path, "", fuse.OK, node.Index)
 		}
-		data := []byte(node.Value)
-		return fuse.ReadResultData(data[off:end]), fuse.OK
+		return fuse.ReadResultData(Slice([]byte(node.Value), buf, off)), fuse.OK
 	}
 }
 
+/* The part of the data covered by the read */
+func Slice(data []byte, buf []byte, off int64) []byte {
+	if off >= int64(len(data)) {
+		return []byte{}
+	}
+	end := int(off) + int(len(buf))
+	if end > len(data) {
+		end = len(data)
+	}
+	return data[off:end]
+}
+
 /*
-	We do not allow writing for the file. This should be handled at the K/V end and
-	not by the client
+	Writing is only allowed on a writable mount; the content is buffered and written to the
+	store on flush
 */
-func (f *KVFile) Write(data []byte, off int64) (uint32, fuse.Status) {
-	Verbose("Write: file: %s, data: %V, off: %d", f.Path, data, off)
-	return 0, fuse.EPERM
+func (f *KVFile) Write(data []byte, off int64) (written uint32, code fuse.Status) {
+	defer Measure("write", time.Now(), &code)
+	Verbose("Write: file: %s, data: %s, off: %d", f.Path, config.Redact(f.Path, string(data)), off)
+	f.Lock()
+	defer f.Unlock()
+	if f.Node == nil {
+		return 0, fuse.EPERM
+	}
+	if end := int(off) + len(data); end > len(f.Buffer) {
+		f.Buffer = append(f.Buffer, make([]byte, end-len(f.Buffer))...)
+	}
+	copy(f.Buffer[off:], data)
+	f.Dirty = true
+	return uint32(len(data)), fuse.OK
 }
 
-func (f *KVFile) Flush() fuse.Status {
+/*
+	Write the buffer to the store, as long as the key is unchanged since we opened it; if
+	someone else has changed it we fail with EAGAIN, if it's been removed with ESTALE
+*/
+func (f *KVFile) Flush() (code fuse.Status) {
+	defer Measure("flush", time.Now(), &code)
+	f.Lock()
+	defer f.Unlock()
+	if f.Node == nil || !f.Dirty {
+		return fuse.OK
+	}
+	Verbose("Flush() file: %s, index: %d", f.Path, f.Node.Index)
+	ctx, cancel := WriteContext(nil)
+	defer cancel()
+	value := string(f.Buffer)
+	if err := f.StoreKV.CompareAndSwap(ctx, f.Path, f.Node.Value, f.Node.Index, value); err != nil {
+		glog.Errorf("Flush() file: %s failed to write, error: %s", f.Path, err)
+		status := StoreStatus(err, fuse.EIO)
+		if err == config.NodeNotFoundErr {
+			status = fuse.Status(syscall.ESTALE)
+		}
+		f.Audit.Record(&f.Caller, AUDIT_WRITE, f.Path, "", status, f.Node.Index)
+		return status
+	}
+	f.Node = f.Written(ctx, value)
+	f.Dirty = false
+	f.Audit.Record(&f.Caller, AUDIT_WRITE, f.Path, "", fuse.OK, f.Node.Index)
 	return fuse.OK
 }
 
-func (f *KVFile) Release() {
+/*
+The key as we wrote it, read back for the index it was given, so a further flush compares on
+the index rather than the value; if it can't be read, or someone has changed it since, we only
+know the value we wrote, and the next flush compares on that
+*/
+func (f *KVFile) Written(ctx context.Context, value string) *config.Node {
+	node, err := f.StoreKV.Get(ctx, f.Path)
+	if err != nil {
+		glog.Warningf("Flush() file: %s failed to read back the key, error: %s", f.Path, err)
+		return &config.Node{Path: f.Path, Value: value}
+	}
+	if node.Value != value {
+		return &config.Node{Path: f.Path, Value: value}
+	}
+	return node
+}
 
+func (f *KVFile) Release() {
+	if f.Done != nil {
+		f.Done()
+	}
 }
 
 func (f *KVFile) GetAttr(attr *fuse.Attr) fuse.Status {
-	if node, err := f.StoreKV.Get(f.Path); err != nil {
+	f.Lock()
+	defer f.Unlock()
+	if f.Node != nil {
+		f.Permissions.SetAttr(f.Path, false, attr)
+		attr.Size = uint64(len(f.Buffer))
+		return fuse.OK
+	}
+	ctx, cancel := ReadContext(nil)
+	defer cancel()
+	if node, err := f.StoreKV.Get(ctx, f.Path); err != nil {
 		glog.Errorf("GetAttr() Failed to get the key: %s, error: %s", f.Path, err)
-		return fuse.EIO
+		return StoreStatus(err, fuse.EIO)
 	} else {
-		attr.Mode = fuse.S_IFREG | 0444
+		f.Permissions.SetAttr(f.Path, false, attr)
 		attr.Size = uint64(len(node.Value))
 	}
 	return fuse.OK
@@ -97,16 +226,27 @@ func (f *KVFile) Utimens(atime *time.Time, mtime *time.Time) fuse.Status {
 
 func (f *KVFile) Truncate(size uint64) fuse.Status {
 	Verbose("Truncate() file: %s, size: %d", f.Path, size)
+	f.Lock()
+	defer f.Unlock()
+	if f.Node == nil {
+		return fuse.EPERM
+	}
+	if size < uint64(len(f.Buffer)) {
+		f.Buffer = f.Buffer[:size]
+	} else {
+		f.Buffer = append(f.Buffer, make([]byte, size-uint64(len(f.Buffer)))...)
+	}
+	f.Dirty = true
 	return fuse.OK
 }
 
 func (f *KVFile) Chown(uid uint32, gid uint32) fuse.Status {
-	Verbose("Chown() uid: %s, gid: %d", uid, gid)
+	Verbose("Chown() uid: %d, gid: %d", uid, gid)
 	return fuse.ENOSYS
 }
 
 func (f *KVFile) Chmod(perms uint32) fuse.Status {
-	Verbose("Chmod() file: %s, perms:", f.Path, perms)
+	Verbose("Chmod() file: %s, perms: %o", f.Path, perms)
 	return fuse.ENOSYS
 }
 
@@ -115,8 +255,21 @@ func (f *KVFile) Allocate(off uint64, size uint64, mode uint32) (code fuse.Statu
 	return fuse.OK
 }
 
+/* There's no locking of the keys, the kernel handles the locks of the mount itself */
+func (f *KVFile) GetLk(owner uint64, lk *fuse.FileLock, flags uint32, out *fuse.FileLock) (code fuse.Status) {
+	return fuse.ENOSYS
+}
+
+func (f *KVFile) SetLk(owner uint64, lk *fuse.FileLock, flags uint32) (code fuse.Status) {
+	return fuse.ENOSYS
+}
+
+func (f *KVFile) SetLkw(owner uint64, lk *fuse.FileLock, flags uint32) (code fuse.Status) {
+	return fuse.ENOSYS
+}
+
 func (f *KVFile) SetInode(node *nodefs.Inode) {
-	Verbose("SetInode() file: %s, node: %V", f.Path, node)
+	Verbose("SetInode() file: %s, node: %v", f.Path, node)
 }
 
 func (f *KVFile) InnerFile() nodefs.File {
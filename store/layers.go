@@ -0,0 +1,90 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"flag"
+	"os"
+	"strings"
+
+	"github.com/gambol99/config-store/store/config"
+	"github.com/golang/glog"
+)
+
+/* The layers given on the command line, the flag can be repeated */
+type LayerFlags []string
+
+func (r *LayerFlags) String() string {
+	return strings.Join(*r, " ")
+}
+
+func (r *LayerFlags) Set(value string) error {
+	*r = append(*r, value)
+	return nil
+}
+
+func (r *LayerFlags) Values() []string {
+	return append([]string{}, *r...)
+}
+
+func (r *LayerFlags) Reset() {
+	*r = nil
+}
+
+var union_layers LayerFlags
+
+func init() {
+	flag.Var(&union_layers, "layer", "a layer of the mount, <prefix>[@<backend url>], repeated bottom layer first; {hostname} and $VARIABLES are expanded in the prefix")
+}
+
+/*
+Create the store for the mount; with layers it's the union of them, each a prefix in the
+mount's backend or its own, i.e. -layer /defaults/app -layer /env/prod/app -layer
+/host/{hostname}/app@consul://127.0.0.1:8500; otherwise it's simply the backend
+*/
+func NewLayeredStore(shared *Shared, backend string, specs []string) (config.KVStore, error) {
+	if len(specs) == 0 {
+		return shared.KVStore(backend)
+	}
+	layers := make([]*config.Layer, 0)
+	for _, spec := range specs {
+		prefix, location := spec, backend
+		if index := strings.Index(spec, "@"); index >= 0 {
+			prefix, location = spec[:index], spec[index+1:]
+		}
+		prefix = ExpandLayerPrefix(prefix)
+		store, err := shared.KVStore(location)
+		if err != nil {
+			glog.Errorf("Failed to create the backend: %s for the layer: %s, error: %s", BackendName(location), prefix, err)
+			return nil, err
+		}
+		layers = append(layers, &config.Layer{
+			Name:   prefix + "@" + BackendName(location),
+			Store:  store,
+			Prefix: prefix})
+	}
+	return config.NewUnionStore(layers)
+}
+
+/* Expand the {hostname} and any environment variables in the prefix of the layer */
+func ExpandLayerPrefix(prefix string) string {
+	if strings.Contains(prefix, "{hostname}") {
+		hostname, err := os.Hostname()
+		if err != nil {
+			glog.Errorf("Failed to get the hostname for the layer: %s, error: %s", prefix, err)
+		}
+		prefix = strings.Replace(prefix, "{hostname}", hostname, -1)
+	}
+	return os.ExpandEnv(prefix)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"flag"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/gambol99/config-store/store/config"
+	"github.com/golang/glog"
+	"github.com/hanwen/go-fuse/v2/fuse"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var metrics_address *string
+
+/*
+The operations on the mounts and their caches are measured here, the calls to the backends and
+their watches in config/metered.go. The templates have no metrics; nothing renders them yet, the
+render of template.Resource is a stub, so they'd only ever read zero
+*/
+var (
+	fuse_operations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "config_store_fuse_operations_total",
+		Help: "The operations on the mounts by type and status"},
+		[]string{"operation", "status"})
+	fuse_durations = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "config_store_fuse_operation_duration_seconds",
+		Help:    "The time taken by the operations on the mounts",
+		Buckets: config.DURATION_BUCKETS},
+		[]string{"operation"})
+)
+
+var (
+	cache_hits = prometheus.NewDesc("config_store_cache_hits_total",
+		"The lookups found in the cache of the mount", []string{"mount"}, nil)
+	cache_misses = prometheus.NewDesc("config_store_cache_misses_total",
+		"The lookups not found in the cache of the mount, or expired", []string{"mount"}, nil)
+	cache_evictions = prometheus.NewDesc("config_store_cache_evictions_total",
+		"The entries removed from the cache of the mount as they expired", []string{"mount"}, nil)
+	cache_entries = prometheus.NewDesc("config_store_cache_entries",
+		"The entries in the cache of the mount", []string{"mount"}, nil)
+)
+
+/* the names of the errors the operations usually fail with, anything else is its number */
+var status_names = map[fuse.Status]string{
+	fuse.OK:                           "OK",
+	fuse.ENOENT:                       "ENOENT",
+	fuse.EACCES:                       "EACCES",
+	fuse.EPERM:                        "EPERM",
+	fuse.EIO:                          "EIO",
+	fuse.ENOSYS:                       "ENOSYS",
+	fuse.ENODATA:                      "ENODATA",
+	fuse.EINVAL:                       "EINVAL",
+	fuse.Status(syscall.EAGAIN):       "EAGAIN",
+	fuse.Status(syscall.ESTALE):       "ESTALE",
+	fuse.Status(syscall.EEXIST):       "EEXIST",
+	fuse.Status(syscall.EINTR):        "EINTR",
+	fuse.Status(syscall.ETIMEDOUT):    "ETIMEDOUT",
+	fuse.Status(syscall.ENOTSUP):      "ENOTSUP",
+	fuse.Status(syscall.ENOTEMPTY):    "ENOTEMPTY",
+	fuse.Status(syscall.ENOTDIR):      "ENOTDIR",
+	fuse.Status(syscall.EISDIR):       "EISDIR",
+	fuse.Status(syscall.EROFS):        "EROFS",
+	fuse.Status(syscall.ENAMETOOLONG): "ENAMETOOLONG"}
+
+func init() {
+	prometheus.MustRegister(fuse_operations, fuse_durations)
+	metrics_address = flag.String("metrics", "", "the address the prometheus metrics are served on, i.e. 127.0.0.1:9105, none if empty; they are on the control socket as well")
+}
+
+/* Count and time the operation on the mount, called deferred with the status it returned */
+func Measure(operation string, started time.Time, code *fuse.Status) {
+	fuse_operations.WithLabelValues(operation, StatusName(*code)).Inc()
+	fuse_durations.WithLabelValues(operation).Observe(time.Since(started).Seconds())
+}
+
+func StatusName(code fuse.Status) string {
+	if name, found := status_names[code]; found {
+		return name
+	}
+	return "errno_" + strconv.Itoa(int(code))
+}
+
+/*
+The caches of the mounts, which keep their own counts; they're read at the time of the scrape. A
+reload or resync starts the cache of the mount afresh, so its counters go back to zero
+*/
+type CacheCollector struct {
+	/* the mounts being served */
+	Mounts func() []*ReloadableFileSystem
+}
+
+func (r *CacheCollector) Describe(descriptions chan<- *prometheus.Desc) {
+	descriptions <- cache_hits
+	descriptions <- cache_misses
+	descriptions <- cache_evictions
+	descriptions <- cache_entries
+}
+
+func (r *CacheCollector) Collect(metrics chan<- prometheus.Metric) {
+	for _, mount := range r.Mounts() {
+		filesystem := mount.FS()
+		stats := filesystem.Cache.Stats()
+		metrics <- prometheus.MustNewConstMetric(cache_hits, prometheus.CounterValue, float64(stats.Hits), filesystem.Options.Mount)
+		metrics <- prometheus.MustNewConstMetric(cache_misses, prometheus.CounterValue, float64(stats.Misses), filesystem.Options.Mount)
+		metrics <- prometheus.MustNewConstMetric(cache_evictions, prometheus.CounterValue, float64(stats.Evictions), filesystem.Options.Mount)
+		metrics <- prometheus.MustNewConstMetric(cache_entries, prometheus.GaugeValue, float64(stats.Size), filesystem.Options.Mount)
+	}
+}
+
+/* Add the caches of the mounts to the metrics and, if there's an address, serve them on /metrics */
+func NewMetricsServer(mounts func() []*ReloadableFileSystem) error {
+	if err := prometheus.Register(&CacheCollector{Mounts: mounts}); err != nil {
+		glog.Errorf("Failed to register the metrics of the caches, error: %s", err)
+		return err
+	}
+	if *metrics_address == "" {
+		return nil
+	}
+	listener, err := net.Listen("tcp", *metrics_address)
+	if err != nil {
+		glog.Errorf("Failed to listen on the metrics address: %s, error: %s", *metrics_address, err)
+		return err
+	}
+	router := http.NewServeMux()
+	router.Handle("/metrics", promhttp.Handler())
+	go func() {
+		if err := http.Serve(listener, router); err != nil {
+			glog.Errorf("The metrics server has stopped, error: %s", err)
+		}
+	}()
+	glog.Infof("Serving the metrics on: http://%s/metrics", *metrics_address)
+	return nil
+}
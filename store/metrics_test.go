@@ -0,0 +1,96 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hanwen/go-fuse/v2/fuse"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+func TestMetricsText(t *testing.T) {
+	ResetSharedServices()
+	defer ResetSharedServices()
+	options := &MountOptions{Mount: "/mnt/metrics", Backend: "mem://", Prefix: "/", Mode: MOUNT_READ_ONLY, Cache: CACHE_WATCH}
+	if err := options.Parse(); err != nil {
+		t.Fatalf("invalid options, error: %s", err)
+	}
+	/* step: the key is set before the mount, so its watch has nothing to hand on */
+	shared, err := SharedServices()
+	if err != nil {
+		t.Fatalf("failed to create the shared services, error: %s", err)
+	}
+	backend, err := shared.KVStore(options.Backend)
+	shared.Release()
+	if err != nil {
+		t.Fatalf("failed to create the backend, error: %s", err)
+	}
+	if err := backend.Set(context.Background(), "/key", "value"); err != nil {
+		t.Fatalf("failed to set the key, error: %s", err)
+	}
+	filesystem, err := NewReloadableFileSystem(options)
+	if err != nil {
+		t.Fatalf("failed to create the filesystem, error: %s", err)
+	}
+	defer filesystem.FS().Close()
+	if err := NewMetricsServer(func() []*ReloadableFileSystem { return []*ReloadableFileSystem{filesystem} }); err != nil {
+		t.Fatalf("failed to register the metrics, error: %s", err)
+	}
+	defer prometheus.Unregister(new(CacheCollector))
+	filesystem.GetAttr("/key", &fuse.Context{})
+	filesystem.GetAttr("/missing", &fuse.Context{})
+	server := httptest.NewServer(promhttp.Handler())
+	defer server.Close()
+	response, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("failed to scrape the metrics, error: %s", err)
+	}
+	defer response.Body.Close()
+	content, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("failed to read the metrics, error: %s", err)
+	}
+	if kind := response.Header.Get("Content-Type"); !strings.HasPrefix(kind, "text/plain; version=0.0.4") {
+		t.Errorf("expected the text format, got: %s", kind)
+	}
+	stats := filesystem.FS().Cache.Stats()
+	expected := []string{
+		"# TYPE config_store_fuse_operations_total counter",
+		`config_store_fuse_operations_total{operation="getattr",status="OK"} `,
+		`config_store_fuse_operations_total{operation="getattr",status="ENOENT"} `,
+		"# TYPE config_store_fuse_operation_duration_seconds histogram",
+		`config_store_fuse_operation_duration_seconds_bucket{operation="getattr",le="0.0005"} `,
+		`config_store_fuse_operation_duration_seconds_bucket{operation="getattr",le="+Inf"} `,
+		`config_store_kv_requests_total{backend="mem",error="none",method="set"} `,
+		`config_store_kv_request_duration_seconds_count{backend="mem",method="set"} `,
+		"# TYPE config_store_cache_entries gauge",
+		fmt.Sprintf(`config_store_cache_entries{mount="/mnt/metrics"} %d`, stats.Size),
+		fmt.Sprintf(`config_store_cache_hits_total{mount="/mnt/metrics"} %d`, stats.Hits),
+		fmt.Sprintf(`config_store_cache_misses_total{mount="/mnt/metrics"} %d`, stats.Misses),
+		fmt.Sprintf(`config_store_cache_evictions_total{mount="/mnt/metrics"} %d`, stats.Evictions),
+	}
+	for _, line := range expected {
+		if !strings.Contains(string(content), line) {
+			t.Errorf("expected the metrics to contain: %s", line)
+		}
+	}
+}
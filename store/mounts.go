@@ -0,0 +1,141 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+const (
+	/* the modes of a mount */
+	MOUNT_READ_ONLY  = "ro"
+	MOUNT_READ_WRITE = "rw"
+	/* the cache policies; anything else is the duration the entries are kept for */
+	CACHE_WATCH = "watch"
+	CACHE_NONE  = "none"
+)
+
+var mount_prefix, cache_policy *string
+
+var InvalidMountModeErr = errors.New("Invalid mount mode, must be ro or rw")
+var InvalidCachePolicyErr = errors.New("Invalid cache policy, must be watch, none or a duration of a second or more")
+var NoMountPointErr = errors.New("The mount has no mount point")
+
+func init() {
+	mount_prefix = flag.String("prefix", "/", "the path in the backend which is the root of the mount, i.e. /prod/payments")
+	cache_policy = flag.String("cache", CACHE_WATCH, "how long the keys are cached; watch keeps them until they change, none doesn't cache, or a duration")
+}
+
+/* The options of a mount; with [[mounts]] in the settings file there's one of these per mount point */
+type MountOptions struct {
+	/* the mount point */
+	Mount string `toml:"mount"`
+	/* the backend url, the -kv flag if empty */
+	Backend string `toml:"kv"`
+	/* the path in the backend which is the root of the mount */
+	Prefix string `toml:"prefix"`
+	/* the layers of a union mount, see -layer */
+	Layers []string `toml:"layers"`
+	/* ro or rw */
+	Mode string `toml:"mode"`
+	/* the cache policy, see -cache */
+	Cache string `toml:"cache"`
+	/* the mode and cache policy once parsed */
+	Writable bool          `toml:"-"`
+	NoCache  bool          `toml:"-"`
+	CacheTTL time.Duration `toml:"-"`
+}
+
+/* The options of the single mount given on the command line */
+func DefaultMountOptions(mount string) (*MountOptions, error) {
+	options := &MountOptions{
+		Mount:   mount,
+		Backend: *backend_kv_url,
+		Prefix:  *mount_prefix,
+		Layers:  union_layers,
+		Mode:    MOUNT_READ_ONLY,
+		Cache:   *cache_policy}
+	if *writable_mount {
+		options.Mode = MOUNT_READ_WRITE
+	}
+	return options, options.Parse()
+}
+
+/* Fill in the defaults of the mounts from the flags and check them */
+func ParseMounts(mounts []*MountOptions) error {
+	seen := make(map[string]bool, 0)
+	for index, options := range mounts {
+		if options.Backend == "" {
+			options.Backend = *backend_kv_url
+		}
+		if options.Prefix == "" {
+			options.Prefix = *mount_prefix
+		}
+		if options.Mode == "" {
+			options.Mode = MOUNT_READ_ONLY
+			if *writable_mount {
+				options.Mode = MOUNT_READ_WRITE
+			}
+		}
+		if options.Cache == "" {
+			options.Cache = *cache_policy
+		}
+		if err := options.Parse(); err != nil {
+			return fmt.Errorf("mount %d: %s", index, err)
+		}
+		if seen[options.Mount] {
+			return fmt.Errorf("mount %d: the mount point: %s is used twice", index, options.Mount)
+		}
+		seen[options.Mount] = true
+	}
+	return nil
+}
+
+func (r *MountOptions) Parse() error {
+	if r.Mount == "" {
+		return NoMountPointErr
+	}
+	r.Mount = filepath.Clean(r.Mount)
+	r.Prefix = filepath.Clean("/" + r.Prefix)
+	switch r.Mode {
+	case MOUNT_READ_ONLY:
+		r.Writable = false
+	case MOUNT_READ_WRITE:
+		r.Writable = true
+	default:
+		return InvalidMountModeErr
+	}
+	r.NoCache, r.CacheTTL = false, 0
+	switch r.Cache {
+	case CACHE_WATCH:
+	case CACHE_NONE:
+		r.NoCache = true
+	default:
+		/* step: the cache expires items by the second, anything less would never expire */
+		ttl, err := time.ParseDuration(r.Cache)
+		if err != nil || ttl < time.Second {
+			return InvalidCachePolicyErr
+		}
+		r.CacheTTL = ttl
+	}
+	return nil
+}
+
+func (r *MountOptions) String() string {
+	return fmt.Sprintf("mount: %s, backend: %s, prefix: %s, mode: %s, cache: %s", r.Mount, BackendName(r.Backend), r.Prefix, r.Mode, r.Cache)
+}
@@ -14,18 +14,24 @@ limitations under the License.
 package store
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 	"path/filepath"
 
 	"github.com/gambol99/config-store/store/cache"
 	"github.com/gambol99/config-store/store/config"
 	"github.com/golang/glog"
-	"github.com/hanwen/go-fuse/fuse"
-	"github.com/hanwen/go-fuse/fuse/nodefs"
-	"github.com/hanwen/go-fuse/fuse/pathfs"
+	"github.com/hanwen/go-fuse/v2/fuse"
+	"github.com/hanwen/go-fuse/v2/fuse/nodefs"
+	"github.com/hanwen/go-fuse/v2/fuse/pathfs"
 )
 
 /*
@@ -46,32 +52,114 @@ type FuseKVFileSystem struct {
 	BigBang time.Time
 	/* a map of file name to last change event */
 	NodeChanges map[string]time.Time
+	/* the backend we are serving from */
+	Backend string
+	/* the ownership and modes of the files */
+	Permissions *Permissions
+	/* the access policy for the callers, nil if everything is allowed */
+	ACL *AccessPolicy
+	/* the audit log of the operations, nil if not auditing */
+	Audit *Auditor
+	/* the options of the mount */
+	Options *MountOptions
+	/* stops the watch on the store */
+	StopWatch context.CancelFunc
+	/* the state of the watch on the store */
+	Watch *WatchStatus
+	/* closed to stop the reaper of the cache */
+	Shutdown chan bool
+	/* the backends, access policy and audit log we share with the other mounts */
+	Shared *Shared
+	/* the calls under way and the files open on the filesystem */
+	Active *sync.WaitGroup
+}
+
+/* The state of a watch on the store */
+type WatchStatus struct {
+	/* the events received and the unix time of the last, updated atomically; first for the alignment */
+	Events, LastEvent int64
+	/* set once the watch has ended */
+	Closed int32
+	/* the path being watched */
+	Path string
+	/* when the watch was started */
+	Started time.Time
 }
 
 var backend_kv_url *string
+var backend_read_timeout, backend_write_timeout *time.Duration
+var writable_mount *bool
 
 const FUSE_VERBOSE_LEVEL = 7
 
 func Verbose(message string, args ...interface{}) {
-	glog.V(FUSE_VERBOSE_LEVEL).Infof(message, args)
+	glog.V(FUSE_VERBOSE_LEVEL).Infof(message, args...)
 }
 
 func init() {
 	backend_kv_url = flag.String( "kv", "etcd://127.0.0.1:4001", "the backend url for the key/value store" )
+	backend_read_timeout = flag.Duration("kv-timeout", 10*time.Second, "the deadline for a read from the key/value store")
+	backend_write_timeout = flag.Duration("kv-write-timeout", 30*time.Second, "the deadline for a write to the key/value store")
+	writable_mount = flag.Bool("writable", false, "allow the files to be written, changes are only made if the key is unchanged since it was opened")
+}
+
+
+/*
+The context for an operation against the store; it's cancelled when the caller interrupts the
+syscall, and the deadline stops a hung backend blocking it regardless. The file operations aren't
+handed the request, so they pass nil and only have the deadline. The deadlines come from the
+runtime settings, never the flags, which a reload changes
+*/
+func ReadContext(request *fuse.Context) (context.Context, context.CancelFunc) {
+	return OperationContext(request, CurrentSettings().ReadTimeout)
+}
+
+func WriteContext(request *fuse.Context) (context.Context, context.CancelFunc) {
+	return OperationContext(request, CurrentSettings().WriteTimeout)
+}
+
+func OperationContext(request *fuse.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if request == nil {
+		return context.WithTimeout(context.Background(), timeout)
+	}
+	return context.WithTimeout(request, timeout)
+}
+
+/* Convert an error from the store into a fuse status, using the fallback for anything we don't know */
+func StoreStatus(err error, fallback fuse.Status) fuse.Status {
+	switch err {
+	case nil:
+		return fuse.OK
+	case config.NodeNotFoundErr:
+		return fuse.ENOENT
+	case context.DeadlineExceeded:
+		return fuse.Status(syscall.ETIMEDOUT)
+	case context.Canceled:
+		return fuse.Status(syscall.EINTR)
+	case config.CompareFailedErr:
+		return fuse.Status(syscall.EAGAIN)
+	case config.TTLNotSupportedErr:
+		return fuse.Status(syscall.ENOTSUP)
+	}
+	return fallback
 }
 
 func (px *FuseKVFileSystem) NodeWatcher() error {
-	updateChannel := make(chan config.NodeChange,0)
-	stopChannel, err := px.StoreKV.Watch( "/", updateChannel )
+	updateChannel, cancel, err := px.StoreKV.Watch(context.Background(), "/")
 	if err != nil {
 		glog.Errorf("Unable to create a watch on root, error: %s", err )
 		return err
 	}
+	px.StopWatch = cancel
+	px.Watch = &WatchStatus{Path: "/", Started: time.Now()}
 	go func() {
-		for {
-			/* step: we wait for an update */
-			update := <- updateChannel
-			Verbose("NodeWatcher() update: %s", update )
+		defer cancel()
+		defer atomic.StoreInt32(&px.Watch.Closed, 1)
+		/* step: we wait for an update, the channel is closed when the watch ends */
+		for update := range updateChannel {
+			Verbose("NodeWatcher() update: %v", update )
+			atomic.AddInt64(&px.Watch.Events, 1)
+			atomic.StoreInt64(&px.Watch.LastEvent, time.Now().Unix())
 			switch update.Operation {
 			case config.CHANGED:
 				px.NodeChanges[update.Node.Path] = time.Now()
@@ -84,42 +172,61 @@ func (px *FuseKVFileSystem) NodeWatcher() error {
 			px.CleanNode(update.Node.Path)
 			px.CleanDir(update.Node.Path)
 		}
-		stopChannel <- true
+		glog.Warningf("NodeWatcher() the watch on the store has been closed")
 	}()
 	return nil
 }
 
+/* Stop watching the store, the filesystem is being replaced or unmounted */
+func (px *FuseKVFileSystem) Close() {
+	if px.StopWatch != nil {
+		px.StopWatch()
+	}
+	close(px.Shutdown)
+	/* step: the shared services are released once the calls under way and the open files are done */
+	go func() {
+		px.Active.Wait()
+		px.Shared.Release()
+	}()
+}
+
 func (px *FuseKVFileSystem) Unlink(name string, context *fuse.Context) (code fuse.Status) {
 	/* step: delete the key pair */
-	Verbose("Unlink() deleting the file: %s, context: %V", name, context)
-	if err := px.StoreKV.Delete(name); err != nil {
+	Verbose("Unlink() deleting the file: %s, context: %v", name, context)
+	defer func() { px.Audit.Record(context, AUDIT_DELETE, name, "", code, 0) }()
+	if !px.ACL.Allowed(name, context, ACL_DELETE) || !px.Permissions.Permitted(name, false, context, ACCESS_WRITE) {
+		return fuse.EACCES
+	}
+	ctx, cancel := WriteContext(context)
+	defer cancel()
+	if err := px.StoreKV.Delete(ctx, name); err != nil {
 		glog.Errorf("Failed to delete the key: %s, error: %s", name, err)
-		return fuse.EPERM
+		return StoreStatus(err, fuse.EPERM)
 	}
 	return fuse.OK
 }
 
 func (px *FuseKVFileSystem) GetAttr(name string, context *fuse.Context) (*fuse.Attr, fuse.Status) {
 	if name == "" {
-		return &fuse.Attr{Mode: fuse.S_IFDIR | 0555}, fuse.OK
+		attr := &fuse.Attr{}
+		px.Permissions.SetAttr("/", true, attr)
+		return attr, fuse.OK
 	}
-	if node, err := px.CachedNode(name); err != nil {
-		return nil, fuse.ENOENT
+	if !px.ACL.Allowed(name, context, ACL_LOOKUP) || !px.Permissions.Permitted(name, false, context, 0) {
+		return nil, fuse.EACCES
+	}
+	if node, err := px.CachedNode(name, context); err != nil {
+		return nil, StoreStatus(err, fuse.ENOENT)
 	} else {
 		var attr fuse.Attr
 		attr.Ctime = uint64(px.BigBang.Unix())
-		attr.Mode = fuse.S_IFDIR|0665
-		attr.Gid  = 0
-		attr.Uid  = 0
+		px.Permissions.SetAttr(name, node.IsDir(), &attr)
 		if _, found := px.NodeChanges[node.Path]; found {
 			attr.Mtime = uint64(px.NodeChanges[node.Path].Unix())
 		} else {
 			attr.Mtime = uint64(px.BigBang.Unix())
 		}
-		if node.IsDir() {
-
-		} else {
-			attr.Mode = fuse.S_IFREG|0444
+		if node.IsFile() {
 			attr.Size = uint64(len(node.Value))
 		}
 		return &attr, fuse.OK
@@ -127,35 +234,175 @@ func (px *FuseKVFileSystem) GetAttr(name string, context *fuse.Context) (*fuse.A
 }
 
 func (px *FuseKVFileSystem) Rmdir(name string, context *fuse.Context) (code fuse.Status) {
-	Verbose("Rmdir() removing the directory: %s, context: %V", name, context)
+	Verbose("Rmdir() removing the directory: %s, context: %v", name, context)
 	return fuse.EPERM
 }
 
 func (px *FuseKVFileSystem) Mkdir(name string, mode uint32, context *fuse.Context) fuse.Status {
-	Verbose("Mkdir() path: %s, mode: %d, context: %V", name, mode, context)
+	Verbose("Mkdir() path: %s, mode: %d, context: %v", name, mode, context)
 	return fuse.EPERM
 }
 
 func (px *FuseKVFileSystem) Open(name string, flags uint32, context *fuse.Context) (file nodefs.File, code fuse.Status) {
-	Verbose("Open() name: %s, flags: %d, context: %V", name, flags, context)
-	return NewKVFile(name, px.StoreKV), fuse.OK
+	Verbose("Open() name: %s, flags: %d, context: %v", name, flags, context)
+	access, operations, audit := uint32(ACCESS_READ), []uint32{ACL_READ}, AUDIT_READ
+	switch flags & syscall.O_ACCMODE {
+	case syscall.O_WRONLY:
+		access, operations, audit = ACCESS_WRITE, []uint32{ACL_WRITE}, AUDIT_WRITE
+	case syscall.O_RDWR:
+		access, operations, audit = ACCESS_READ|ACCESS_WRITE, []uint32{ACL_READ, ACL_WRITE}, AUDIT_WRITE
+	}
+	/* step: the reads and writes themselves are recorded by the file, here only the refusals */
+	defer func() {
+		if code != fuse.OK {
+			px.Audit.Record(context, audit, name, "", code, 0)
+		}
+	}()
+	for _, operation := range operations {
+		if !px.ACL.Allowed(name, context, operation) {
+			return nil, fuse.EACCES
+		}
+	}
+	if !px.Permissions.Permitted(name, false, context, access) {
+		return nil, fuse.EACCES
+	}
+	kvfile := NewKVFile(name, px.StoreKV, px.Permissions)
+	kvfile.Audit, kvfile.Caller = px.Audit, *context
+	if flags&(syscall.O_WRONLY|syscall.O_RDWR) != 0 {
+		if !px.Options.Writable {
+			return nil, fuse.EPERM
+		}
+		if status := kvfile.OpenWriter(flags&syscall.O_TRUNC != 0); status != fuse.OK {
+			return nil, status
+		}
+	}
+	/* step: the filesystem isn't closed until the file is released */
+	px.Active.Add(1)
+	kvfile.Done = px.Active.Done
+	return kvfile, fuse.OK
+}
+
+/* A truncate without a file handle, i.e. truncate(1); it's a compare and swap like any other write */
+func (px *FuseKVFileSystem) Truncate(name string, size uint64, context *fuse.Context) (code fuse.Status) {
+	Verbose("Truncate() name: %s, size: %d, context: %v", name, size, context)
+	defer func() { px.Audit.Record(context, AUDIT_TRUNCATE, name, "", code, 0) }()
+	if !px.Options.Writable {
+		return fuse.EPERM
+	}
+	if !px.ACL.Allowed(name, context, ACL_WRITE) || !px.Permissions.Permitted(name, false, context, ACCESS_WRITE) {
+		return fuse.EACCES
+	}
+	kvfile := NewKVFile(name, px.StoreKV, px.Permissions)
+	if status := kvfile.OpenWriter(false); status != fuse.OK {
+		return status
+	}
+	if status := kvfile.Truncate(size); status != fuse.OK {
+		return status
+	}
+	return kvfile.Flush()
 }
 
 func (px *FuseKVFileSystem) Create(name string, flags uint32, mode uint32, context *fuse.Context) (file nodefs.File, code fuse.Status) {
-	Verbose("Open() name: %s, flags: %d, mode: %d, context: %V", name, flags, mode, context)
+	Verbose("Open() name: %s, flags: %d, mode: %d, context: %v", name, flags, mode, context)
 	return nil, fuse.EPERM
 }
 
+/*
+Renames are made as a single transaction; the keys are set under the new name and removed
+from the old one together, so a directory is never left half moved
+*/
+func (px *FuseKVFileSystem) Rename(oldName string, newName string, context *fuse.Context) (code fuse.Status) {
+	Verbose("Rename() from: %s, to: %s, context: %v", oldName, newName, context)
+	defer func() { px.Audit.Record(context, AUDIT_RENAME, oldName, newName, code, 0) }()
+	if !px.Options.Writable {
+		return fuse.EPERM
+	}
+	/* step: the acls are checked before the read, so a denied caller can't tell if the key exists */
+	if !px.ACL.Allowed(oldName, context, ACL_READ) || !px.ACL.Allowed(oldName, context, ACL_DELETE) ||
+		!px.ACL.Allowed(newName, context, ACL_WRITE) {
+		return fuse.EACCES
+	}
+	ctx, cancel := WriteContext(context)
+	defer cancel()
+	node, err := px.StoreKV.Get(ctx, oldName)
+	if err != nil {
+		glog.Errorf("Rename() failed to get the key: %s, error: %s", oldName, err)
+		return StoreStatus(err, fuse.EIO)
+	}
+	if !px.Permissions.Permitted(oldName, node.IsDir(), context, ACCESS_READ|ACCESS_WRITE) ||
+		!px.Permissions.Permitted(newName, node.IsDir(), context, ACCESS_WRITE) {
+		return fuse.EACCES
+	}
+	if target, err := px.StoreKV.Get(ctx, newName); err == nil && target.IsDir() {
+		return fuse.Status(syscall.EEXIST)
+	}
+	keys := []*config.Node{node}
+	if node.IsDir() {
+		if keys, err = config.Walk(ctx, px.StoreKV, oldName); err != nil {
+			glog.Errorf("Rename() failed to read the keys under: %s, error: %s", oldName, err)
+			return StoreStatus(err, fuse.EIO)
+		}
+	}
+	/* step: moving a directory reads and deletes every key under it and writes each to the target */
+	targets := make([]string, 0, len(keys))
+	for _, key := range keys {
+		target := "/" + newName + strings.TrimPrefix(key.Path, node.Path)
+		if node.IsDir() && (!px.ACL.Allowed(key.Path, context, ACL_READ) || !px.ACL.Allowed(key.Path, context, ACL_DELETE) ||
+			!px.ACL.Allowed(target, context, ACL_WRITE)) {
+			return fuse.EACCES
+		}
+		targets = append(targets, target)
+	}
+	operations := make([]*config.Operation, 0)
+	for index, key := range keys {
+		operations = append(operations, &config.Operation{Key: targets[index], Value: key.Value})
+	}
+	for _, key := range keys {
+		operations = append(operations, &config.Operation{Key: key.Path, Delete: true})
+	}
+	if err := px.StoreKV.Txn(ctx, operations); err != nil {
+		glog.Errorf("Rename() failed to move: %s to: %s, error: %s", oldName, newName, err)
+		return StoreStatus(err, fuse.EIO)
+	}
+	/* step: the keys have moved, remove what's left of the old directory */
+	if node.IsDir() {
+		if err := px.StoreKV.Mkdir(ctx, newName); err != nil {
+			Verbose("Rename() failed to create the directory: %s, error: %s", newName, err)
+		}
+		if err := px.StoreKV.RemovePath(ctx, oldName); err != nil {
+			glog.Warningf("Rename() failed to remove the old directory: %s, error: %s", oldName, err)
+		}
+	}
+	for _, name := range []string{oldName, newName} {
+		px.CleanNode("/" + name)
+		px.CleanDir("/" + name)
+	}
+	return fuse.OK
+}
+
 func (px *FuseKVFileSystem) OpenDir(name string, context *fuse.Context) (stream []fuse.DirEntry, status fuse.Status) {
 	entries := []fuse.DirEntry{}
 	/* step: get a list of the nodes under the path */
 	Verbose("Opendir() key: %s", name )
-	if nodes, err := px.CachedListing(name); err != nil {
-		glog.Errorf("OpenDir() path: %s, context: %V, error: %s", name, context, err)
-		return entries, fuse.EPERM
+	defer func() { px.Audit.Record(context, AUDIT_LIST, name, "", status, 0) }()
+	/* step: a caller who can't list the directory only sees the entries they're allowed */
+	filtered := !px.ACL.Permits(name, context, ACL_LIST)
+	if filtered && !px.ACL.Permits(name, context, ACL_LOOKUP) {
+		px.ACL.Allowed(name, context, ACL_LIST)
+		return entries, fuse.EACCES
+	}
+	if !px.Permissions.Permitted(name, true, context, ACCESS_READ|ACCESS_EXECUTE) {
+		return entries, fuse.EACCES
+	}
+	if nodes, err := px.CachedListing(name, context); err != nil {
+		glog.Errorf("OpenDir() path: %s, context: %v, error: %s", name, context, err)
+		return entries, StoreStatus(err, fuse.EPERM)
 	} else {
 		Verbose("OpenDir() nodes: %v", nodes)
 		for _, node := range nodes {
+			if filtered && !px.ACL.Permits(node.Path, context, ACL_LOOKUP) {
+				continue
+			}
 			chunks := strings.Split(node.Path, "/")
 			file := chunks[len(chunks) - 1]
 			if node.IsDir() {
@@ -168,6 +415,189 @@ func (px *FuseKVFileSystem) OpenDir(name string, context *fuse.Context) (stream
 	}
 }
 
+const (
+	XATTR_PREFIX  = "user."
+	XATTR_TTL     = "user.ttl"
+	XATTR_VERSION = "user.version."
+)
+
+/*
+The metadata of the key is exposed as extended attributes, i.e. getfattr -d, so tooling can
+see exactly which revision of a key was read. Only the ttl can be changed
+*/
+func (px *FuseKVFileSystem) NodeAttributes(node *config.Node) map[string]string {
+	attributes := map[string]string{
+		XATTR_PREFIX + "backend": px.Backend,
+		XATTR_PREFIX + "stale":   strconv.FormatBool(node.Stale)}
+	if node.Index > 0 {
+		attributes[XATTR_PREFIX+"index"] = strconv.FormatUint(node.Index, 10)
+	}
+	if node.CreateIndex > 0 {
+		attributes[XATTR_PREFIX+"create_index"] = strconv.FormatUint(node.CreateIndex, 10)
+	}
+	if node.TTL > 0 {
+		attributes[XATTR_TTL] = node.TTL.Round(time.Second).String()
+		attributes[XATTR_PREFIX+"expiration"] = time.Now().Add(node.TTL).UTC().Format(time.RFC3339)
+	}
+	for name, value := range node.Metadata {
+		attributes[XATTR_PREFIX+name] = value
+	}
+	return attributes
+}
+
+/* We skip the cache for the attributes, the time left on the ttl is always changing */
+func (px *FuseKVFileSystem) GetNodeAttributes(name string, context *fuse.Context) (map[string]string, fuse.Status) {
+	ctx, cancel := ReadContext(context)
+	defer cancel()
+	node, err := px.StoreKV.Get(ctx, name)
+	if err != nil {
+		return nil, StoreStatus(err, fuse.EIO)
+	}
+	return px.NodeAttributes(node), fuse.OK
+}
+
+func (px *FuseKVFileSystem) GetXAttr(name string, attribute string, context *fuse.Context) ([]byte, fuse.Status) {
+	Verbose("GetXAttr() name: %s, attribute: %s, context: %v", name, attribute, context)
+	/* step: the kernel asks for the security and system attributes on most calls, we only have user ones */
+	if !strings.HasPrefix(attribute, XATTR_PREFIX) {
+		return nil, fuse.ENODATA
+	}
+	if strings.HasPrefix(attribute, XATTR_VERSION) {
+		return px.GetVersion(name, strings.TrimPrefix(attribute, XATTR_VERSION), context)
+	}
+	if !px.ACL.Allowed(name, context, ACL_LOOKUP) || !px.Permissions.Permitted(name, false, context, 0) {
+		return nil, fuse.EACCES
+	}
+	attributes, status := px.GetNodeAttributes(name, context)
+	if status != fuse.OK {
+		return nil, status
+	}
+	if value, found := attributes[attribute]; found {
+		return []byte(value), fuse.OK
+	}
+	return nil, fuse.ENODATA
+}
+
+/*
+An older version of the key, i.e. getfattr --only-values -n user.version.3; it's a read of the
+content, so it needs read access. Only the stores which keep the versions (vault) have them,
+anything else has only the version the key is at now
+*/
+func (px *FuseKVFileSystem) GetVersion(name string, version string, context *fuse.Context) (value []byte, code fuse.Status) {
+	index, err := strconv.ParseUint(version, 10, 64)
+	if err != nil || index == 0 {
+		return nil, fuse.ENODATA
+	}
+	defer func() { px.Audit.Record(context, AUDIT_READ, name, "", code, index) }()
+	if !px.ACL.Allowed(name, context, ACL_READ) || !px.Permissions.Permitted(name, false, context, ACCESS_READ) {
+		return nil, fuse.EACCES
+	}
+	ctx, cancel := ReadContext(context)
+	defer cancel()
+	node, err := px.StoreKV.Get(config.WithVersion(ctx, index), name)
+	if err == config.NodeNotFoundErr {
+		return nil, fuse.ENODATA
+	}
+	if err != nil {
+		return nil, StoreStatus(err, fuse.EIO)
+	}
+	if node.IsDir() || node.Index != index {
+		return nil, fuse.ENODATA
+	}
+	return []byte(node.Value), fuse.OK
+}
+
+func (px *FuseKVFileSystem) ListXAttr(name string, context *fuse.Context) ([]string, fuse.Status) {
+	Verbose("ListXAttr() name: %s, context: %v", name, context)
+	if !px.ACL.Allowed(name, context, ACL_LOOKUP) || !px.Permissions.Permitted(name, false, context, 0) {
+		return nil, fuse.EACCES
+	}
+	attributes, status := px.GetNodeAttributes(name, context)
+	if status != fuse.OK {
+		return nil, status
+	}
+	names := make([]string, 0)
+	for attribute := range attributes {
+		names = append(names, attribute)
+	}
+	sort.Strings(names)
+	return names, fuse.OK
+}
+
+/* Set the ttl on the key, i.e. setfattr -n user.ttl -v 30s; a plain number is taken as seconds */
+func (px *FuseKVFileSystem) SetXAttr(name string, attribute string, data []byte, flags int, context *fuse.Context) fuse.Status {
+	Verbose("SetXAttr() name: %s, attribute: %s, value: %s, context: %v", name, attribute, config.Redact(name, string(data)), context)
+	if attribute != XATTR_TTL {
+		return px.ReadOnlyXAttr(name, attribute, context)
+	}
+	ttl, err := ParseTTL(string(data))
+	if err != nil || ttl <= 0 {
+		return fuse.EINVAL
+	}
+	return px.SetTTL(name, ttl, context)
+}
+
+/* Removing the ttl makes the key permanent */
+func (px *FuseKVFileSystem) RemoveXAttr(name string, attribute string, context *fuse.Context) fuse.Status {
+	Verbose("RemoveXAttr() name: %s, attribute: %s, context: %v", name, attribute, context)
+	if attribute != XATTR_TTL {
+		return px.ReadOnlyXAttr(name, attribute, context)
+	}
+	return px.SetTTL(name, 0, context)
+}
+
+/* The metadata and the older versions can't be changed, anything else isn't supported */
+func (px *FuseKVFileSystem) ReadOnlyXAttr(name string, attribute string, context *fuse.Context) fuse.Status {
+	if !strings.HasPrefix(attribute, XATTR_PREFIX) {
+		return fuse.Status(syscall.ENOTSUP)
+	}
+	if strings.HasPrefix(attribute, XATTR_VERSION) {
+		return fuse.EPERM
+	}
+	attributes, status := px.GetNodeAttributes(name, context)
+	if status != fuse.OK {
+		return status
+	}
+	if _, found := attributes[attribute]; found {
+		return fuse.EPERM
+	}
+	return fuse.Status(syscall.ENOTSUP)
+}
+
+func (px *FuseKVFileSystem) SetTTL(name string, ttl time.Duration, context *fuse.Context) (code fuse.Status) {
+	defer func() { px.Audit.Record(context, AUDIT_TTL, name, "", code, 0) }()
+	if !px.Options.Writable {
+		return fuse.EPERM
+	}
+	if !px.ACL.Allowed(name, context, ACL_WRITE) || !px.Permissions.Permitted(name, false, context, ACCESS_WRITE) {
+		return fuse.EACCES
+	}
+	ctx, cancel := WriteContext(context)
+	defer cancel()
+	node, err := px.StoreKV.Get(ctx, name)
+	if err != nil {
+		return StoreStatus(err, fuse.EIO)
+	}
+	if node.IsDir() {
+		return fuse.Status(syscall.EISDIR)
+	}
+	/* step: the key is only changed if it's still as we read it, otherwise someone else's write is lost */
+	if err := px.StoreKV.CompareAndSwapWithTTL(ctx, name, node.Value, node.Index, node.Value, ttl); err != nil {
+		glog.Errorf("SetTTL() failed to set the ttl on key: %s, error: %s", name, err)
+		return StoreStatus(err, fuse.EIO)
+	}
+	px.CleanNode("/" + name)
+	return fuse.OK
+}
+
+func ParseTTL(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return time.ParseDuration(value)
+}
+
 func (px *FuseKVFileSystem) String() string {
 	return fmt.Sprintf("FuseKVFileSystem(%v)", px.FileSystem)
 }
@@ -188,7 +618,7 @@ func (px *FuseKVFileSystem) CleanDir(key string) {
 	px.Cache.Delete(cacheKey)
 }
 
-func (px *FuseKVFileSystem) CachedNode(key string) (*config.Node,error) {
+func (px *FuseKVFileSystem) CachedNode(key string, context *fuse.Context) (*config.Node,error) {
 	var node *config.Node
 	cacheKey := ""
 	if key != ""  {
@@ -198,18 +628,20 @@ func (px *FuseKVFileSystem) CachedNode(key string) (*config.Node,error) {
 			return node.(*config.Node), nil
 		}
 	}
-	node, err := px.StoreKV.Get(key)
+	ctx, cancel := ReadContext(context)
+	defer cancel()
+	node, err := px.StoreKV.Get(ctx, key)
 	if err != nil {
 		glog.Errorf("GetAttr() failed get attribute, path: %s, error: %s", key, err)
 		return nil, err
 	}
-	if key != "" {
-		px.Cache.Set(cacheKey, node, 0)
+	if key != "" && !px.Options.NoCache {
+		px.Cache.Set(cacheKey, node, px.Options.CacheTTL)
 	}
 	return node, nil
 }
 
-func (px *FuseKVFileSystem) CachedListing(key string) ([]*config.Node,error) {
+func (px *FuseKVFileSystem) CachedListing(key string, context *fuse.Context) ([]*config.Node,error) {
 	cacheKey := "/"+key+SUFFIX_CACHE_LISTING
 	if key != "" {
 		nodes, _ := px.Cache.Get(cacheKey)
@@ -217,12 +649,14 @@ func (px *FuseKVFileSystem) CachedListing(key string) ([]*config.Node,error) {
 			return nodes.([]*config.Node), nil
 		}
 	}
-	nodes, err := px.StoreKV.List(key)
+	ctx, cancel := ReadContext(context)
+	defer cancel()
+	nodes, err := px.StoreKV.List(ctx, key)
 	if err != nil || nodes == nil {
 		return nil, err
 	}
-	if key != "" {
-		px.Cache.Set(cacheKey, nodes, 0)
+	if key != "" && !px.Options.NoCache {
+		px.Cache.Set(cacheKey, nodes, px.Options.CacheTTL)
 	}
 	return nodes, nil
 }
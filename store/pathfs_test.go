@@ -0,0 +1,160 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"context"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gambol99/config-store/store/config"
+	"github.com/hanwen/go-fuse/v2/fuse"
+)
+
+func TestInterruptedOperation(t *testing.T) {
+	ResetSharedServices()
+	defer ResetSharedServices()
+	options := &MountOptions{Mount: "/mnt/interrupt", Backend: "mem://", Prefix: "/", Mode: MOUNT_READ_WRITE, Cache: CACHE_NONE}
+	if err := options.Parse(); err != nil {
+		t.Fatalf("invalid options, error: %s", err)
+	}
+	filesystem, err := NewReloadableFileSystem(options)
+	if err != nil {
+		t.Fatalf("failed to create the filesystem, error: %s", err)
+	}
+	defer filesystem.FS().Close()
+	/* step: the backend hangs once the mount is up */
+	filesystem.FS().StoreKV.(*config.MeteredStore).Store.(*config.MemoryStoreClient).SetLatency(time.Minute)
+	operations := map[string]func(context *fuse.Context) fuse.Status{
+		"getattr": func(context *fuse.Context) fuse.Status {
+			_, status := filesystem.GetAttr("/key", context)
+			return status
+		},
+		"opendir": func(context *fuse.Context) fuse.Status {
+			_, status := filesystem.OpenDir("/dir", context)
+			return status
+		},
+		"getxattr": func(context *fuse.Context) fuse.Status {
+			_, status := filesystem.GetXAttr("/key", XATTR_PREFIX+"index", context)
+			return status
+		},
+		"unlink": func(context *fuse.Context) fuse.Status { return filesystem.Unlink("/key", context) },
+	}
+	for name, operation := range operations {
+		cancel := make(chan struct{})
+		result := make(chan fuse.Status, 1)
+		go func() {
+			result <- operation(&fuse.Context{Cancel: cancel})
+		}()
+		/* step: the kernel interrupts the syscall, i.e. the caller was killed */
+		close(cancel)
+		select {
+		case status := <-result:
+			if status != fuse.Status(syscall.EINTR) {
+				t.Errorf("operation: %s, expected EINTR, got: %s", name, status)
+			}
+		case <-time.After(5 * time.Second):
+			t.Errorf("operation: %s, expected the interrupt to stop the operation", name)
+		}
+	}
+}
+
+func TestRenameAccess(t *testing.T) {
+	ResetSharedServices()
+	defer ResetSharedServices()
+	options := &MountOptions{Mount: "/mnt/rename", Backend: "mem://", Prefix: "/", Mode: MOUNT_READ_WRITE, Cache: CACHE_NONE}
+	if err := options.Parse(); err != nil {
+		t.Fatalf("invalid options, error: %s", err)
+	}
+	filesystem, err := NewReloadableFileSystem(options)
+	if err != nil {
+		t.Fatalf("failed to create the filesystem, error: %s", err)
+	}
+	defer filesystem.FS().Close()
+	filesystem.FS().Permissions = &Permissions{Uid: 1000, Gid: 1000, FileMode: 0644, DirMode: 0755,
+		Rules: []*PermissionRule{{Pattern: "/app/writeonly", Mode: 0200, Uid: 1000, Gid: 1000}}}
+	filesystem.FS().ACL = NewTestAccessPolicy(t,
+		"uid:1000 read,list,write,delete /app/**",
+		"uid:1000 write,delete /secret/**").ForMount("/")
+	tests := []struct {
+		From   string
+		To     string
+		Status fuse.Status
+	}{
+		{"app/key", "app/moved", fuse.OK},
+		{"app/missing", "app/moved", fuse.ENOENT},
+		{"app/writeonly", "app/moved", fuse.EACCES},
+		{"secret/key", "secret/moved", fuse.EACCES},
+		{"secret/missing", "secret/moved", fuse.EACCES},
+		{"app/key", "other/moved", fuse.EACCES},
+	}
+	for _, test := range tests {
+		backend := filesystem.FS().StoreKV
+		for _, key := range []string{"/app/key", "/app/writeonly", "/secret/key"} {
+			backend.Set(context.Background(), key, "value")
+		}
+		if status := filesystem.Rename(test.From, test.To, Caller(1000, 1000)); status != test.Status {
+			t.Errorf("rename: %s to: %s, expected: %s, got: %s", test.From, test.To, test.Status, status)
+		}
+	}
+}
+
+func TestVersionXAttr(t *testing.T) {
+	ResetSharedServices()
+	defer ResetSharedServices()
+	options := &MountOptions{Mount: "/mnt/version", Backend: "mem://", Prefix: "/", Mode: MOUNT_READ_WRITE, Cache: CACHE_NONE}
+	if err := options.Parse(); err != nil {
+		t.Fatalf("invalid options, error: %s", err)
+	}
+	filesystem, err := NewReloadableFileSystem(options)
+	if err != nil {
+		t.Fatalf("failed to create the filesystem, error: %s", err)
+	}
+	defer filesystem.FS().Close()
+	filesystem.FS().Permissions = &Permissions{Uid: 1000, Gid: 1000, FileMode: 0644, DirMode: 0755,
+		Rules: []*PermissionRule{{Pattern: "/writeonly", Mode: 0200, Uid: 1000, Gid: 1000}}}
+	backend := filesystem.FS().StoreKV
+	for _, key := range []string{"/key", "/writeonly"} {
+		backend.Set(context.Background(), key, "value")
+	}
+	node, err := backend.Get(context.Background(), "/key")
+	if err != nil {
+		t.Fatalf("failed to get the key, error: %s", err)
+	}
+	/* step: the memory store only has the version the key is at now */
+	current := strconv.FormatUint(node.Index, 10)
+	tests := []struct {
+		Name      string
+		Attribute string
+		Value     string
+		Status    fuse.Status
+	}{
+		{"key", XATTR_VERSION + current, "value", fuse.OK},
+		{"key", XATTR_VERSION + strconv.FormatUint(node.Index+1, 10), "", fuse.ENODATA},
+		{"key", XATTR_VERSION + "latest", "", fuse.ENODATA},
+		{"missing", XATTR_VERSION + "1", "", fuse.ENODATA},
+		{"writeonly", XATTR_VERSION + current, "", fuse.EACCES},
+	}
+	for _, test := range tests {
+		value, status := filesystem.GetXAttr(test.Name, test.Attribute, Caller(1000, 1000))
+		if status != test.Status || string(value) != test.Value {
+			t.Errorf("name: %s, attribute: %s, expected: %q (%s), got: %q (%s)", test.Name, test.Attribute, test.Value, test.Status, value, status)
+		}
+	}
+	if status := filesystem.SetXAttr("key", XATTR_VERSION+current, []byte("changed"), 0, Caller(1000, 1000)); status != fuse.EPERM {
+		t.Errorf("expected the versions to be read only, got: %s", status)
+	}
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gambol99/config-store/store/config"
+	"github.com/golang/glog"
+	"github.com/hanwen/go-fuse/v2/fuse"
+)
+
+const (
+	/* the access being asked for, same as access(2) */
+	ACCESS_READ    = 4
+	ACCESS_WRITE   = 2
+	ACCESS_EXECUTE = 1
+)
+
+var default_uid, default_gid *uint
+var default_file_mode, default_dir_mode, permission_rules *string
+
+var InvalidPermissionRuleErr = errors.New("Invalid permission rule, must be: <pattern> <mode> [owner[:group]]")
+
+func init() {
+	default_uid = flag.Uint("uid", 0, "the default owner of the files and directories")
+	default_gid = flag.Uint("gid", 0, "the default group of the files and directories")
+	default_file_mode = flag.String("file-mode", "", "the default mode of the files, 0444 or 0644 when writable")
+	default_dir_mode = flag.String("dir-mode", "0555", "the default mode of the directories")
+	permission_rules = flag.String("perms", "", "a file of per prefix permission rules, i.e. /secrets/** 0400 app:app")
+}
+
+type PermissionRule struct {
+	/* the pattern of the paths the rule covers, i.e. /secrets/** */
+	Pattern string
+	/* the mode of the files, directories get execute wherever they have read */
+	Mode uint32
+	/* the owner and group */
+	Uid uint32
+	Gid uint32
+}
+
+/*
+The ownership and modes of the files and directories; the rules are checked in order and the
+first one matching the path applies, otherwise we fall back to the defaults. We enforce them
+ourselves against the caller, so the mount can be shared with allow_other
+*/
+type Permissions struct {
+	/* the owner and group when no rule matches */
+	Uid uint32
+	Gid uint32
+	/* the default modes */
+	FileMode uint32
+	DirMode  uint32
+	/* the rules for the prefixes */
+	Rules []*PermissionRule
+}
+
+func NewPermissions(writable bool) (*Permissions, error) {
+	permissions := &Permissions{
+		Uid:      uint32(*default_uid),
+		Gid:      uint32(*default_gid),
+		FileMode: 0444,
+		DirMode:  0555,
+		Rules:    make([]*PermissionRule, 0)}
+	if writable {
+		permissions.FileMode = 0644
+	}
+	var err error
+	if *default_file_mode != "" {
+		if permissions.FileMode, err = ParseMode(*default_file_mode); err != nil {
+			glog.Errorf("Invalid file mode: %s, error: %s", *default_file_mode, err)
+			return nil, err
+		}
+	}
+	if permissions.DirMode, err = ParseMode(*default_dir_mode); err != nil {
+		glog.Errorf("Invalid directory mode: %s, error: %s", *default_dir_mode, err)
+		return nil, err
+	}
+	if *permission_rules != "" {
+		if permissions.Rules, err = LoadPermissionRules(*permission_rules); err != nil {
+			glog.Errorf("Failed to load the permission rules: %s, error: %s", *permission_rules, err)
+			return nil, err
+		}
+	}
+	return permissions, nil
+}
+
+/* Read the rules from the file, one per line; blank lines and anything after a # are ignored */
+func LoadPermissionRules(filename string) ([]*PermissionRule, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	rules := make([]*PermissionRule, 0)
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if index := strings.Index(text, "#"); index >= 0 {
+			text = text[:index]
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		rule, err := ParsePermissionRule(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", line, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, scanner.Err()
+}
+
+/* Parse a rule, i.e. /secrets/** 0400 app:app; without an owner the defaults are used */
+func ParsePermissionRule(line string) (*PermissionRule, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 || len(fields) > 3 || !strings.HasPrefix(fields[0], "/") {
+		return nil, InvalidPermissionRuleErr
+	}
+	mode, err := ParseMode(fields[1])
+	if err != nil {
+		return nil, err
+	}
+	rule := &PermissionRule{
+		Pattern: filepath.Clean(fields[0]),
+		Mode:    mode,
+		Uid:     uint32(*default_uid),
+		Gid:     uint32(*default_gid)}
+	if len(fields) == 3 {
+		if rule.Uid, rule.Gid, err = LookupOwner(fields[2]); err != nil {
+			return nil, err
+		}
+	}
+	return rule, nil
+}
+
+func ParseMode(value string) (uint32, error) {
+	mode, err := strconv.ParseUint(value, 8, 32)
+	if err != nil || mode > 07777 {
+		return 0, fmt.Errorf("invalid mode: %s", value)
+	}
+	return uint32(mode), nil
+}
+
+/* Resolve the owner[:group], either can be a name or a number; without a group we use the user's */
+func LookupOwner(owner string) (uint32, uint32, error) {
+	name, group := owner, ""
+	if index := strings.Index(owner, ":"); index >= 0 {
+		name, group = owner[:index], owner[index+1:]
+	}
+	var uid, gid uint64
+	var err error
+	if uid, err = strconv.ParseUint(name, 10, 32); err != nil {
+		account, err := user.Lookup(name)
+		if err != nil {
+			return 0, 0, err
+		}
+		uid, _ = strconv.ParseUint(account.Uid, 10, 32)
+		if group == "" {
+			gid, _ = strconv.ParseUint(account.Gid, 10, 32)
+		}
+	} else if group == "" {
+		gid = uint64(*default_gid)
+	}
+	if group != "" {
+		if gid, err = strconv.ParseUint(group, 10, 32); err != nil {
+			entry, err := user.LookupGroup(group)
+			if err != nil {
+				return 0, 0, err
+			}
+			gid, _ = strconv.ParseUint(entry.Gid, 10, 32)
+		}
+	}
+	return uint32(uid), uint32(gid), nil
+}
+
+/* The mode, owner and group for the path */
+func (r *Permissions) Attributes(path string, directory bool) (uint32, uint32, uint32) {
+	path = filepath.Clean("/" + path)
+	for _, rule := range r.Rules {
+		if config.MatchPattern(rule.Pattern, path) {
+			if directory {
+				return rule.Mode | (rule.Mode&0444)>>2, rule.Uid, rule.Gid
+			}
+			return rule.Mode, rule.Uid, rule.Gid
+		}
+	}
+	if directory {
+		return r.DirMode, r.Uid, r.Gid
+	}
+	return r.FileMode, r.Uid, r.Gid
+}
+
+/* Fill in the mode and ownership of the attributes */
+func (r *Permissions) SetAttr(path string, directory bool, attr *fuse.Attr) {
+	mode, uid, gid := r.Attributes(path, directory)
+	if directory {
+		attr.Mode = fuse.S_IFDIR | mode
+	} else {
+		attr.Mode = fuse.S_IFREG | mode
+	}
+	attr.Uid = uid
+	attr.Gid = gid
+}
+
+/*
+Check the caller can have the access to the path, which also means being able to search every
+directory above it. Note we only know the primary group of the caller, so supplementary groups
+don't count; root can do anything
+*/
+func (r *Permissions) Permitted(path string, directory bool, caller *fuse.Context, access uint32) bool {
+	if caller == nil || caller.Uid == 0 {
+		return true
+	}
+	path = filepath.Clean("/" + path)
+	for parent := path; parent != "/"; {
+		parent = filepath.Dir(parent)
+		if !r.Allowed(parent, true, caller, ACCESS_EXECUTE) {
+			return false
+		}
+	}
+	return r.Allowed(path, directory, caller, access)
+}
+
+func (r *Permissions) Allowed(path string, directory bool, caller *fuse.Context, access uint32) bool {
+	mode, uid, gid := r.Attributes(path, directory)
+	switch {
+	case caller.Uid == uid:
+		mode = mode >> 6
+	case caller.Gid == gid:
+		mode = mode >> 3
+	}
+	return mode&access == access
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"reflect"
+	"testing"
+)
+
+func NewTestPermissions(t *testing.T, lines ...string) *Permissions {
+	permissions := &Permissions{Uid: 0, Gid: 0, FileMode: 0444, DirMode: 0555}
+	for _, line := range lines {
+		rule, err := ParsePermissionRule(line)
+		if err != nil {
+			t.Fatalf("invalid rule: %s, error: %s", line, err)
+		}
+		permissions.Rules = append(permissions.Rules, rule)
+	}
+	return permissions
+}
+
+func TestParsePermissionRule(t *testing.T) {
+	tests := []struct {
+		Line  string
+		Rule  *PermissionRule
+		Error bool
+	}{
+		{Line: "/secrets/** 0400 1000:1001", Rule: &PermissionRule{Pattern: "/secrets/**", Mode: 0400, Uid: 1000, Gid: 1001}},
+		{Line: "/secrets/ 0640", Rule: &PermissionRule{Pattern: "/secrets", Mode: 0640}},
+		{Line: "  /app/*   0644   1000  ", Rule: &PermissionRule{Pattern: "/app/*", Mode: 0644, Uid: 1000}},
+		{Line: "/app/* 0644 root:0", Rule: &PermissionRule{Pattern: "/app/*", Mode: 0644}},
+		{Line: "secrets/** 0400", Error: true},
+		{Line: "/secrets/**", Error: true},
+		{Line: "/secrets/** 0400 1000 extra", Error: true},
+		{Line: "/secrets/** 0800", Error: true},
+		{Line: "/secrets/** 17777", Error: true},
+		{Line: "/secrets/** 0400 no-such-user-here", Error: true},
+	}
+	for _, test := range tests {
+		rule, err := ParsePermissionRule(test.Line)
+		if test.Error {
+			if err == nil {
+				t.Errorf("rule: %q, expected an error, got: %v", test.Line, rule)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("rule: %q, failed to parse, error: %s", test.Line, err)
+			continue
+		}
+		if !reflect.DeepEqual(rule, test.Rule) {
+			t.Errorf("rule: %q, expected: %+v, got: %+v", test.Line, test.Rule, rule)
+		}
+	}
+}
+
+/* The first rule matching the path applies, directories get search wherever they have read */
+func TestPermissionsAttributes(t *testing.T) {
+	permissions := NewTestPermissions(t,
+		"/secrets/db/password 0400 1000:1000",
+		"/secrets/** 0440 1001:1001",
+		"/app/* 0640 1002:1002")
+	tests := []struct {
+		Path      string
+		Directory bool
+		Mode      uint32
+		Uid       uint32
+	}{
+		{"/secrets/db/password", false, 0400, 1000},
+		{"secrets/db/password", false, 0400, 1000},
+		{"/secrets/db/user", false, 0440, 1001},
+		{"/secrets", true, 0550, 1001},
+		{"/secrets/db", true, 0550, 1001},
+		{"/app/config", false, 0640, 1002},
+		{"/app/config", true, 0750, 1002},
+		{"/app/config/nested", false, 0444, 0},
+		{"/app", true, 0555, 0},
+		{"/other", false, 0444, 0},
+	}
+	for _, test := range tests {
+		mode, uid, gid := permissions.Attributes(test.Path, test.Directory)
+		if mode != test.Mode || uid != test.Uid || gid != test.Uid {
+			t.Errorf("path: %s, directory: %t, expected: %o %d:%d, got: %o %d:%d", test.Path, test.Directory, test.Mode, test.Uid, test.Uid, mode, uid, gid)
+		}
+	}
+}
+
+func TestPermissionsPermitted(t *testing.T) {
+	permissions := NewTestPermissions(t,
+		"/secrets 0500 1000:1000",
+		"/secrets/** 0640 1000:2000",
+		"/locked 0000 1000:1000",
+		"/app/config 0604 1000:1000")
+	tests := []struct {
+		Path      string
+		Directory bool
+		Uid       uint32
+		Gid       uint32
+		Access    uint32
+		Permitted bool
+	}{
+		{"/secrets/db", false, 1000, 1000, ACCESS_READ, true},
+		{"/secrets/db", false, 1000, 1000, ACCESS_WRITE, true},
+		{"/secrets/db", false, 1000, 1000, ACCESS_READ | ACCESS_WRITE, true},
+		{"/secrets/db", false, 1001, 2000, ACCESS_READ, false},
+		{"/secrets/db", false, 1001, 2000, ACCESS_WRITE, false},
+		{"/secrets", true, 1001, 2000, ACCESS_READ, false},
+		{"/secrets", true, 1000, 2000, ACCESS_READ | ACCESS_EXECUTE, true},
+		{"/locked/key", false, 1000, 1000, ACCESS_READ, false},
+		{"/locked", true, 1000, 1000, 0, true},
+		{"/app/config", false, 1000, 1000, ACCESS_READ, true},
+		{"/app/config", false, 1000, 1000, ACCESS_WRITE, true},
+		{"/app/config", false, 1001, 1000, ACCESS_READ, false},
+		{"/app/config", false, 1001, 1001, ACCESS_READ, true},
+		{"/app/config", false, 1001, 1001, ACCESS_WRITE, false},
+		{"/secrets/db", false, 0, 0, ACCESS_READ | ACCESS_WRITE, true},
+	}
+	for _, test := range tests {
+		if permitted := permissions.Permitted(test.Path, test.Directory, Caller(test.Uid, test.Gid), test.Access); permitted != test.Permitted {
+			t.Errorf("path: %s, caller: %d:%d, access: %o, expected: %t, got: %t", test.Path, test.Uid, test.Gid, test.Access, test.Permitted, permitted)
+		}
+	}
+	if !permissions.Permitted("/locked/key", false, nil, ACCESS_READ) {
+		t.Errorf("expected the calls without a caller to be permitted")
+	}
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/hanwen/go-fuse/v2/fuse"
+	"github.com/hanwen/go-fuse/v2/fuse/nodefs"
+	"github.com/hanwen/go-fuse/v2/fuse/pathfs"
+)
+
+/*
+The filesystem a mount serves, which passes each call on to the current K/V filesystem. A reload
+creates a new one from the flags and swaps it in without touching the mount; a call already under
+way, or a file already open, carries on with the one it started with, which releases its backends
+and audit log once they're done
+*/
+type ReloadableFileSystem struct {
+	sync.RWMutex
+	/* the filesystem the calls are passed to */
+	Current *FuseKVFileSystem
+}
+
+func NewReloadableFileSystem(options *MountOptions) (*ReloadableFileSystem, error) {
+	filesystem, err := NewFuseKVFileSystem(options)
+	if err != nil {
+		return nil, err
+	}
+	return &ReloadableFileSystem{Current: filesystem}, nil
+}
+
+/* Swap in a filesystem created from the options, on an error the current one is kept */
+func (r *ReloadableFileSystem) Reload(options *MountOptions) error {
+	filesystem, err := NewFuseKVFileSystem(options)
+	if err != nil {
+		glog.Errorf("Failed to reload the mount: %s, keeping the current filesystem, error: %s", options.Mount, err)
+		return err
+	}
+	r.Lock()
+	previous := r.Current
+	r.Current = filesystem
+	r.Unlock()
+	previous.Close()
+	glog.Infof("Reloaded the mount, %s", options)
+	return nil
+}
+
+/* Read everything from the store again, a new filesystem with an empty cache and a new watch */
+func (r *ReloadableFileSystem) Resync() error {
+	/* step: a resync isn't part of a reload, so the flags it reads mustn't change under it */
+	settings_lock.RLock()
+	defer settings_lock.RUnlock()
+	return r.Reload(r.FS().Options)
+}
+
+func (r *ReloadableFileSystem) FS() *FuseKVFileSystem {
+	r.RLock()
+	defer r.RUnlock()
+	return r.Current
+}
+
+/* The current filesystem, which isn't closed out from under the caller until it calls Active.Done() */
+func (r *ReloadableFileSystem) Hold() *FuseKVFileSystem {
+	r.RLock()
+	defer r.RUnlock()
+	r.Current.Active.Add(1)
+	return r.Current
+}
+
+func (r *ReloadableFileSystem) String() string {
+	return r.FS().String()
+}
+
+func (r *ReloadableFileSystem) SetDebug(debug bool) {
+	r.FS().SetDebug(debug)
+}
+
+func (r *ReloadableFileSystem) GetAttr(name string, context *fuse.Context) (attr *fuse.Attr, code fuse.Status) {
+	defer Measure("getattr", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.GetAttr(name, context)
+}
+
+func (r *ReloadableFileSystem) Chmod(name string, mode uint32, context *fuse.Context) (code fuse.Status) {
+	defer Measure("chmod", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.Chmod(name, mode, context)
+}
+
+func (r *ReloadableFileSystem) Chown(name string, uid uint32, gid uint32, context *fuse.Context) (code fuse.Status) {
+	defer Measure("chown", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.Chown(name, uid, gid, context)
+}
+
+func (r *ReloadableFileSystem) Utimens(name string, Atime *time.Time, Mtime *time.Time, context *fuse.Context) (code fuse.Status) {
+	defer Measure("utimens", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.Utimens(name, Atime, Mtime, context)
+}
+
+func (r *ReloadableFileSystem) Truncate(name string, size uint64, context *fuse.Context) (code fuse.Status) {
+	defer Measure("truncate", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.Truncate(name, size, context)
+}
+
+func (r *ReloadableFileSystem) Access(name string, mode uint32, context *fuse.Context) (code fuse.Status) {
+	defer Measure("access", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.Access(name, mode, context)
+}
+
+func (r *ReloadableFileSystem) Link(oldName string, newName string, context *fuse.Context) (code fuse.Status) {
+	defer Measure("link", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.Link(oldName, newName, context)
+}
+
+func (r *ReloadableFileSystem) Mkdir(name string, mode uint32, context *fuse.Context) (code fuse.Status) {
+	defer Measure("mkdir", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.Mkdir(name, mode, context)
+}
+
+func (r *ReloadableFileSystem) Mknod(name string, mode uint32, dev uint32, context *fuse.Context) (code fuse.Status) {
+	defer Measure("mknod", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.Mknod(name, mode, dev, context)
+}
+
+func (r *ReloadableFileSystem) Rename(oldName string, newName string, context *fuse.Context) (code fuse.Status) {
+	defer Measure("rename", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.Rename(oldName, newName, context)
+}
+
+func (r *ReloadableFileSystem) Rmdir(name string, context *fuse.Context) (code fuse.Status) {
+	defer Measure("rmdir", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.Rmdir(name, context)
+}
+
+func (r *ReloadableFileSystem) Unlink(name string, context *fuse.Context) (code fuse.Status) {
+	defer Measure("unlink", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.Unlink(name, context)
+}
+
+func (r *ReloadableFileSystem) GetXAttr(name string, attribute string, context *fuse.Context) (data []byte, code fuse.Status) {
+	defer Measure("getxattr", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.GetXAttr(name, attribute, context)
+}
+
+func (r *ReloadableFileSystem) ListXAttr(name string, context *fuse.Context) (attributes []string, code fuse.Status) {
+	defer Measure("listxattr", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.ListXAttr(name, context)
+}
+
+func (r *ReloadableFileSystem) RemoveXAttr(name string, attr string, context *fuse.Context) (code fuse.Status) {
+	defer Measure("removexattr", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.RemoveXAttr(name, attr, context)
+}
+
+func (r *ReloadableFileSystem) SetXAttr(name string, attr string, data []byte, flags int, context *fuse.Context) (code fuse.Status) {
+	defer Measure("setxattr", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.SetXAttr(name, attr, data, flags, context)
+}
+
+func (r *ReloadableFileSystem) OnMount(nodeFs *pathfs.PathNodeFs) {
+	r.FS().OnMount(nodeFs)
+}
+
+/* The mount is gone, so is the watch */
+func (r *ReloadableFileSystem) OnUnmount() {
+	filesystem := r.FS()
+	filesystem.OnUnmount()
+	filesystem.Close()
+}
+
+func (r *ReloadableFileSystem) Open(name string, flags uint32, context *fuse.Context) (file nodefs.File, code fuse.Status) {
+	defer Measure("open", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.Open(name, flags, context)
+}
+
+func (r *ReloadableFileSystem) Create(name string, flags uint32, mode uint32, context *fuse.Context) (file nodefs.File, code fuse.Status) {
+	defer Measure("create", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.Create(name, flags, mode, context)
+}
+
+func (r *ReloadableFileSystem) OpenDir(name string, context *fuse.Context) (stream []fuse.DirEntry, code fuse.Status) {
+	defer Measure("opendir", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.OpenDir(name, context)
+}
+
+func (r *ReloadableFileSystem) Symlink(value string, linkName string, context *fuse.Context) (code fuse.Status) {
+	defer Measure("symlink", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.Symlink(value, linkName, context)
+}
+
+func (r *ReloadableFileSystem) Readlink(name string, context *fuse.Context) (link string, code fuse.Status) {
+	defer Measure("readlink", time.Now(), &code)
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.Readlink(name, context)
+}
+
+func (r *ReloadableFileSystem) StatFs(name string) *fuse.StatfsOut {
+	filesystem := r.Hold()
+	defer filesystem.Active.Done()
+	return filesystem.StatFs(name)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hanwen/go-fuse/v2/fuse"
+)
+
+func AuditorClosed(auditor *Auditor) bool {
+	auditor.Lock()
+	defer auditor.Unlock()
+	_, err := auditor.Writer.Write(nil)
+	return err != nil
+}
+
+/* The services replaced by a reload are closed once the old filesystem is done, not before */
+func TestReloadClosesTheSharedServices(t *testing.T) {
+	directory, err := ioutil.TempDir("", "reload")
+	if err != nil {
+		t.Fatalf("failed to create a directory, error: %s", err)
+	}
+	defer os.RemoveAll(directory)
+	*audit_log = filepath.Join(directory, "audit.log")
+	defer func() { *audit_log = "" }()
+	ResetSharedServices()
+	defer ResetSharedServices()
+	options := &MountOptions{Mount: "/mnt/test", Backend: "mem://", Prefix: "/", Mode: MOUNT_READ_ONLY, Cache: CACHE_WATCH}
+	if err := options.Parse(); err != nil {
+		t.Fatalf("invalid options, error: %s", err)
+	}
+	filesystem, err := NewReloadableFileSystem(options)
+	if err != nil {
+		t.Fatalf("failed to create the filesystem, error: %s", err)
+	}
+	previous := filesystem.FS()
+	if err := previous.StoreKV.Set(context.Background(), "/key", "value"); err != nil {
+		t.Fatalf("failed to set the key, error: %s", err)
+	}
+	file, code := filesystem.Open("/key", syscall.O_RDONLY, &fuse.Context{})
+	if code != fuse.OK {
+		t.Fatalf("failed to open the file, status: %s", code)
+	}
+	ResetSharedServices()
+	if err := filesystem.Reload(options); err != nil {
+		t.Fatalf("failed to reload, error: %s", err)
+	}
+	defer filesystem.FS().Close()
+	if filesystem.FS().Shared == previous.Shared {
+		t.Fatalf("expected the reload to create new shared services")
+	}
+	/* step: the file is still open on the old filesystem */
+	time.Sleep(50 * time.Millisecond)
+	if AuditorClosed(previous.Audit) {
+		t.Fatalf("the audit log was closed while a file was open on it")
+	}
+	file.Release()
+	for deadline := time.Now().Add(time.Second); !AuditorClosed(previous.Audit); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the audit log to be closed once the file was released")
+		}
+	}
+	if AuditorClosed(filesystem.FS().Audit) {
+		t.Errorf("expected the audit log of the new filesystem to be open")
+	}
+}
@@ -0,0 +1,392 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/gambol99/config-store/store/config"
+	"github.com/golang/glog"
+)
+
+var InvalidSettingErr = errors.New("Invalid setting, must be a string or an array of strings")
+
+/*
+The settings file, which is toml, i.e.
+
+	kv = "consul://127.0.0.1:8500"
+	kv-timeout = "5s"
+	redact = ["/secrets/**", "/prod/db/password"]
+
+	[consul]
+	token = "secret"
+
+	[log]
+	v = 3
+
+	[[mounts]]
+	mount = "/data/payments"
+	prefix = "/prod/payments"
+	mode = "ro"
+	cache = "30s"
+
+each key sets the flag in its tag; the keys in the consul and etcd sections are the flags named
+<section>-<key>, i.e. consul-token, and those in the log section are the logging flags as they are.
+The file is the whole of the settings, a key taken out of it puts the flag back to its default.
+There are no discovery or template sections; the discovery package isn't built into the daemon, so
+its -discovery flag doesn't exist, and the templates have nothing to set yet
+*/
+type SettingsFile struct {
+	Backend               *string        `toml:"kv" flag:"kv"`
+	ReadTimeout           *string        `toml:"kv-timeout" flag:"kv-timeout"`
+	WriteTimeout          *string        `toml:"kv-write-timeout" flag:"kv-write-timeout"`
+	CA                    *string        `toml:"kv-ca" flag:"kv-ca"`
+	Cert                  *string        `toml:"kv-cert" flag:"kv-cert"`
+	Key                   *string        `toml:"kv-key" flag:"kv-key"`
+	ServerName            *string        `toml:"kv-server-name" flag:"kv-server-name"`
+	Insecure              *bool          `toml:"kv-insecure" flag:"kv-insecure"`
+	VaultToken            *string        `toml:"vault-token" flag:"vault-token"`
+	Mount                 *string        `toml:"mount" flag:"mount"`
+	Prefix                *string        `toml:"prefix" flag:"prefix"`
+	Layers                SettingValues  `toml:"layer" flag:"layer"`
+	Cache                 *string        `toml:"cache" flag:"cache"`
+	Writable              *bool          `toml:"writable" flag:"writable"`
+	AllowOther            *bool          `toml:"allow-other" flag:"allow-other"`
+	Uid                   *int64         `toml:"uid" flag:"uid"`
+	Gid                   *int64         `toml:"gid" flag:"gid"`
+	FileMode              *string        `toml:"file-mode" flag:"file-mode"`
+	DirMode               *string        `toml:"dir-mode" flag:"dir-mode"`
+	Permissions           *string        `toml:"perms" flag:"perms"`
+	AccessPolicy          *string        `toml:"acl" flag:"acl"`
+	Audit                 *string        `toml:"audit" flag:"audit"`
+	AuditReadSample       *float64       `toml:"audit-read-sample" flag:"audit-read-sample"`
+	Redact                SettingValues  `toml:"redact" flag:"redact"`
+	RedactDisplay         *string        `toml:"redact-display" flag:"redact-display"`
+	Encrypt               SettingValues  `toml:"encrypt" flag:"encrypt"`
+	EncryptKeys           *string        `toml:"encrypt-keys" flag:"encrypt-keys"`
+	EncryptAllowPlaintext *bool          `toml:"encrypt-allow-plaintext" flag:"encrypt-allow-plaintext"`
+	Control               *string        `toml:"control" flag:"control"`
+	Metrics               *string        `toml:"metrics" flag:"metrics"`
+	Consul                ConsulSettings `toml:"consul"`
+	Etcd                  EtcdSettings   `toml:"etcd"`
+	Log                   LogSettings    `toml:"log"`
+	/* the mounts, each a [[mounts]] table */
+	Mounts []*MountOptions `toml:"mounts"`
+}
+
+type ConsulSettings struct {
+	Datacenter  *string `toml:"dc" flag:"consul-dc"`
+	Token       *string `toml:"token" flag:"consul-token"`
+	Consistency *string `toml:"consistency" flag:"consul-consistency"`
+	Namespace   *string `toml:"namespace" flag:"consul-namespace"`
+	Prefix      *string `toml:"prefix" flag:"consul-prefix"`
+}
+
+type EtcdSettings struct {
+	Username *string `toml:"username" flag:"etcd-username"`
+	Password *string `toml:"password" flag:"etcd-password"`
+}
+
+/* The logging flags, i.e. v = 3 or log_dir = "/var/log/config-store" */
+type LogSettings struct {
+	Verbosity       *int64  `toml:"v" flag:"v"`
+	Modules         *string `toml:"vmodule" flag:"vmodule"`
+	Directory       *string `toml:"log_dir" flag:"log_dir"`
+	ToStderr        *bool   `toml:"logtostderr" flag:"logtostderr"`
+	AlsoToStderr    *bool   `toml:"alsologtostderr" flag:"alsologtostderr"`
+	StderrThreshold *string `toml:"stderrthreshold" flag:"stderrthreshold"`
+	BacktraceAt     *string `toml:"log_backtrace_at" flag:"log_backtrace_at"`
+}
+
+/* A setting which is either a string or an array of them, i.e. redact = "/secrets/**" */
+type SettingValues []string
+
+func (r *SettingValues) UnmarshalTOML(data interface{}) error {
+	switch value := data.(type) {
+	case string:
+		*r = SettingValues{value}
+	case []interface{}:
+		values := make(SettingValues, 0, len(value))
+		for _, item := range value {
+			text, ok := item.(string)
+			if !ok {
+				return InvalidSettingErr
+			}
+			values = append(values, text)
+		}
+		*r = values
+	default:
+		return InvalidSettingErr
+	}
+	return nil
+}
+
+/* A flag to set from the settings file and the values it's set to */
+type Setting struct {
+	/* the name of the flag */
+	Name string
+	/* the value, or the values of an array */
+	Values []string
+}
+
+/* The settings read from the file */
+type Settings struct {
+	/* the file they were read from */
+	File string
+	/* the flags to set */
+	Flags []*Setting
+	/* the mounts, if the file has any */
+	Mounts []*MountOptions
+}
+
+/* The flags which implement this can be repeated, each value is a Set */
+type RepeatedFlag interface {
+	Values() []string
+	Reset()
+}
+
+/*
+The flags are only changed with this held; a reload applies the settings under it, and anything
+which reads the flags outside of a reload, i.e. a resync from the control api, holds it to read
+*/
+var settings_lock sync.RWMutex
+
+/*
+The settings the filesystems read as they serve the calls. They're taken from the flags once the
+settings are applied, at start and on a reload, and swapped in whole, so a call never reads a flag
+a reload is changing
+*/
+type RuntimeSettings struct {
+	/* the deadline for a read from the store */
+	ReadTimeout time.Duration
+	/* the deadline for a write to the store */
+	WriteTimeout time.Duration
+}
+
+var runtime_settings atomic.Value
+
+/* Take the runtime settings from the flags, once they've been applied and validated */
+func LoadRuntimeSettings() {
+	settings_lock.RLock()
+	defer settings_lock.RUnlock()
+	runtime_settings.Store(&RuntimeSettings{
+		ReadTimeout:  *backend_read_timeout,
+		WriteTimeout: *backend_write_timeout})
+	config.ResetRedaction()
+}
+
+/* The runtime settings, taken from the flags on first use if they haven't been loaded */
+func CurrentSettings() *RuntimeSettings {
+	if current, found := runtime_settings.Load().(*RuntimeSettings); found {
+		return current
+	}
+	LoadRuntimeSettings()
+	return runtime_settings.Load().(*RuntimeSettings)
+}
+
+/* The flags given on the command line, which take precedence over the settings file */
+func CommandLineFlags() map[string]bool {
+	explicit := make(map[string]bool, 0)
+	flag.Visit(func(option *flag.Flag) {
+		explicit[option.Name] = true
+	})
+	return explicit
+}
+
+/* Read the settings file; any error carries the file, and the line if the parser knows it */
+func LoadSettings(filename string) (*Settings, error) {
+	file := new(SettingsFile)
+	metadata, err := toml.DecodeFile(filename, file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, 0, len(undecoded))
+		for _, key := range undecoded {
+			keys = append(keys, key.String())
+		}
+		return nil, fmt.Errorf("%s: unknown settings: %s", filename, strings.Join(keys, ", "))
+	}
+	settings := &Settings{File: filename, Mounts: file.Mounts}
+	SettingFlags(reflect.ValueOf(file).Elem(), &settings.Flags)
+	return settings, nil
+}
+
+/* Add the fields of the section which are set in the file to the flags, in the order they're declared */
+func SettingFlags(section reflect.Value, flags *[]*Setting) {
+	for index := 0; index < section.NumField(); index++ {
+		field, name := section.Field(index), section.Type().Field(index).Tag.Get("flag")
+		switch {
+		case field.Kind() == reflect.Struct:
+			SettingFlags(field, flags)
+		case name == "" || field.IsNil():
+		case field.Kind() == reflect.Slice:
+			*flags = append(*flags, &Setting{Name: name, Values: field.Interface().(SettingValues)})
+		default:
+			*flags = append(*flags, &Setting{Name: name, Values: []string{fmt.Sprint(field.Elem().Interface())}})
+		}
+	}
+}
+
+/* The names of the flags the settings file sets, in the order they're declared */
+func SettingNames(section reflect.Type, names *[]string) {
+	for index := 0; index < section.NumField(); index++ {
+		field := section.Field(index)
+		switch name := field.Tag.Get("flag"); {
+		case field.Type.Kind() == reflect.Struct:
+			SettingNames(field.Type, names)
+		case name != "":
+			*names = append(*names, name)
+		}
+	}
+}
+
+/* Fill in the defaults of the mounts from the flags, once the settings are applied, and check them */
+func (r *Settings) ParseMounts() error {
+	if err := ParseMounts(r.Mounts); err != nil {
+		return fmt.Errorf("%s: %s", r.File, err)
+	}
+	return nil
+}
+
+/*
+Set the flags from the settings, skipping any given on the command line; the flags the file can
+set but doesn't go back to their defaults, so a setting removed on a reload doesn't linger. Nothing
+is changed unless all the settings are flags. The function returned puts the flags back as they were
+*/
+func (r *Settings) Apply(explicit map[string]bool) (func(), error) {
+	for _, setting := range r.Flags {
+		if flag.Lookup(setting.Name) == nil {
+			return nil, fmt.Errorf("%s: unknown setting: %s", r.File, setting.Name)
+		}
+	}
+	settings_lock.Lock()
+	defer settings_lock.Unlock()
+	previous := make(map[string][]string, 0)
+	restore := func() {
+		for name, values := range previous {
+			SetFlag(flag.Lookup(name), values)
+		}
+	}
+	settings := make(map[string][]string, 0)
+	for _, setting := range r.Flags {
+		settings[setting.Name] = setting.Values
+	}
+	names := make([]string, 0)
+	SettingNames(reflect.TypeOf(SettingsFile{}), &names)
+	for _, name := range names {
+		option := flag.Lookup(name)
+		if _, found := settings[name]; found || option == nil || explicit[name] {
+			continue
+		}
+		values, defaults := FlagValues(option), DefaultValues(option)
+		if reflect.DeepEqual(values, defaults) || (len(values) == 0 && len(defaults) == 0) {
+			continue
+		}
+		/* step: not every flag can parse its own default, i.e. log_backtrace_at, those keep their value */
+		previous[name] = values
+		if err := SetFlag(option, defaults); err != nil {
+			glog.Warningf("The setting: %s is no longer in %s but can't be reset, keeping: %v, error: %s", name, r.File, values, err)
+		}
+	}
+	for _, setting := range r.Flags {
+		if explicit[setting.Name] {
+			glog.Infof("The setting: %s in %s is overridden by the command line", setting.Name, r.File)
+			continue
+		}
+		option := flag.Lookup(setting.Name)
+		previous[setting.Name] = FlagValues(option)
+		if err := SetFlag(option, setting.Values); err != nil {
+			restore()
+			return nil, fmt.Errorf("%s: invalid value for %s: %s", r.File, setting.Name, err)
+		}
+	}
+	return func() {
+		settings_lock.Lock()
+		defer settings_lock.Unlock()
+		restore()
+	}, nil
+}
+
+/* The current values of the flag */
+func FlagValues(option *flag.Flag) []string {
+	if repeated, ok := option.Value.(RepeatedFlag); ok {
+		return repeated.Values()
+	}
+	return []string{option.Value.String()}
+}
+
+/* The values of the flag by default, a repeated flag has none */
+func DefaultValues(option *flag.Flag) []string {
+	if _, ok := option.Value.(RepeatedFlag); ok {
+		return nil
+	}
+	return []string{option.DefValue}
+}
+
+/* Set the flag; a repeated flag is set once per value, any other gets them comma separated */
+func SetFlag(option *flag.Flag, values []string) error {
+	if repeated, ok := option.Value.(RepeatedFlag); ok {
+		repeated.Reset()
+		for _, value := range values {
+			if err := option.Value.Set(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return option.Value.Set(strings.Join(values, ","))
+}
+
+/*
+Check the mounts and the settings now in the flags hang together, so a mistake is an error at
+start, or on a reload, rather than when the filesystem is first used
+*/
+func ValidateSettings(mounts []*MountOptions) error {
+	if err := ValidBackend(*backend_kv_url); err != nil {
+		return err
+	}
+	for _, options := range mounts {
+		if err := ValidBackend(options.Backend); err != nil {
+			return fmt.Errorf("mount: %s, %s", options.Mount, err)
+		}
+		for _, spec := range options.Layers {
+			if index := strings.Index(spec, "@"); index >= 0 {
+				if err := ValidBackend(spec[index+1:]); err != nil {
+					return fmt.Errorf("mount: %s, layer: %s, %s", options.Mount, spec, err)
+				}
+			}
+		}
+	}
+	if _, err := NewPermissions(false); err != nil {
+		return fmt.Errorf("permissions: %s", err)
+	}
+	if *access_policy != "" {
+		if _, err := LoadAccessRules(*access_policy); err != nil {
+			return fmt.Errorf("access policy: %s, %s", *access_policy, err)
+		}
+	}
+	if *audit_read_sample < 0 || *audit_read_sample > 1 {
+		return InvalidAuditSampleErr
+	}
+	return nil
+}
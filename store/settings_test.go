@@ -0,0 +1,211 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func NewTestSettings(t *testing.T, content string) (*Settings, error) {
+	file, err := ioutil.TempFile("", "settings")
+	if err != nil {
+		t.Fatalf("failed to create the settings file, error: %s", err)
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.WriteString(content); err != nil {
+		t.Fatalf("failed to write the settings file, error: %s", err)
+	}
+	file.Close()
+	return LoadSettings(file.Name())
+}
+
+func TestLoadSettings(t *testing.T) {
+	tests := []struct {
+		Content string
+		Flags   map[string][]string
+		Mounts  int
+		Error   string
+	}{
+		{Content: "", Flags: map[string][]string{}},
+		{
+			Content: `kv = "consul://127.0.0.1:8500" # the backend
+kv-timeout = "5s"
+writable = true
+uid = 1000
+audit-read-sample = 0.25
+redact = ["/secrets/**", "/prod/db/password"]
+layer = "/defaults/app"`,
+			Flags: map[string][]string{
+				"kv":                {"consul://127.0.0.1:8500"},
+				"kv-timeout":        {"5s"},
+				"writable":          {"true"},
+				"uid":               {"1000"},
+				"audit-read-sample": {"0.25"},
+				"redact":            {"/secrets/**", "/prod/db/password"},
+				"layer":             {"/defaults/app"}},
+		},
+		{
+			Content: "[consul]\ntoken = 'secret#1'\ndc = \"eu-west\"\n[etcd]\nusername = \"root\"\n[log]\nv = 3\nlog_dir = \"/var/log\"",
+			Flags: map[string][]string{
+				"consul-token":  {"secret#1"},
+				"consul-dc":     {"eu-west"},
+				"etcd-username": {"root"},
+				"v":             {"3"},
+				"log_dir":       {"/var/log"}},
+		},
+		{
+			Content: "[[mounts]]\nmount = \"/data/payments\"\nprefix = \"/prod/payments\"\nlayers = [\"/a\", \"/b\"]\n[[mounts]]\nmount = \"/data/orders\"",
+			Flags:   map[string][]string{},
+			Mounts:  2,
+		},
+		{Content: "kv = consul", Error: "line 1"},
+		{Content: "writable = \"yes\"", Error: "writable"},
+		{Content: "redact = [1, 2]", Error: InvalidSettingErr.Error()},
+		{Content: "unknown = \"value\"", Error: "unknown settings: unknown"},
+		{Content: "[consul]\ntokens = \"secret\"", Error: "unknown settings: consul.tokens"},
+		{Content: "[[mounts]]\nmount = \"/data\"\nbackend = \"mem://\"", Error: "unknown settings: mounts.backend"},
+		{Content: "kv = \"mem://\"\nkv = \"file:///tmp\"", Error: "line 2"},
+	}
+	for _, test := range tests {
+		settings, err := NewTestSettings(t, test.Content)
+		if test.Error != "" {
+			if err == nil || !strings.Contains(err.Error(), test.Error) {
+				t.Errorf("settings: %q, expected the error: %s, got: %v", test.Content, test.Error, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("settings: %q, unexpected error: %s", test.Content, err)
+			continue
+		}
+		flags := make(map[string][]string, 0)
+		for _, setting := range settings.Flags {
+			flags[setting.Name] = setting.Values
+		}
+		if !reflect.DeepEqual(flags, test.Flags) {
+			t.Errorf("settings: %q, expected the flags: %v, got: %v", test.Content, test.Flags, flags)
+		}
+		if len(settings.Mounts) != test.Mounts {
+			t.Errorf("settings: %q, expected %d mounts, got: %d", test.Content, test.Mounts, len(settings.Mounts))
+		}
+	}
+}
+
+func TestLoadSettingsMounts(t *testing.T) {
+	settings, err := NewTestSettings(t, "[[mounts]]\nmount = \"/data/payments\"\nkv = \"mem://\"\nprefix = \"/prod/payments\"\nlayers = [\"/a\", \"/b\"]\nmode = \"rw\"\ncache = \"30s\"")
+	if err != nil {
+		t.Fatalf("failed to load the settings, error: %s", err)
+	}
+	expected := &MountOptions{Mount: "/data/payments", Backend: "mem://", Prefix: "/prod/payments", Layers: []string{"/a", "/b"}, Mode: MOUNT_READ_WRITE, Cache: "30s"}
+	if len(settings.Mounts) != 1 || !reflect.DeepEqual(settings.Mounts[0], expected) {
+		t.Fatalf("expected the mount: %v, got: %v", expected, settings.Mounts)
+	}
+	if err := settings.ParseMounts(); err != nil || !settings.Mounts[0].Writable || settings.Mounts[0].CacheTTL != 30*time.Second {
+		t.Errorf("expected the mount to parse, got: %v, error: %v", settings.Mounts[0], err)
+	}
+}
+
+/* The calls read the runtime settings, which only change once the applied settings are loaded */
+func TestSettingsApply(t *testing.T) {
+	LoadRuntimeSettings()
+	before := CurrentSettings()
+	settings := &Settings{File: "test.toml", Flags: []*Setting{
+		{Name: "kv-timeout", Values: []string{"3s"}},
+		{Name: "layer", Values: []string{"/a", "/b"}},
+		{Name: "prefix", Values: []string{"/prod"}}}}
+	restore, err := settings.Apply(map[string]bool{"prefix": true})
+	if err != nil {
+		t.Fatalf("failed to apply the settings, error: %s", err)
+	}
+	if *backend_read_timeout != 3*time.Second || !reflect.DeepEqual(union_layers.Values(), []string{"/a", "/b"}) {
+		t.Errorf("expected the flags to be set, timeout: %s, layers: %v", *backend_read_timeout, union_layers)
+	}
+	if *mount_prefix != "/" {
+		t.Errorf("expected the flag given on the command line to be kept, got: %s", *mount_prefix)
+	}
+	if CurrentSettings() != before {
+		t.Errorf("expected the runtime settings to be kept until they're loaded")
+	}
+	LoadRuntimeSettings()
+	if CurrentSettings().ReadTimeout != 3*time.Second {
+		t.Errorf("expected the new read timeout, got: %s", CurrentSettings().ReadTimeout)
+	}
+	restore()
+	LoadRuntimeSettings()
+	if *backend_read_timeout != before.ReadTimeout || len(union_layers) != 0 || CurrentSettings().ReadTimeout != before.ReadTimeout {
+		t.Errorf("expected the flags to be restored, timeout: %s, layers: %v", *backend_read_timeout, union_layers)
+	}
+	/* step: nothing is changed if a setting is invalid or isn't a flag */
+	invalid := []*Settings{
+		{File: "test.toml", Flags: []*Setting{{Name: "kv-timeout", Values: []string{"3s"}}, {Name: "kv-write-timeout", Values: []string{"never"}}}},
+		{File: "test.toml", Flags: []*Setting{{Name: "kv-timeout", Values: []string{"3s"}}, {Name: "no-such-flag", Values: []string{"1"}}}},
+	}
+	for _, settings := range invalid {
+		if _, err := settings.Apply(nil); err == nil {
+			t.Errorf("expected an error applying: %v", settings.Flags[1])
+		}
+		if *backend_read_timeout != before.ReadTimeout {
+			t.Errorf("expected the flags to be put back, timeout: %s", *backend_read_timeout)
+		}
+	}
+	if flag.Lookup("kv-timeout").Value.String() != before.ReadTimeout.String() {
+		t.Errorf("expected the flag to be as it was")
+	}
+}
+
+/* A key taken out of the file puts the flag back to its default, unless it was given on the command line */
+func TestSettingsApplyResetsRemovedKeys(t *testing.T) {
+	first := &Settings{File: "test.toml", Flags: []*Setting{
+		{Name: "kv-timeout", Values: []string{"3s"}},
+		{Name: "cache", Values: []string{"30s"}},
+		{Name: "layer", Values: []string{"/a"}},
+		{Name: "prefix", Values: []string{"/prod"}}}}
+	restore, err := first.Apply(nil)
+	if err != nil {
+		t.Fatalf("failed to apply the settings, error: %s", err)
+	}
+	defer restore()
+	*cache_policy = "1m"
+	second := &Settings{File: "test.toml", Flags: []*Setting{{Name: "kv-timeout", Values: []string{"4s"}}}}
+	undo, err := second.Apply(map[string]bool{"cache": true})
+	if err != nil {
+		t.Fatalf("failed to apply the settings, error: %s", err)
+	}
+	tests := []struct {
+		Name  string
+		Value string
+	}{
+		{"kv-timeout", "4s"},
+		{"cache", "1m"},
+		{"prefix", flag.Lookup("prefix").DefValue},
+		{"layer", ""},
+	}
+	for _, test := range tests {
+		if value := strings.Join(FlagValues(flag.Lookup(test.Name)), ","); value != test.Value {
+			t.Errorf("flag: %s, expected: %q, got: %q", test.Name, test.Value, value)
+		}
+	}
+	/* step: putting the flags back undoes the resets as well */
+	undo()
+	if *mount_prefix != "/prod" || !reflect.DeepEqual(union_layers.Values(), []string{"/a"}) || *backend_read_timeout != 3*time.Second {
+		t.Errorf("expected the flags to be put back, prefix: %s, layers: %v, timeout: %s", *mount_prefix, union_layers, *backend_read_timeout)
+	}
+	*cache_policy = CACHE_WATCH
+}
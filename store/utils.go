@@ -14,45 +14,253 @@ limitations under the License.
 package store
 
 import (
+	"encoding/json"
 	"errors"
+	"io/ioutil"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/gambol99/config-store/store/cache"
 	"github.com/gambol99/config-store/store/config"
-	"github.com/hanwen/go-fuse/fuse/pathfs"
+	"github.com/hanwen/go-fuse/v2/fuse/pathfs"
 	"github.com/golang/glog"
 )
 
-func NewFuseKVFileSystem() (pathfs.FileSystem, error) {
-	glog.Infof("Creating a new K/V FileSystem, backend: %s", *backend_kv_url)
+func NewFuseKVFileSystem(options *MountOptions) (*FuseKVFileSystem, error) {
+	glog.Infof("Creating a new K/V FileSystem, %s", options)
+	shared, err := SharedServices()
+	if err != nil {
+		return nil, err
+	}
+	kv_agent, err := NewLayeredStore(shared, options.Backend, options.Layers)
+	if err != nil {
+		glog.Errorf("Failed to create the K/V agent for filesystem, error: %s", err)
+		shared.Release()
+		return nil, err
+	}
+	kv_agent = config.NewPrefixStore(kv_agent, options.Prefix)
+	permissions, err := NewPermissions(options.Writable)
+	if err != nil {
+		shared.Release()
+		return nil, err
+	}
+	shutdown := make(chan bool)
+	fs := &FuseKVFileSystem{pathfs.NewDefaultFileSystem(),
+		cache.NewCacheStore(shutdown),kv_agent,
+		time.Now(),make(map[string]time.Time,0),
+		BackendName(options.Backend),permissions,shared.Policy.ForMount(options.Prefix),shared.Auditor.ForMount(options.Prefix),options,nil,nil,shutdown,
+		shared,new(sync.WaitGroup)}
+
+	/* step: start the node watcher */
+	fs.NodeWatcher()
+	return fs, nil
+}
+
+/*
+The backends, access policy and audit log are the same for every mount, so they are only created
+the once, or again after a reload; each mount checks the policy against its prefix in the backend.
+The services replaced by a reload are closed once the filesystems using them are done with them
+*/
+type Shared struct {
+	sync.Mutex
+	/* the backends by url, so the mounts on the same backend share the client and its connections */
+	Backends map[string]config.KVStore
+	/* the access policy, nil if everything is allowed */
+	Policy *AccessPolicy
+	/* the audit log, nil if not auditing */
+	Auditor *Auditor
+	/* the filesystems using the services */
+	Users sync.WaitGroup
+}
+
+var shared_services struct {
+	sync.Mutex
+	Current *Shared
+}
+
+/* The current services, which the caller must release once done with them */
+func SharedServices() (*Shared, error) {
+	shared_services.Lock()
+	defer shared_services.Unlock()
+	if shared_services.Current == nil {
+		auditor, err := NewAuditor()
+		if err != nil {
+			return nil, err
+		}
+		policy, err := NewAccessPolicy(auditor)
+		if err != nil {
+			auditor.Close()
+			return nil, err
+		}
+		shared_services.Current = &Shared{Backends: make(map[string]config.KVStore, 0), Policy: policy, Auditor: auditor}
+	}
+	shared_services.Current.Users.Add(1)
+	return shared_services.Current, nil
+}
+
+/*
+Forget the shared backends, access policy and audit log, so the mounts created from here on get new
+ones from the flags; the mounts already created keep the ones they have, which are closed once the
+last of them is done
+*/
+func ResetSharedServices() {
+	shared_services.Lock()
+	previous := shared_services.Current
+	shared_services.Current = nil
+	shared_services.Unlock()
+	if previous != nil {
+		go func() {
+			previous.Users.Wait()
+			previous.Close()
+		}()
+	}
+}
+
+/* The backend of the url, created on first use */
+func (r *Shared) KVStore(backend string) (config.KVStore, error) {
+	r.Lock()
+	defer r.Unlock()
+	if store, found := r.Backends[backend]; found {
+		return store, nil
+	}
+	store, err := NewKVStore(backend)
+	if err != nil {
+		return nil, err
+	}
+	r.Backends[backend] = store
+	return store, nil
+}
+
+func (r *Shared) Release() {
+	r.Users.Done()
+}
+
+func (r *Shared) Close() {
+	r.Lock()
+	defer r.Unlock()
+	for backend, store := range r.Backends {
+		glog.V(3).Infof("Closing the backend: %s", BackendName(backend))
+		if err := store.Close(); err != nil {
+			glog.Errorf("Failed to close the backend: %s, error: %s", BackendName(backend), err)
+		}
+	}
+	if err := r.Auditor.Close(); err != nil {
+		glog.Errorf("Failed to close the audit log, error: %s", err)
+	}
+}
+
+func NewKVStore(backend string) (config.KVStore, error) {
 	/* step: parse the url and make sure it's valid */
-	uri, err := url.Parse(*backend_kv_url)
+	uri, err := ParseBackend(backend)
 	if err != nil {
-		glog.Errorf("Failed to parse the url: %s, probably invalid, error: %s", *backend_kv_url, err)
+		glog.Errorf("Failed to parse the backend url, probably invalid, error: %s", err)
 		return nil, err
 	}
 	/* step: create a backend K/V client */
-	var kv_agent config.KVStore
+	var store config.KVStore
 	switch uri.Scheme {
 	case "etcd":
-		kv_agent, err = config.NewEtcdStoreClient(uri)
+		store, err = config.NewEtcdStoreClient(uri)
+	case "etcd3":
+		store, err = config.NewEtcd3StoreClient(uri)
 	case "consul":
-		kv_agent, err = config.NewConsulStoreClient(uri)
+		store, err = config.NewConsulStoreClient(uri)
+	case "file":
+		store, err = config.NewFileStoreClient(uri)
+	case "mem":
+		store, err = config.NewMemoryStoreClient(uri)
+	case "vault":
+		store, err = config.NewVaultStoreClient(uri)
 	default:
-		glog.Errorf("Invalid backend url: %s, unsupported provider, please check usage", *backend_kv_url)
-		return nil, errors.New("Unsupported backend k/v provider: " + *backend_kv_url)
+		glog.Errorf("Invalid backend url, unsupported provider: %s, please check usage", uri.Scheme)
+		return nil, errors.New("Unsupported backend k/v provider: " + uri.Scheme)
 	}
-	/* step: validate the error */
 	if err != nil {
-		glog.Errorf("Failed to create the K/V agent for filesystem, error: %s", err)
 		return nil, err
 	}
-	fs := &FuseKVFileSystem{pathfs.NewDefaultFileSystem(),
-		cache.NewCacheStore(),kv_agent,
-		time.Now(),make(map[string]time.Time,0)}
+	/* step: count and time the calls to the backend */
+	store = config.NewMeteredStore(store, uri.Scheme)
+	/* step: wrap the backend if any of the values are encrypted */
+	return config.EncryptStore(store)
+}
 
-	/* step: start the node watcher */
-	fs.NodeWatcher()
-	return fs, nil
+/* Check the url is one of the backends we know, without connecting to it */
+func ValidBackend(backend string) error {
+	uri, err := ParseBackend(backend)
+	if err != nil {
+		return err
+	}
+	switch uri.Scheme {
+	case "etcd", "etcd3", "consul", "file", "mem", "vault":
+		return nil
+	}
+	return errors.New("Unsupported backend k/v provider: " + uri.Scheme)
+}
+
+/* Parse the backend url; a parse error is stripped of the url, as it can carry the credentials */
+func ParseBackend(backend string) (*url.URL, error) {
+	uri, err := url.Parse(backend)
+	if err, ok := err.(*url.Error); ok {
+		return nil, err.Err
+	}
+	return uri, err
+}
+
+/* The backend url without the credentials or options, which could carry a token */
+func BackendName(backend string) string {
+	uri, err := url.Parse(backend)
+	if err != nil {
+		return ""
+	}
+	return (&url.URL{Scheme: uri.Scheme, Host: uri.Host, Path: uri.Path}).String()
+}
+
+/*
+Import a JSON tree into the store under the path as a single transaction, i.e. a service's
+config is changed in one go or not at all. With prune, any keys under the path which aren't
+in the file are removed as part of the same transaction
+*/
+func ImportFile(filename, path string, prune bool) error {
+	glog.Infof("Importing the file: %s into path: %s, backend: %s", filename, path, BackendName(*backend_kv_url))
+	kv_agent, err := NewKVStore(*backend_kv_url)
+	if err != nil {
+		return err
+	}
+	defer kv_agent.Close()
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		glog.Errorf("Failed to read the import file: %s, error: %s", filename, err)
+		return err
+	}
+	var tree map[string]interface{}
+	if err := json.Unmarshal(content, &tree); err != nil {
+		glog.Errorf("Failed to parse the import file: %s, error: %s", filename, err)
+		return err
+	}
+	ctx, cancel := WriteContext(nil)
+	defer cancel()
+	operations := config.TreeOperations(path, tree)
+	if prune {
+		imported := make(map[string]bool, 0)
+		for _, operation := range operations {
+			imported[operation.Key] = true
+		}
+		existing, err := config.Walk(ctx, kv_agent, path)
+		if err != nil && err != config.NodeNotFoundErr {
+			glog.Errorf("Failed to read the keys under path: %s, error: %s", path, err)
+			return err
+		}
+		for _, node := range existing {
+			if !imported[node.Path] {
+				operations = append(operations, &config.Operation{Key: node.Path, Delete: true})
+			}
+		}
+	}
+	if err := kv_agent.Txn(ctx, operations); err != nil {
+		glog.Errorf("Failed to import the file: %s, error: %s", filename, err)
+		return err
+	}
+	glog.Infof("Imported %d operations from the file: %s", len(operations), filename)
+	return nil
 }